@@ -1,24 +1,33 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 	"news-release/internal/article/dto"
 	"news-release/internal/article/model"
 	"news-release/internal/article/service"
+	translationsvc "news-release/internal/translation/service"
 	"news-release/internal/utils"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
+// translatedMetaMaxLength 使用译文时SEO描述的最大字数
+const translatedMetaMaxLength = 300
+
 // ArticleController 控制器
 type ArticleController struct {
-	articleService service.ArticleService
+	articleService     service.ArticleService
+	engagementService  service.ArticleEngagementService
+	translationService translationsvc.TranslationService
 }
 
 // NewArticleController 创建控制器实例
-func NewArticleController(articleService service.ArticleService) *ArticleController {
-	return &ArticleController{articleService: articleService}
+func NewArticleController(articleService service.ArticleService, engagementService service.ArticleEngagementService, translationService translationsvc.TranslationService) *ArticleController {
+	return &ArticleController{articleService: articleService, engagementService: engagementService, translationService: translationService}
 }
 
 // ListArticle 分页查询
@@ -29,20 +38,48 @@ func (ctr *ArticleController) ListArticle(ctx *gin.Context) {
 		return
 	}
 
-	// page 默认1
-	page := req.Page
-	if page == 0 {
-		page = 1
+	// 页码分页与游标分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
-	// pageSize 默认10
-	pageSize := req.PageSize
-	if pageSize == 0 {
-		pageSize = 10
+	// 调用服务层
+	results, err := ctr.articleService.ListArticle(ctx, pg, req)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 替换为请求语言的译文
+	if err := ctr.localizeArticles(ctx, results); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回分页结果
+	ctx.JSON(http.StatusOK, pg.Response(results))
+}
+
+// ListAllArticles 分页查询全部状态的文章（管理端）
+func (ctr *ArticleController) ListAllArticles(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.ArticleListRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// 页码分页与游标分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
 	// 调用服务层
-	results, total, err := ctr.articleService.ListArticle(ctx, page, pageSize, req.ArticleTitle, req.ArticleType, req.ReleaseTime, req.FieldType, req.IsSelection, req.QueryScope)
+	results, err := ctr.articleService.ListAllArticles(ctx, pg, req)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
@@ -50,16 +87,93 @@ func (ctr *ArticleController) ListArticle(ctx *gin.Context) {
 	}
 
 	// 返回分页结果
-	ctx.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      results,
-	})
+	ctx.JSON(http.StatusOK, pg.Response(results))
 }
 
-// GetArticleContent 获取文章内容
+// GetArticleContent 获取文章内容，支持按数字ID或别名查询
 func (ctr *ArticleController) GetArticleContent(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.ArticleKeyRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 调用服务层，别名不允许为纯数字，因此纯数字按ID查询
+	var result *dto.ArticleContentResponse
+	var err error
+	if articleID, convErr := strconv.Atoi(req.Key); convErr == nil {
+		result, err = ctr.articleService.GetArticleContent(ctx, articleID)
+	} else {
+		result, err = ctr.articleService.GetArticleContentBySlug(ctx, req.Key)
+	}
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 登录用户按用户ID统计浏览量并返回点赞、收藏状态，匿名用户按IP统计
+	userID, _ := utils.GetUserID(ctx)
+	counted, err := ctr.engagementService.RecordView(ctx, result.ArticleID, userID, ctx.ClientIP())
+	if err != nil {
+		logrus.Errorf("记录文章浏览失败: %v", err) // 只记录异常，不影响文章内容的返回
+	}
+	if counted {
+		result.ViewCount++
+	}
+
+	// 替换为请求语言的译文
+	if err := ctr.localizeArticleContent(ctx, result); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	if userID != 0 {
+		engagement, err := ctr.engagementService.GetEngagement(ctx, result.ArticleID, userID)
+		if err != nil {
+			utils.WrapErrorHandler(ctx, err)
+			return
+		}
+		result.IsLiked = engagement.IsLiked
+		result.IsFavorited = engagement.IsFavorited
+	}
+
+	// 返回成功响应
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// ListRelatedArticles 查询相关文章
+func (ctr *ArticleController) ListRelatedArticles(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 绑定查询参数
+	var req dto.RelatedArticleRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// 调用服务层
+	results, err := ctr.articleService.ListRelatedArticles(ctx, urlReq.ArticleID, req.Limit)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 替换为请求语言的译文
+	if err := ctr.localizeArticles(ctx, results); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回成功响应
+	ctx.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// PreviewArticle 预览任意状态的文章内容（管理端）
+func (ctr *ArticleController) PreviewArticle(ctx *gin.Context) {
 	// 初始化参数结构体并绑定查询参数
 	var req dto.ArticleContentRequest
 	if !utils.BindUrl(ctx, &req) {
@@ -67,7 +181,7 @@ func (ctr *ArticleController) GetArticleContent(ctx *gin.Context) {
 	}
 
 	// 调用服务层
-	result, err := ctr.articleService.GetArticleContent(ctx, req.ArticleID)
+	result, err := ctr.articleService.PreviewArticle(ctx, req.ArticleID)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
@@ -93,23 +207,36 @@ func (ctr *ArticleController) CreateArticle(ctx *gin.Context) {
 		return
 	}
 
+	// 计划发布时间，未指定时默认为当前时间，审核通过后立即发布
+	releaseTime := time.Now()
+	if req.ReleaseTime != "" {
+		releaseTime, err = utils.StringToTime(req.ReleaseTime)
+		if err != nil {
+			utils.WrapErrorHandler(ctx, err)
+			return
+		}
+	}
+
 	// 构造文章对象
 	article := &model.Article{
-		ArticleTitle:   req.ArticleTitle,
-		ArticleType:    req.ArticleType,
-		BriefContent:   req.BriefContent,
-		ArticleContent: req.ArticleContent,
-		IsSelection:    req.IsSelection,
-		FieldType:      req.FieldType,
-		CoverImageURL:  req.CoverImageURL,
-		ArticleSource:  req.ArticleSource,
-		ReleaseTime:    time.Now(),
-		CreateUser:     userID,
-		UpdateUser:     userID,
+		ArticleTitle:    req.ArticleTitle,
+		ArticleType:     req.ArticleType,
+		BriefContent:    req.BriefContent,
+		ArticleContent:  req.ArticleContent,
+		IsSelection:     req.IsSelection,
+		FieldType:       req.FieldType,
+		CoverImageURL:   req.CoverImageURL,
+		ArticleSource:   req.ArticleSource,
+		Slug:            req.Slug,
+		MetaDescription: req.MetaDescription,
+		OgImageURL:      req.OgImageURL,
+		ReleaseTime:     releaseTime,
+		CreateUser:      userID,
+		UpdateUser:      userID,
 	}
 
 	// 调用服务层
-	if err := ctr.articleService.CreateArticle(ctx, article, req.ImageIDList); err != nil {
+	if err := ctr.articleService.CreateArticle(ctx, article, req.ImageIDList, req.TagIDList); err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
@@ -185,3 +312,325 @@ func (ctr *ArticleController) DeleteArticle(ctx *gin.Context) {
 		"message": "文章删除成功",
 	})
 }
+
+// RestoreArticle 处理恢复文章的请求
+func (ctr *ArticleController) RestoreArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var req dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层恢复文章
+	err = ctr.articleService.RestoreArticle(ctx, req.ArticleID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章恢复成功",
+	})
+}
+
+// SubmitArticle 处理提交文章审核的请求
+func (ctr *ArticleController) SubmitArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var req dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层提交审核
+	err = ctr.articleService.SubmitArticle(ctx, req.ArticleID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章已提交审核",
+	})
+}
+
+// ApproveArticle 处理审核通过文章的请求
+func (ctr *ArticleController) ApproveArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 从请求体获取审核意见
+	var req dto.ApproveArticleRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层审核通过
+	err = ctr.articleService.ApproveArticle(ctx, urlReq.ArticleID, req.Comment, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章审核通过",
+	})
+}
+
+// RejectArticle 处理驳回文章的请求
+func (ctr *ArticleController) RejectArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 从请求体获取驳回意见
+	var req dto.RejectArticleRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层驳回文章
+	err = ctr.articleService.RejectArticle(ctx, urlReq.ArticleID, req.Comment, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章已驳回",
+	})
+}
+
+// ScheduleArticle 处理设置文章定时发布的请求
+func (ctr *ArticleController) ScheduleArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 从请求体获取计划发布时间
+	var req dto.ScheduleArticleRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 转换时间格式
+	releaseTime, err := utils.StringToTime(req.ReleaseTime)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层设置发布时间
+	err = ctr.articleService.ScheduleArticle(ctx, urlReq.ArticleID, releaseTime, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章发布时间设置成功",
+	})
+}
+
+// ArchiveArticle 处理归档文章的请求
+func (ctr *ArticleController) ArchiveArticle(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var req dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层归档文章
+	err = ctr.articleService.ArchiveArticle(ctx, req.ArticleID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章归档成功",
+	})
+}
+
+// LikeArticle 点赞文章
+func (ctr *ArticleController) LikeArticle(ctx *gin.Context) {
+	ctr.handleEngagement(ctx, ctr.engagementService.LikeArticle)
+}
+
+// UnlikeArticle 取消点赞
+func (ctr *ArticleController) UnlikeArticle(ctx *gin.Context) {
+	ctr.handleEngagement(ctx, ctr.engagementService.UnlikeArticle)
+}
+
+// FavoriteArticle 收藏文章
+func (ctr *ArticleController) FavoriteArticle(ctx *gin.Context) {
+	ctr.handleEngagement(ctx, ctr.engagementService.FavoriteArticle)
+}
+
+// UnfavoriteArticle 取消收藏
+func (ctr *ArticleController) UnfavoriteArticle(ctx *gin.Context) {
+	ctr.handleEngagement(ctx, ctr.engagementService.UnfavoriteArticle)
+}
+
+// handleEngagement 处理点赞/收藏类请求，返回操作后的点赞、收藏数据
+func (ctr *ArticleController) handleEngagement(ctx *gin.Context, action func(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)) {
+	// 从URL获取文章ID
+	var req dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层
+	result, err := action(ctx, req.ArticleID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// ListUserFavorites 分页查询当前用户收藏的文章
+func (ctr *ArticleController) ListUserFavorites(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.UserFavoritesRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层
+	results, total, err := ctr.engagementService.ListUserFavorites(ctx, page, pageSize, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回分页结果
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// localizeArticles 将文章列表的标题和摘要替换为请求语言的译文，缺少译文的文章保留原文
+func (ctr *ArticleController) localizeArticles(ctx *gin.Context, articles []dto.ArticleListResponse) error {
+	articleIDs := make([]int, 0, len(articles))
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ArticleID)
+	}
+	translations, err := ctr.translationService.Localize(ctx, utils.TypeArticle, utils.GetLang(ctx), articleIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range articles {
+		articles[i].Lang = ctr.translationService.DefaultLang()
+		if translation, ok := translations[articles[i].ArticleID]; ok {
+			articles[i].ArticleTitle = translation.Title
+			articles[i].BriefContent = translation.Brief
+			articles[i].Lang = translation.Lang
+		}
+	}
+	return nil
+}
+
+// localizeArticleContent 将文章的标题、摘要和正文替换为请求语言的译文，缺少译文时保留原文
+// 原文的SEO描述为原文语言，使用译文时改取译文摘要
+func (ctr *ArticleController) localizeArticleContent(ctx *gin.Context, article *dto.ArticleContentResponse) error {
+	translations, err := ctr.translationService.Localize(ctx, utils.TypeArticle, utils.GetLang(ctx), []int{article.ArticleID})
+	if err != nil {
+		return err
+	}
+
+	article.Lang = ctr.translationService.DefaultLang()
+	if translation, ok := translations[article.ArticleID]; ok {
+		article.ArticleTitle = translation.Title
+		article.BriefContent = translation.Brief
+		article.ArticleContent = translation.Content
+		article.MetaDescription = utils.TruncateRunes(translation.Brief, translatedMetaMaxLength)
+		article.Lang = translation.Lang
+	}
+	return nil
+}
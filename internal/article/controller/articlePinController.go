@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArticlePinController 文章置顶控制器
+type ArticlePinController struct {
+	pinService service.ArticlePinService
+}
+
+// NewArticlePinController 创建控制器实例
+func NewArticlePinController(pinService service.ArticlePinService) *ArticlePinController {
+	return &ArticlePinController{pinService: pinService}
+}
+
+// ListPins 查询指定列表的置顶（管理端）
+func (ctr *ArticlePinController) ListPins(ctx *gin.Context) {
+	var req dto.ListPinsRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	pins, err := ctr.pinService.ListPins(ctx, req)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": pins})
+}
+
+// CreatePin 置顶文章（管理端）
+func (ctr *ArticlePinController) CreatePin(ctx *gin.Context) {
+	var req dto.CreatePinRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	pinID, err := ctr.pinService.CreatePin(ctx, req, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "文章置顶成功", "id": pinID})
+}
+
+// UpdatePin 更新置顶的排序值和到期时间（管理端）
+func (ctr *ArticlePinController) UpdatePin(ctx *gin.Context) {
+	var urlReq dto.PinUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	var req dto.UpdatePinRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.pinService.UpdatePin(ctx, urlReq.ID, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "置顶更新成功"})
+}
+
+// DeletePin 取消置顶（管理端）
+func (ctr *ArticlePinController) DeletePin(ctx *gin.Context) {
+	var urlReq dto.PinUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	if err := ctr.pinService.DeletePin(ctx, urlReq.ID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "已取消置顶"})
+}
+
+// ReorderPins 调整列表内置顶的顺序（管理端）
+func (ctr *ArticlePinController) ReorderPins(ctx *gin.Context) {
+	var req dto.ReorderPinRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.pinService.ReorderPins(ctx, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "置顶排序成功"})
+}
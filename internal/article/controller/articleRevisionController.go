@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleRevisionController 文章修订记录控制器
+type ArticleRevisionController struct {
+	revisionService service.ArticleRevisionService
+}
+
+// NewArticleRevisionController 创建控制器实例
+func NewArticleRevisionController(revisionService service.ArticleRevisionService) *ArticleRevisionController {
+	return &ArticleRevisionController{revisionService: revisionService}
+}
+
+// ListRevisions 分页查询文章的修订记录
+func (ctr *ArticleRevisionController) ListRevisions(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 绑定分页参数
+	var req dto.ListRevisionRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 调用服务层
+	results, total, err := ctr.revisionService.ListRevisions(ctx, page, pageSize, urlReq.ArticleID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// DiffRevisions 对比文章的两个修订版本
+func (ctr *ArticleRevisionController) DiffRevisions(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 绑定对比的修订ID
+	var req dto.RevisionDiffRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// 调用服务层
+	result, err := ctr.revisionService.DiffRevisions(ctx, urlReq.ArticleID, req.FromID, req.ToID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// RestoreRevision 将文章回滚到指定的修订版本
+func (ctr *ArticleRevisionController) RestoreRevision(ctx *gin.Context) {
+	// 从URL获取文章ID
+	var urlReq dto.ArticleContentRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 从请求体获取修订ID
+	var req dto.RestoreRevisionRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层
+	err = ctr.revisionService.RestoreRevision(ctx, urlReq.ArticleID, req.RevisionID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "文章已回滚到指定版本",
+	})
+}
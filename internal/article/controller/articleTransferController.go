@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// importMaxFileSize 导入文件的最大字节数
+const importMaxFileSize = 200 << 20
+
+// exportContentTypes 各导出格式的响应类型
+var exportContentTypes = map[string]string{
+	dto.TransferFormatJSONL: "application/x-ndjson; charset=utf-8",
+	dto.TransferFormatCSV:   "text/csv; charset=utf-8",
+	dto.TransferFormatZIP:   "application/zip",
+}
+
+// ArticleTransferController 文章批量导入导出控制器
+type ArticleTransferController struct {
+	transferService service.ArticleTransferService
+}
+
+// NewArticleTransferController 创建控制器实例
+func NewArticleTransferController(transferService service.ArticleTransferService) *ArticleTransferController {
+	return &ArticleTransferController{transferService: transferService}
+}
+
+// ImportArticles 批量导入文章
+func (ctr *ArticleTransferController) ImportArticles(ctx *gin.Context) {
+	var req dto.ImportArticleRequest
+	if !utils.BindForm(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if req.File.Size > importMaxFileSize {
+		utils.WrapErrorHandler(ctx, utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("导入文件大小不能超过%dMB", importMaxFileSize>>20)))
+		return
+	}
+
+	// 保存临时文件
+	tempFilePath := filepath.Join(os.TempDir(), uuid.New().String())
+	if err := ctx.SaveUploadedFile(req.File, tempFilePath); err != nil {
+		utils.WrapErrorHandler(ctx, utils.NewSystemError(fmt.Errorf("保存临时文件失败: %w", err)))
+		return
+	}
+	defer os.Remove(tempFilePath)
+
+	onDuplicate := req.OnDuplicate
+	if onDuplicate == "" {
+		onDuplicate = dto.DuplicateError
+	}
+
+	file := service.ImportFile{FileName: req.File.Filename, Path: tempFilePath}
+	result, err := ctr.transferService.ImportArticles(ctx, file, req.DryRun, onDuplicate, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	message := "文章导入完成"
+	if req.DryRun {
+		message = "文章导入校验完成"
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data":    result,
+	})
+}
+
+// ExportArticles 批量导出文章，以附件形式下载
+func (ctr *ArticleTransferController) ExportArticles(ctx *gin.Context) {
+	var req dto.ExportArticleRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	fileName := fmt.Sprintf("articles-%s.%s", time.Now().Format("20060102150405"), req.Format)
+	ctx.Header("Content-Type", exportContentTypes[req.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	if err := ctr.transferService.ExportArticles(ctx, ctx.Writer, req); err != nil {
+		// 尚未输出内容时返回错误信息，否则只能中断下载并记录日志
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Disposition")
+			utils.WrapErrorHandler(ctx, err)
+			return
+		}
+		logrus.Errorf("导出文章失败: %v", err)
+		_ = ctx.Error(err)
+	}
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArticleTypeController 控制器
+type ArticleTypeController struct {
+	articleTypeService service.ArticleTypeService
+}
+
+// NewArticleTypeController 创建控制器实例
+func NewArticleTypeController(articleTypeService service.ArticleTypeService) *ArticleTypeController {
+	return &ArticleTypeController{articleTypeService: articleTypeService}
+}
+
+// ListArticleTypes 获取文章类型列表
+func (ctr *ArticleTypeController) ListArticleTypes(ctx *gin.Context) {
+	articleTypes, err := ctr.articleTypeService.ListArticleTypes(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	list := make([]dto.ListArticleTypesResponse, 0, len(articleTypes))
+	for _, articleType := range articleTypes {
+		list = append(list, dto.ListArticleTypesResponse{
+			ID:        articleType.ID,
+			TypeCode:  articleType.TypeCode,
+			TypeName:  articleType.TypeName,
+			SortOrder: articleType.SortOrder,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// CreateArticleType 创建文章类型
+func (ctr *ArticleTypeController) CreateArticleType(ctx *gin.Context) {
+	var req dto.CreateArticleTypeRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	articleType := &model.ArticleType{
+		TypeCode:   req.TypeCode,
+		TypeName:   req.TypeName,
+		SortOrder:  req.SortOrder,
+		CreateUser: userID,
+		UpdateUser: userID,
+	}
+
+	if err := ctr.articleTypeService.CreateArticleType(ctx, articleType); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "文章类型创建成功", "id": articleType.ID})
+}
+
+// UpdateArticleType 更新文章类型
+func (ctr *ArticleTypeController) UpdateArticleType(ctx *gin.Context) {
+	var urlReq dto.ArticleTypeUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	var req dto.UpdateArticleTypeRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.articleTypeService.UpdateArticleType(ctx, urlReq.ID, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "文章类型更新成功"})
+}
+
+// DeleteArticleType 删除文章类型
+func (ctr *ArticleTypeController) DeleteArticleType(ctx *gin.Context) {
+	var urlReq dto.ArticleTypeUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	var req dto.DeleteTypeRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.articleTypeService.DeleteArticleType(ctx, urlReq.ID, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "文章类型删除成功"})
+}
+
+// ReorderArticleTypes 调整文章类型顺序
+func (ctr *ArticleTypeController) ReorderArticleTypes(ctx *gin.Context) {
+	var req dto.ReorderTypeRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.articleTypeService.ReorderArticleTypes(ctx, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "文章类型排序成功"})
+}
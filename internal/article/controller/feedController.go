@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// feedCacheMaxAge 订阅源允许客户端缓存的时间（秒）
+const feedCacheMaxAge = 300
+
+// FeedController 文章订阅源控制器
+type FeedController struct {
+	feedService service.FeedService
+}
+
+// NewFeedController 创建控制器实例
+func NewFeedController(feedService service.FeedService) *FeedController {
+	return &FeedController{feedService: feedService}
+}
+
+// ArticleRSS 输出 RSS 2.0 格式的文章订阅源
+func (ctr *FeedController) ArticleRSS(ctx *gin.Context) {
+	ctr.serveFeed(ctx, service.RenderRSS, "application/rss+xml; charset=utf-8")
+}
+
+// ArticleAtom 输出 Atom 格式的文章订阅源
+func (ctr *FeedController) ArticleAtom(ctx *gin.Context) {
+	ctr.serveFeed(ctx, service.RenderAtom, "application/atom+xml; charset=utf-8")
+}
+
+// ArticleJSONFeed 输出 JSON Feed 格式的文章订阅源
+func (ctr *FeedController) ArticleJSONFeed(ctx *gin.Context) {
+	ctr.serveFeed(ctx, service.RenderJSONFeed, "application/feed+json; charset=utf-8")
+}
+
+// serveFeed 查询并渲染订阅源，支持 ETag 和 Last-Modified 条件请求
+func (ctr *FeedController) serveFeed(ctx *gin.Context, render func(feed *dto.Feed) ([]byte, error), contentType string) {
+	var req dto.FeedRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	feed, err := ctr.feedService.GetArticleFeed(ctx, req, utils.RequestSiteURL(ctx), ctx.Request.URL.RequestURI())
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	body, err := render(feed)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, utils.NewSystemError(err))
+		return
+	}
+
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", feedCacheMaxAge))
+
+	if notModified(ctx, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
+// notModified 判断客户端缓存是否仍然有效，If-None-Match 优先于 If-Modified-Since
+func notModified(ctx *gin.Context, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := ctx.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := ctx.GetHeader("If-Modified-Since"); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !lastModified.After(since)
+	}
+
+	return false
+}
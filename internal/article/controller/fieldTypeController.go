@@ -35,6 +35,7 @@ func (ctr *FieldTypeController) GetFieldType(ctx *gin.Context) {
 			FieldID:   field.FieldID,
 			FieldCode: field.FieldCode,
 			FieldName: field.FieldName,
+			SortOrder: field.SortOrder,
 		})
 	}
 
@@ -49,9 +50,18 @@ func (ctr *FieldTypeController) CreateFieldType(ctx *gin.Context) {
 		return
 	}
 
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
 	fieldType := &model.FieldType{
-		FieldCode: req.FieldCode,
-		FieldName: req.FieldName,
+		FieldCode:  req.FieldCode,
+		FieldName:  req.FieldName,
+		SortOrder:  req.SortOrder,
+		CreateUser: userID,
+		UpdateUser: userID,
 	}
 
 	if err := ctr.fieldTypeService.CreateFieldType(ctx, fieldType); err != nil {
@@ -74,7 +84,13 @@ func (ctr *FieldTypeController) UpdateFieldType(ctx *gin.Context) {
 		return
 	}
 
-	if err := ctr.fieldTypeService.UpdateFieldType(ctx, urlReq.FieldID, req); err != nil {
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.fieldTypeService.UpdateFieldType(ctx, urlReq.FieldID, req, userID); err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
@@ -89,10 +105,42 @@ func (ctr *FieldTypeController) DeleteFieldType(ctx *gin.Context) {
 		return
 	}
 
-	if err := ctr.fieldTypeService.DeleteFieldType(ctx, urlReq.FieldID); err != nil {
+	var req dto.DeleteTypeRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.fieldTypeService.DeleteFieldType(ctx, urlReq.FieldID, req, userID); err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "领域类型删除成功"})
 }
+
+// ReorderFieldTypes 调整领域类型顺序
+func (ctr *FieldTypeController) ReorderFieldTypes(ctx *gin.Context) {
+	var req dto.ReorderTypeRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.fieldTypeService.ReorderFieldTypes(ctx, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "领域类型排序成功"})
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagController 标签控制器
+type TagController struct {
+	tagService service.TagService
+}
+
+// NewTagController 创建控制器实例
+func NewTagController(tagService service.TagService) *TagController {
+	return &TagController{tagService: tagService}
+}
+
+// ListTags 分页查询标签列表
+func (ctr *TagController) ListTags(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.ListTagRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 调用服务层
+	results, total, err := ctr.tagService.ListTags(ctx, page, pageSize, req.TagName)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回分页结果
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// GetTag 查询标签详情
+func (ctr *TagController) GetTag(ctx *gin.Context) {
+	var urlReq dto.TagUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	result, err := ctr.tagService.GetTag(ctx, urlReq.TagID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// CreateTag 创建标签
+func (ctr *TagController) CreateTag(ctx *gin.Context) {
+	var req dto.CreateTagRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	tag := &model.Tag{
+		TagName:    req.TagName,
+		CreateUser: userID,
+		UpdateUser: userID,
+	}
+	if err := ctr.tagService.CreateTag(ctx, tag); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "标签创建成功",
+		"data": gin.H{
+			"tag_id": tag.TagID,
+		},
+	})
+}
+
+// UpdateTag 更新标签
+func (ctr *TagController) UpdateTag(ctx *gin.Context) {
+	var urlReq dto.TagUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	var req dto.UpdateTagRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.tagService.UpdateTag(ctx, urlReq.TagID, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "标签更新成功",
+	})
+}
+
+// DeleteTag 删除标签
+func (ctr *TagController) DeleteTag(ctx *gin.Context) {
+	var urlReq dto.TagUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.tagService.DeleteTag(ctx, urlReq.TagID, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "标签删除成功",
+	})
+}
@@ -6,14 +6,22 @@ import (
 
 // ArticleListRequest 文章列表查询请求参数
 type ArticleListRequest struct {
-	Page         int    `form:"page" binding:"omitempty,min=1"`               // 页码，最小为1
-	PageSize     int    `form:"page_size" binding:"omitempty,min=1,max=100"`  // 页大小，1-100
-	ArticleTitle string `form:"article_title"`                                // 文章标题
-	FieldType    string `form:"field_type" binding:"omitempty"`               // 领域类型代码
-	IsSelection  int    `form:"is_selection" binding:"omitempty,numeric"`     // 是否精选，必须为数字
-	ArticleType  string `form:"article_type"`                                 // 文章类型
-	ReleaseTime  string `form:"release_time" binding:"omitempty,time_format"` // 发布时间
-	QueryScope   string `form:"query_scope" binding:"omitempty,query_scope"`  // 查询范围
+	Page         int    `form:"page" binding:"omitempty,min=1"`                                                     // 页码，最小为1
+	PageSize     int    `form:"page_size" binding:"omitempty,min=1,max=100"`                                        // 页大小，1-100
+	Cursor       string `form:"cursor" binding:"omitempty,max=200"`                                                 // 游标，传入时按游标分页并忽略页码
+	WithTotal    *bool  `form:"with_total"`                                                                         // 是否返回总数，页码分页默认返回，游标分页默认不返回
+	ArticleTitle string `form:"article_title"`                                                                      // 文章标题
+	FieldType    string `form:"field_type" binding:"omitempty"`                                                     // 领域类型代码
+	IsSelection  int    `form:"is_selection" binding:"omitempty,numeric"`                                           // 是否精选，必须为数字
+	ArticleType  string `form:"article_type"`                                                                       // 文章类型
+	ReleaseTime  string `form:"release_time" binding:"omitempty,time_format"`                                       // 发布时间
+	QueryScope   string `form:"query_scope" binding:"omitempty,query_scope"`                                        // 查询范围
+	Status       string `form:"status" binding:"omitempty,oneof=DRAFT PENDING_REVIEW SCHEDULED PUBLISHED ARCHIVED"` // 文章状态，仅管理端列表有效
+	Tags         string `form:"tags" binding:"omitempty,max=200"`                                                   // 标签ID，多个以英文逗号分隔，匹配任一标签
+	TagIDList    []int  `form:"-"`                                                                                  // 由Tags解析得到的标签ID列表
+	SortBy       string `form:"sort_by" binding:"omitempty,oneof=LATEST POPULAR"`                                   // 排序方式，默认按发布时间，POPULAR按热度
+	PinScope     string `form:"-"`                                                                                  // 置顶范围，由服务层根据筛选条件确定，为空时不合并置顶
+	PinValue     string `form:"-"`                                                                                  // 置顶范围值
 }
 
 // ArticleContentRequest 文章内容查询请求参数
@@ -21,30 +29,59 @@ type ArticleContentRequest struct {
 	ArticleID int `uri:"id" binding:"required,numeric"` // 文章ID，必须为数字
 }
 
+// ArticleKeyRequest 按文章ID或别名查询文章的请求参数
+type ArticleKeyRequest struct {
+	Key string `uri:"id" binding:"required,max=200"` // 文章ID或别名
+}
+
 // CreateArticleRequest 创建文章请求参数
 type CreateArticleRequest struct {
-	ArticleTitle   string `json:"article_title" binding:"required,max=255"` // 文章标题
-	ArticleType    string `json:"article_type" binding:"required"`          // 文章
-	BriefContent   string `json:"brief_content"`
-	ArticleContent string `json:"article_content" binding:"required"`
-	IsSelection    int    `json:"is_selection" binding:"oneof=0 1"` // 默认=2，1：精选，2：非精选
-	FieldType      string `json:"field_type"`
-	CoverImageURL  string `json:"cover_image_url"` // 封面图片URL
-	ArticleSource  string `json:"article_source"`  // 文章来源
-	ImageIDList    []int  `json:"image_id_list"`   // 关联图片ID列表
+	ArticleTitle    string `json:"article_title" binding:"required,max=255"` // 文章标题
+	ArticleType     string `json:"article_type" binding:"required"`          // 文章
+	BriefContent    string `json:"brief_content"`
+	ArticleContent  string `json:"article_content" binding:"required"`
+	IsSelection     int    `json:"is_selection" binding:"oneof=0 1"` // 默认=2，1：精选，2：非精选
+	FieldType       string `json:"field_type"`
+	CoverImageURL   string `json:"cover_image_url"`                              // 封面图片URL
+	ArticleSource   string `json:"article_source"`                               // 文章来源
+	ImageIDList     []int  `json:"image_id_list"`                                // 关联图片ID列表
+	ReleaseTime     string `json:"release_time" binding:"omitempty,time_format"` // 计划发布时间，为空则审核通过后立即发布
+	TagIDList       []int  `json:"tag_id_list" binding:"omitempty,dive,min=1"`   // 关联标签ID列表
+	Slug            string `json:"slug" binding:"omitempty,slug"`                // 别名，为空时根据标题生成
+	MetaDescription string `json:"meta_description" binding:"omitempty,max=300"` // SEO描述，为空时取摘要
+	OgImageURL      string `json:"og_image_url" binding:"omitempty,url"`         // Open Graph 分享图片，为空时取封面图片
 }
 
 // UpdateArticleRequest 更新文章请求参数
 type UpdateArticleRequest struct {
-	ArticleTitle   *string `json:"article_title" binding:"omitempty,non_empty_string,max=255"`
-	ArticleType    *string `json:"article_type" binding:"omitempty,non_empty_string"`    // 文章类型
-	BriefContent   *string `json:"brief_content" binding:"omitempty"`                    // 摘要
-	ArticleContent *string `json:"article_content" binding:"omitempty,non_empty_string"` // 文章内容
-	IsSelection    *int    `json:"is_selection" binding:"omitempty,numeric,oneof=0 1"`   // 是否精选
-	FieldType      *string `json:"field_type" binding:"omitempty"`                       // 领域类型
-	CoverImageURL  *string `json:"cover_image_url" binding:"omitempty,url"`              // 封面图URL
-	ArticleSource  *string `json:"article_source" binding:"omitempty"`                   // 文章来源
-	ImageIDList    *[]int  `json:"image_id_list" binding:"omitempty,dive,min=1"`         // 图片ID列表
+	ArticleTitle    *string `json:"article_title" binding:"omitempty,non_empty_string,max=255"`
+	ArticleType     *string `json:"article_type" binding:"omitempty,non_empty_string"`    // 文章类型
+	BriefContent    *string `json:"brief_content" binding:"omitempty"`                    // 摘要
+	ArticleContent  *string `json:"article_content" binding:"omitempty,non_empty_string"` // 文章内容
+	IsSelection     *int    `json:"is_selection" binding:"omitempty,numeric,oneof=0 1"`   // 是否精选
+	FieldType       *string `json:"field_type" binding:"omitempty"`                       // 领域类型
+	CoverImageURL   *string `json:"cover_image_url" binding:"omitempty,url"`              // 封面图URL
+	ArticleSource   *string `json:"article_source" binding:"omitempty"`                   // 文章来源
+	ImageIDList     *[]int  `json:"image_id_list" binding:"omitempty,dive,min=1"`         // 图片ID列表
+	TagIDList       *[]int  `json:"tag_id_list" binding:"omitempty,dive,min=1"`           // 标签ID列表，传空数组表示清空标签
+	Slug            *string `json:"slug" binding:"omitempty,slug"`                        // 别名，传空字符串表示清除别名
+	MetaDescription *string `json:"meta_description" binding:"omitempty,max=300"`         // SEO描述
+	OgImageURL      *string `json:"og_image_url" binding:"omitempty,url"`                 // Open Graph 分享图片
+}
+
+// RejectArticleRequest 驳回文章请求参数
+type RejectArticleRequest struct {
+	Comment string `json:"comment" binding:"required,max=500"` // 驳回意见
+}
+
+// ApproveArticleRequest 审核通过文章请求参数
+type ApproveArticleRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=500"` // 审核意见
+}
+
+// ScheduleArticleRequest 设置文章定时发布请求参数
+type ScheduleArticleRequest struct {
+	ReleaseTime string `json:"release_time" binding:"required,time_format"` // 计划发布时间
 }
 
 // ArticleListResponse 文章列表响应结构体
@@ -59,6 +96,15 @@ type ArticleListResponse struct {
 	IsSelection     int       `json:"is_selection"`
 	CoverImageURL   string    `json:"cover_image_url"`
 	ArticleSource   string    `json:"article_source"`
+	Status          string    `json:"status"`
+	ViewCount       int64     `json:"view_count"`
+	LikeCount       int64     `json:"like_count"`
+	FavoriteCount   int64     `json:"favorite_count"`
+	Slug            string    `json:"slug"`
+	IsPinned        bool      `json:"is_pinned"`     // 是否置顶
+	PinOrder        int       `json:"-"`             // 置顶排序值，用于生成游标
+	Lang            string    `json:"lang" gorm:"-"` // 标题和摘要实际使用的语言，缺少请求语言的译文时为默认语言
+	Tags            []Tag     `json:"tags" gorm:"-"`
 }
 
 // Image 关联图片列表结构体
@@ -69,18 +115,28 @@ type Image struct {
 
 // ArticleContentResponse 文章内容响应结构体
 type ArticleContentDTO struct {
-	ArticleID       int       `json:"article_id"`
-	ArticleTitle    string    `json:"article_title"`
-	BriefContent    string    `json:"brief_content"`
-	FieldType       string    `json:"field_type"`
-	FieldName       string    `json:"field_name"`
-	ReleaseTime     time.Time `json:"release_time"`
-	ArticleContent  string    `json:"article_content"`
-	ArticleTypeCode string    `json:"article_type_code"`
-	ArticleType     string    `json:"article_type"`
-	ArticleSource   string    `json:"article_source"`
-	IsSelection     int       `json:"is_selection"`
-	CoverImageURL   string    `json:"cover_image_url"`
+	ArticleID       int        `json:"article_id"`
+	ArticleTitle    string     `json:"article_title"`
+	BriefContent    string     `json:"brief_content"`
+	FieldType       string     `json:"field_type"`
+	FieldName       string     `json:"field_name"`
+	ReleaseTime     time.Time  `json:"release_time"`
+	ArticleContent  string     `json:"article_content"`
+	ArticleTypeCode string     `json:"article_type_code"`
+	ArticleType     string     `json:"article_type"`
+	ArticleSource   string     `json:"article_source"`
+	IsSelection     int        `json:"is_selection"`
+	CoverImageURL   string     `json:"cover_image_url"`
+	Status          string     `json:"status"`
+	ReviewComment   string     `json:"review_comment"`
+	PublishedTime   *time.Time `json:"published_time"` // 首次发布时间，未发布过时为nil
+	IsDeleted       string     `json:"is_deleted"`
+	ViewCount       int64      `json:"view_count"`
+	LikeCount       int64      `json:"like_count"`
+	FavoriteCount   int64      `json:"favorite_count"`
+	Slug            string     `json:"slug"`
+	MetaDescription string     `json:"meta_description"`
+	OgImageURL      string     `json:"og_image_url"`
 }
 
 // ArticleContentResponse 文章内容响应结构体
@@ -97,5 +153,17 @@ type ArticleContentResponse struct {
 	ArticleSource   string    `json:"article_source"`
 	IsSelection     int       `json:"is_selection"`
 	CoverImageURL   string    `json:"cover_image_url"`
+	Status          string    `json:"status,omitempty"`         // 文章状态，仅后台预览时返回
+	ReviewComment   string    `json:"review_comment,omitempty"` // 审核意见，仅后台预览时返回
+	ViewCount       int64     `json:"view_count"`
+	LikeCount       int64     `json:"like_count"`
+	FavoriteCount   int64     `json:"favorite_count"`
+	IsLiked         bool      `json:"is_liked"`     // 当前用户是否已点赞，未登录时为false
+	IsFavorited     bool      `json:"is_favorited"` // 当前用户是否已收藏，未登录时为false
+	Slug            string    `json:"slug"`
+	MetaDescription string    `json:"meta_description"` // SEO描述，未设置时取摘要
+	OgImageURL      string    `json:"og_image_url"`     // Open Graph 分享图片，未设置时取封面图片
+	Lang            string    `json:"lang"`             // 标题、摘要和正文实际使用的语言，缺少请求语言的译文时为默认语言
+	Tags            []Tag     `json:"tags"`
 	Images          []Image   `json:"images"`
 }
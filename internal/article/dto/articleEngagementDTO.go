@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// UserFavoritesRequest 用户收藏列表查询请求参数
+type UserFavoritesRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+}
+
+// FavoriteArticleResponse 用户收藏的文章
+type FavoriteArticleResponse struct {
+	ArticleListResponse
+	FavoriteTime time.Time `json:"favorite_time"` // 收藏时间
+}
+
+// ArticleEngagementResponse 点赞/收藏操作后的文章互动数据
+type ArticleEngagementResponse struct {
+	ArticleID     int   `json:"article_id"`
+	LikeCount     int64 `json:"like_count"`
+	FavoriteCount int64 `json:"favorite_count"`
+	IsLiked       bool  `json:"is_liked"`
+	IsFavorited   bool  `json:"is_favorited"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// ListPinsRequest 置顶列表查询请求参数
+type ListPinsRequest struct {
+	Scope      string `form:"scope" binding:"required,oneof=HOME FIELD_TYPE ARTICLE_TYPE"` // 置顶范围
+	ScopeValue string `form:"scope_value" binding:"omitempty,max=50"`                      // 范围值，领域编码或类型编码，首页不填
+}
+
+// PinUrlID 用于操作单条置顶的URL参数
+type PinUrlID struct {
+	ID int `uri:"id" binding:"required"` // 置顶ID
+}
+
+// CreatePinRequest 置顶文章请求参数
+type CreatePinRequest struct {
+	ArticleID  int    `json:"article_id" binding:"required,min=1"`                         // 文章ID
+	Scope      string `json:"scope" binding:"required,oneof=HOME FIELD_TYPE ARTICLE_TYPE"` // 置顶范围
+	ScopeValue string `json:"scope_value" binding:"omitempty,max=50"`                      // 范围值，领域编码或类型编码，首页不填
+	SortOrder  int    `json:"sort_order" binding:"omitempty,min=0"`                        // 排序值，越小越靠前
+	ExpireTime string `json:"expire_time" binding:"omitempty,time_format"`                 // 置顶到期时间，为空表示长期置顶
+}
+
+// UpdatePinRequest 更新置顶请求参数
+type UpdatePinRequest struct {
+	SortOrder  *int    `json:"sort_order" binding:"omitempty,min=0"` // 排序值
+	ExpireTime *string `json:"expire_time"`                          // 置顶到期时间，传空字符串表示改为长期置顶
+}
+
+// ReorderPinRequest 调整置顶顺序请求参数
+type ReorderPinRequest struct {
+	Scope      string `json:"scope" binding:"required,oneof=HOME FIELD_TYPE ARTICLE_TYPE"` // 置顶范围
+	ScopeValue string `json:"scope_value" binding:"omitempty,max=50"`                      // 范围值
+	IDList     []int  `json:"id_list" binding:"required,min=1,dive,min=1"`                 // 按新顺序排列的置顶ID列表，排序值依次设为1、2、3……
+}
+
+// PinResponse 置顶列表响应
+type PinResponse struct {
+	ID           int        `json:"id"`
+	ArticleID    int        `json:"article_id"`
+	ArticleTitle string     `json:"article_title"`
+	Status       string     `json:"status"`     // 文章状态，未发布的文章不会出现在公开列表中
+	IsDeleted    string     `json:"is_deleted"` // 文章是否已删除
+	Scope        string     `json:"scope"`
+	ScopeValue   string     `json:"scope_value"`
+	SortOrder    int        `json:"sort_order"`
+	ExpireTime   *time.Time `json:"expire_time"`
+	Expired      bool       `json:"expired" gorm:"-"` // 是否已过期，过期的置顶不再生效
+	UpdateTime   time.Time  `json:"update_time"`
+	UpdateUser   int        `json:"update_user"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// ListRevisionRequest 文章修订记录列表请求参数
+type ListRevisionRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+}
+
+// RevisionDiffRequest 修订记录对比请求参数
+type RevisionDiffRequest struct {
+	FromID int `form:"from_id" binding:"required,numeric"` // 对比的旧版本修订ID
+	ToID   int `form:"to_id" binding:"required,numeric"`   // 对比的新版本修订ID
+}
+
+// RestoreRevisionRequest 回滚文章到历史版本请求参数
+type RestoreRevisionRequest struct {
+	RevisionID int `json:"revision_id" binding:"required,numeric"` // 修订ID
+}
+
+// RevisionListResponse 文章修订记录列表响应结构体
+type RevisionListResponse struct {
+	ID           int       `json:"id"`
+	ArticleID    int       `json:"article_id"`
+	RevisionNo   int       `json:"revision_no"`
+	Action       string    `json:"action"`
+	RestoreFrom  int       `json:"restore_from"`
+	ArticleTitle string    `json:"article_title"`
+	EditUser     int       `json:"edit_user"`
+	EditUserName string    `json:"edit_user_name"`
+	CreateTime   time.Time `json:"create_time"`
+}
+
+// RevisionFieldDiff 修订记录字段差异
+type RevisionFieldDiff struct {
+	Field    string `json:"field"`     // 字段名
+	Label    string `json:"label"`     // 字段中文名称
+	OldValue string `json:"old_value"` // 旧版本值
+	NewValue string `json:"new_value"` // 新版本值
+}
+
+// RevisionDiffResponse 修订记录对比响应结构体
+type RevisionDiffResponse struct {
+	ArticleID   int                 `json:"article_id"`
+	FromID      int                 `json:"from_id"`
+	FromNo      int                 `json:"from_no"`
+	ToID        int                 `json:"to_id"`
+	ToNo        int                 `json:"to_no"`
+	Differences []RevisionFieldDiff `json:"differences"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// ArticleTypeUrlID 用于获取单个文章类型的URL参数
+type ArticleTypeUrlID struct {
+	ID int `uri:"id" binding:"required"` // 文章类型ID
+}
+
+// CreateArticleTypeRequest 创建文章类型请求参数
+type CreateArticleTypeRequest struct {
+	TypeCode  string `json:"type_code" binding:"required,max=50"`  // 类型编码
+	TypeName  string `json:"type_name" binding:"required,max=255"` // 类型名称
+	SortOrder int    `json:"sort_order" binding:"omitempty,min=0"` // 排序值，越小越靠前
+}
+
+// UpdateArticleTypeRequest 更新文章类型请求参数
+type UpdateArticleTypeRequest struct {
+	TypeCode        string `json:"type_code" binding:"omitempty,non_empty_string,max=50"`  // 类型编码
+	TypeName        string `json:"type_name" binding:"omitempty,non_empty_string,max=255"` // 类型名称
+	SortOrder       *int   `json:"sort_order" binding:"omitempty,min=0"`                   // 排序值
+	CascadeArticles bool   `json:"cascade_articles"`                                       // 修改编码时是否同步更新引用该编码的文章，未指定时存在引用则拒绝修改
+}
+
+// ListArticleTypesResponse 文章类型列表响应
+type ListArticleTypesResponse struct {
+	ID        int    `json:"id"`         // 类型ID
+	TypeCode  string `json:"type_code"`  // 类型编码
+	TypeName  string `json:"type_name"`  // 类型名称
+	SortOrder int    `json:"sort_order"` // 排序值
+}
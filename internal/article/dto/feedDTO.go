@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// FeedRequest 文章订阅源查询请求参数
+type FeedRequest struct {
+	FieldType   string `form:"field_type" binding:"omitempty,max=50"`   // 领域类型代码
+	ArticleType string `form:"article_type" binding:"omitempty,max=50"` // 文章类型代码
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"` // 输出的文章数量，1-100
+}
+
+// FeedArticleDTO 订阅源文章查询结果，在文章内容的基础上增加更新时间
+type FeedArticleDTO struct {
+	ArticleContentDTO
+	UpdateTime time.Time `json:"update_time"`
+}
+
+// Feed 订阅源，与具体输出格式无关，由各格式的渲染函数转换输出
+type Feed struct {
+	Title       string
+	Description string
+	SiteURL     string    // 站点地址
+	SelfURL     string    // 订阅源自身地址
+	Updated     time.Time // 订阅源最后更新时间，取各文章发布时间和更新时间的最大值
+	Items       []FeedItem
+}
+
+// FeedItem 订阅源条目
+type FeedItem struct {
+	ArticleID   int
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Author      string // 文章来源
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+	ImageURL    string // 封面图片地址，作为附件输出
+	ImageType   string // 封面图片的MIME类型
+}
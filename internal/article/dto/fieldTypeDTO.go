@@ -9,12 +9,15 @@ type FieldTypeUrlID struct {
 type CreateFieldTypeRequest struct {
 	FieldCode string `json:"field_code" binding:"required,max=50"`  // 领域编码
 	FieldName string `json:"field_name" binding:"required,max=255"` // 领域名称
+	SortOrder int    `json:"sort_order" binding:"omitempty,min=0"`  // 排序值，越小越靠前
 }
 
 // UpdateFieldTypeRequest 更新领域类型请求参数
 type UpdateFieldTypeRequest struct {
-	FieldCode string `json:"field_code" binding:"omitempty,non_empty_string,max=50"`  // 领域编码
-	FieldName string `json:"field_name" binding:"omitempty,non_empty_string,max=255"` // 领域名称
+	FieldCode       string `json:"field_code" binding:"omitempty,non_empty_string,max=50"`  // 领域编码
+	FieldName       string `json:"field_name" binding:"omitempty,non_empty_string,max=255"` // 领域名称
+	SortOrder       *int   `json:"sort_order" binding:"omitempty,min=0"`                    // 排序值
+	CascadeArticles bool   `json:"cascade_articles"`                                        // 修改编码时是否同步更新引用该编码的文章，未指定时存在引用则拒绝修改
 }
 
 // ListFieldTypesResponse 领域类型列表响应
@@ -22,4 +25,15 @@ type ListFieldTypesResponse struct {
 	FieldID   int    `json:"field_id"`   // 领域ID
 	FieldCode string `json:"field_code"` // 领域编码
 	FieldName string `json:"field_name"` // 领域名称
+	SortOrder int    `json:"sort_order"` // 排序值
+}
+
+// DeleteTypeRequest 删除领域类型或文章类型的请求参数
+type DeleteTypeRequest struct {
+	ReplaceWith string `form:"replace_with" binding:"omitempty,max=50"` // 将引用该类型的文章迁移到的目标编码，未指定时存在引用则拒绝删除
+}
+
+// ReorderTypeRequest 调整领域类型或文章类型顺序的请求参数
+type ReorderTypeRequest struct {
+	IDList []int `json:"id_list" binding:"required,min=1,dive,min=1"` // 按新顺序排列的ID列表，排序值依次设为1、2、3……
 }
@@ -0,0 +1,48 @@
+package dto
+
+// TagUrlID 用于获取单个标签的URL参数
+type TagUrlID struct {
+	TagID int `uri:"id" binding:"required,numeric"` // 标签ID
+}
+
+// ListTagRequest 标签列表查询请求参数
+type ListTagRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+	TagName  string `form:"tag_name"`                                    // 标签名称，模糊匹配
+}
+
+// CreateTagRequest 创建标签请求参数
+type CreateTagRequest struct {
+	TagName string `json:"tag_name" binding:"required,non_empty_string,max=50"` // 标签名称
+}
+
+// UpdateTagRequest 更新标签请求参数
+type UpdateTagRequest struct {
+	TagName string `json:"tag_name" binding:"required,non_empty_string,max=50"` // 标签名称
+}
+
+// Tag 文章关联的标签
+type Tag struct {
+	TagID   int    `json:"tag_id"`
+	TagName string `json:"tag_name"`
+}
+
+// ArticleTag 文章与标签的关联查询结果
+type ArticleTag struct {
+	ArticleID int
+	TagID     int
+	TagName   string
+}
+
+// TagResponse 标签列表响应结构体
+type TagResponse struct {
+	TagID        int    `json:"tag_id"`
+	TagName      string `json:"tag_name"`
+	ArticleCount int64  `json:"article_count"` // 已发布文章数量
+}
+
+// RelatedArticleRequest 相关文章查询请求参数
+type RelatedArticleRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=20"` // 返回数量，默认5
+}
@@ -0,0 +1,80 @@
+package dto
+
+import "mime/multipart"
+
+// 导入导出文件格式
+const (
+	TransferFormatJSONL = "jsonl"
+	TransferFormatCSV   = "csv"
+	TransferFormatZIP   = "zip"
+)
+
+// 重复标题的处理方式
+const (
+	DuplicateSkip  = "SKIP"  // 跳过重复行
+	DuplicateError = "ERROR" // 将重复行记为错误
+)
+
+// 导入行的处理结果
+const (
+	ImportRowValid   = "VALID"   // 试运行校验通过
+	ImportRowCreated = "CREATED" // 已创建
+	ImportRowSkipped = "SKIPPED" // 标题重复已跳过
+	ImportRowFailed  = "FAILED"  // 校验或创建失败
+)
+
+// ImportArticleRequest 批量导入文章请求参数
+type ImportArticleRequest struct {
+	File        *multipart.FileHeader `form:"file" binding:"required"`                           // 导入文件，支持 .jsonl、.csv 及包含图片的 .zip
+	DryRun      bool                  `form:"dry_run"`                                           // 是否仅校验不写入
+	OnDuplicate string                `form:"on_duplicate" binding:"omitempty,oneof=SKIP ERROR"` // 标题重复时的处理方式，默认ERROR
+}
+
+// ExportArticleRequest 批量导出文章请求参数
+type ExportArticleRequest struct {
+	Format      string `form:"format" binding:"required,oneof=jsonl csv zip"`                                      // 导出格式
+	Status      string `form:"status" binding:"omitempty,oneof=DRAFT PENDING_REVIEW SCHEDULED PUBLISHED ARCHIVED"` // 文章状态
+	FieldType   string `form:"field_type"`                                                                         // 领域类型代码
+	ArticleType string `form:"article_type"`                                                                       // 文章类型
+}
+
+// ArticleTransferRecord 导入导出的单篇文章记录，JSON Lines 每行一条，CSV 每行一条
+// 导入ZIP时 images、cover_image_url 及正文中的图片地址可填写压缩包内的图片路径，导入后替换为上传后的地址
+// 导入 JSON Lines 或 CSV 时忽略 images，status 仅用于导出，导入的文章统一为草稿
+type ArticleTransferRecord struct {
+	ArticleTitle    string   `json:"article_title" binding:"required,max=255"`
+	ArticleType     string   `json:"article_type" binding:"required,max=50"`
+	BriefContent    string   `json:"brief_content"`
+	ArticleContent  string   `json:"article_content" binding:"required"`
+	IsSelection     int      `json:"is_selection" binding:"omitempty,oneof=1 2"`
+	FieldType       string   `json:"field_type" binding:"max=50"`
+	CoverImageURL   string   `json:"cover_image_url" binding:"max=255"`
+	ArticleSource   string   `json:"article_source" binding:"max=255"`
+	ReleaseTime     string   `json:"release_time" binding:"omitempty,time_format"`
+	Status          string   `json:"status,omitempty"`
+	Slug            string   `json:"slug" binding:"omitempty,slug"`
+	MetaDescription string   `json:"meta_description" binding:"max=300"`
+	OgImageURL      string   `json:"og_image_url" binding:"omitempty,url"`
+	Tags            []string `json:"tags"`   // 标签名称，CSV 中以 | 分隔
+	Images          []string `json:"images"` // 关联图片，CSV 中以 | 分隔
+}
+
+// ImportRowResult 单行导入结果
+type ImportRowResult struct {
+	Row          int      `json:"row"` // 行号，从文件第1行开始计数
+	ArticleTitle string   `json:"article_title"`
+	Status       string   `json:"status"`
+	ArticleID    int      `json:"article_id,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// ImportResult 批量导入结果
+type ImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
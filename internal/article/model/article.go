@@ -5,23 +5,50 @@ import (
 	"time"
 )
 
+// 文章状态常量定义
+const (
+	ArticleStatusDraft         = "DRAFT"          // 草稿
+	ArticleStatusPendingReview = "PENDING_REVIEW" // 待审核
+	ArticleStatusScheduled     = "SCHEDULED"      // 审核通过，等待定时发布
+	ArticleStatusPublished     = "PUBLISHED"      // 已发布
+	ArticleStatusArchived      = "ARCHIVED"       // 已归档
+)
+
+// 文章列表排序方式常量定义
+const (
+	ArticleSortLatest  = "LATEST"  // 按发布时间降序
+	ArticleSortPopular = "POPULAR" // 按热度降序
+)
+
 // Article 数据模型
 type Article struct {
-	ArticleID      int       `json:"article_id" gorm:"primaryKey;column:article_id"`
-	ArticleTitle   string    `json:"article_title" gorm:"not null;column:article_title"`
-	ArticleType    string    `json:"article_type" gorm:"not null;column:article_type"`
-	ReleaseTime    time.Time `json:"release_time" gorm:"column:release_time"`
-	BriefContent   string    `json:"brief_content" gorm:"type:text;column:brief_content"`
-	ArticleContent string    `json:"article_content" gorm:"type:mediumtext;column:article_content"`
-	IsSelection    int       `json:"is_selection" gorm:"default:2;column:is_selection"` // 默认=2，1：精选，2：非精选
-	FieldType      string    `json:"field_type" gorm:"column:field_type"`
-	CoverImageURL  string    `json:"cover_image_url" gorm:"column:cover_image_url"` // 封面图片URL
-	ArticleSource  string    `json:"article_source" gorm:"column:article_source"`   // 文章来源
-	IsDeleted      string    `json:"is_deleted" gorm:"column:is_deleted;default:N"` // 软删除标志，默认值为N
-	CreateTime     time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
-	UpdateTime     time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
-	CreateUser     int       `json:"create_user" gorm:"column:create_user"` // 创建人ID
-	UpdateUser     int       `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
+	ArticleID       int        `json:"article_id" gorm:"primaryKey;column:article_id"`
+	ArticleTitle    string     `json:"article_title" gorm:"not null;column:article_title;index:ft_article_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	ArticleType     string     `json:"article_type" gorm:"not null;column:article_type"`
+	ReleaseTime     time.Time  `json:"release_time" gorm:"column:release_time"`
+	BriefContent    string     `json:"brief_content" gorm:"type:text;column:brief_content;index:ft_article_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	ArticleContent  string     `json:"article_content" gorm:"type:mediumtext;column:article_content"`
+	ContentText     string     `json:"-" gorm:"type:mediumtext;column:content_text;index:ft_article_search,class:FULLTEXT,option:WITH PARSER ngram"` // 正文纯文本，由正文HTML提取，用于全文检索
+	IsSelection     int        `json:"is_selection" gorm:"default:2;column:is_selection"`                                                            // 默认=2，1：精选，2：非精选
+	FieldType       string     `json:"field_type" gorm:"column:field_type"`
+	CoverImageURL   string     `json:"cover_image_url" gorm:"column:cover_image_url"`                                      // 封面图片URL
+	ArticleSource   string     `json:"article_source" gorm:"column:article_source"`                                        // 文章来源
+	Status          string     `json:"status" gorm:"type:varchar(20);column:status;default:DRAFT"`                         // 文章状态，默认为草稿
+	ReviewComment   string     `json:"review_comment" gorm:"type:varchar(500);column:review_comment"`                      // 审核意见
+	ReviewUser      int        `json:"review_user" gorm:"column:review_user"`                                              // 审核人ID
+	ReviewTime      *time.Time `json:"review_time" gorm:"column:review_time"`                                              // 审核时间
+	PublishedTime   *time.Time `json:"published_time" gorm:"column:published_time"`                                        // 首次发布时间，修改后重新审核通过时据此保留原发布时间
+	ViewCount       int64      `json:"view_count" gorm:"column:view_count;default:0"`                                      // 浏览量
+	LikeCount       int64      `json:"like_count" gorm:"column:like_count;default:0"`                                      // 点赞数
+	FavoriteCount   int64      `json:"favorite_count" gorm:"column:favorite_count;default:0"`                              // 收藏数
+	Slug            string     `json:"slug" gorm:"type:varchar(200);column:slug;default:NULL;uniqueIndex:uk_article_slug"` // 别名，用于生成可读链接，未设置时为NULL
+	MetaDescription string     `json:"meta_description" gorm:"type:varchar(300);column:meta_description"`                  // SEO描述，为空时取摘要
+	OgImageURL      string     `json:"og_image_url" gorm:"type:varchar(500);column:og_image_url"`                          // Open Graph 分享图片，为空时取封面图片
+	IsDeleted       string     `json:"is_deleted" gorm:"column:is_deleted;default:N"`                                      // 软删除标志，默认值为N
+	CreateTime      time.Time  `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+	UpdateTime      time.Time  `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser      int        `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser      int        `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
 	// 关联字段
 	Images []dto.Image `json:"images" gorm:"-"` // 图片列表，存储图片ID和URL
 }
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// ArticleViewLog 文章浏览记录，用于按用户/IP在时间窗口内对浏览量去重
+type ArticleViewLog struct {
+	ID          int       `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID   int       `json:"article_id" gorm:"column:article_id;uniqueIndex:uk_article_viewer_window"`
+	ViewerKey   string    `json:"viewer_key" gorm:"type:varchar(64);column:viewer_key;uniqueIndex:uk_article_viewer_window"` // 浏览者标识，登录用户为 user:ID，匿名用户为 ip:地址
+	WindowStart time.Time `json:"window_start" gorm:"column:window_start;uniqueIndex:uk_article_viewer_window"`              // 去重时间窗口的起始时间
+	CreateTime  time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+}
+
+// TableName 设置表名
+func (*ArticleViewLog) TableName() string {
+	return "article_view_logs"
+}
+
+// ArticleLike 文章点赞记录
+type ArticleLike struct {
+	ID         int       `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID  int       `json:"article_id" gorm:"column:article_id;uniqueIndex:uk_article_like"`
+	UserID     int       `json:"user_id" gorm:"column:user_id;uniqueIndex:uk_article_like"`
+	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+}
+
+// TableName 设置表名
+func (*ArticleLike) TableName() string {
+	return "article_likes"
+}
+
+// ArticleFavorite 文章收藏记录
+type ArticleFavorite struct {
+	ID         int       `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID  int       `json:"article_id" gorm:"column:article_id;uniqueIndex:uk_article_favorite"`
+	UserID     int       `json:"user_id" gorm:"column:user_id;uniqueIndex:uk_article_favorite;index:idx_favorite_user"`
+	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+}
+
+// TableName 设置表名
+func (*ArticleFavorite) TableName() string {
+	return "article_favorites"
+}
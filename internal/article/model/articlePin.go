@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// 置顶范围常量定义
+const (
+	PinScopeHome        = "HOME"         // 首页文章列表
+	PinScopeFieldType   = "FIELD_TYPE"   // 指定领域的文章列表，范围值为领域编码
+	PinScopeArticleType = "ARTICLE_TYPE" // 指定文章类型的文章列表，范围值为类型编码
+)
+
+// ArticlePin 文章置顶数据模型，同一篇文章在同一列表中只能置顶一次
+type ArticlePin struct {
+	ID         int        `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID  int        `json:"article_id" gorm:"not null;column:article_id;uniqueIndex:uk_article_pin,priority:3"`
+	Scope      string     `json:"scope" gorm:"type:varchar(20);not null;column:scope;uniqueIndex:uk_article_pin,priority:1"`             // 置顶范围
+	ScopeValue string     `json:"scope_value" gorm:"type:varchar(50);not null;column:scope_value;uniqueIndex:uk_article_pin,priority:2"` // 范围值，首页为空字符串
+	SortOrder  int        `json:"sort_order" gorm:"column:sort_order;default:0"`                                                         // 排序值，越小越靠前
+	ExpireTime *time.Time `json:"expire_time" gorm:"column:expire_time"`                                                                 // 置顶到期时间，为空表示长期置顶
+	CreateTime time.Time  `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+	UpdateTime time.Time  `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser int        `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser int        `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
+}
+
+// TableName 设置表名
+func (*ArticlePin) TableName() string {
+	return "article_pins"
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+)
+
+// 文章修订操作类型常量定义
+const (
+	RevisionActionCreate  = "CREATE"  // 创建
+	RevisionActionUpdate  = "UPDATE"  // 更新
+	RevisionActionDelete  = "DELETE"  // 删除
+	RevisionActionRestore = "RESTORE" // 回滚到历史版本
+	RevisionActionRecover = "RECOVER" // 恢复已删除的文章
+)
+
+// ArticleRevision 文章修订记录，每次创建/更新/删除文章时写入一条不可变的快照
+type ArticleRevision struct {
+	ID             int       `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID      int       `json:"article_id" gorm:"not null;column:article_id;uniqueIndex:uk_article_revision"`
+	RevisionNo     int       `json:"revision_no" gorm:"not null;column:revision_no;uniqueIndex:uk_article_revision"` // 文章内的修订版本号，从1开始递增
+	Action         string    `json:"action" gorm:"type:varchar(20);not null;column:action"`                          // 操作类型
+	RestoreFrom    int       `json:"restore_from" gorm:"column:restore_from;default:0"`                              // 回滚操作对应的来源修订ID
+	ArticleTitle   string    `json:"article_title" gorm:"column:article_title"`
+	ArticleType    string    `json:"article_type" gorm:"column:article_type"`
+	BriefContent   string    `json:"brief_content" gorm:"type:text;column:brief_content"`
+	ArticleContent string    `json:"article_content" gorm:"type:mediumtext;column:article_content"`
+	IsSelection    int       `json:"is_selection" gorm:"column:is_selection"`
+	FieldType      string    `json:"field_type" gorm:"column:field_type"`
+	CoverImageURL  string    `json:"cover_image_url" gorm:"column:cover_image_url"`
+	ArticleSource  string    `json:"article_source" gorm:"column:article_source"`
+	EditUser       int       `json:"edit_user" gorm:"column:edit_user"` // 编辑人ID，取自文章的update_user
+	CreateTime     time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+}
+
+// TableName 设置表名
+func (*ArticleRevision) TableName() string {
+	return "article_revisions"
+}
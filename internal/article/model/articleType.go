@@ -7,10 +7,14 @@ import (
 // ArticleType 数据模型
 type ArticleType struct {
 	ID         int       `json:"id" gorm:"primaryKey;column:id"`
-	TypeCode   string    `json:"type_code" gorm:"column:type_code"`
-	TypeName   string    `json:"type_name" gorm:"column:type_name"`
+	TypeCode   string    `json:"type_code" gorm:"type:varchar(50);column:type_code;uniqueIndex:uk_article_type_code"` // 类型编码，文章通过编码关联，已删除的类型仍占用编码
+	TypeName   string    `json:"type_name" gorm:"type:varchar(255);column:type_name"`
+	SortOrder  int       `json:"sort_order" gorm:"column:sort_order;default:0"` // 排序值，越小越靠前
+	IsDeleted  string    `json:"is_deleted" gorm:"column:is_deleted;default:N"` // 软删除标志，默认值为N
 	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
 	UpdateTime time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser int       `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser int       `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
 }
 
 // TableName 设置表名
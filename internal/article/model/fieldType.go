@@ -7,11 +7,14 @@ import (
 // FieldType 对应领域表，用于关联查询
 type FieldType struct {
 	FieldID    int       `json:"field_id" gorm:"primaryKey;column:field_id"`
-	FieldCode  string    `json:"field_code" gorm:"column:field_code"`
+	FieldCode  string    `json:"field_code" gorm:"type:varchar(50);column:field_code;uniqueIndex:uk_field_code"` // 领域编码，文章通过编码关联，已删除的领域仍占用编码
 	FieldName  string    `json:"field_name" gorm:"type:varchar(255);column:field_name"`
+	SortOrder  int       `json:"sort_order" gorm:"column:sort_order;default:0"` // 排序值，越小越靠前
 	IsDeleted  string    `json:"is_deleted" gorm:"column:is_deleted;default:N"`
 	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
 	UpdateTime time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser int       `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser int       `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
 }
 
 // TableName 设置表名
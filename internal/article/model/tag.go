@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// Tag 文章标签数据模型
+type Tag struct {
+	TagID      int       `json:"tag_id" gorm:"primaryKey;column:tag_id"`
+	TagName    string    `json:"tag_name" gorm:"type:varchar(50);not null;column:tag_name"`
+	IsDeleted  string    `json:"is_deleted" gorm:"column:is_deleted;default:N"` // 软删除标志，默认值为N
+	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+	UpdateTime time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser int       `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser int       `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
+}
+
+// TableName 设置表名
+func (*Tag) TableName() string {
+	return "tags"
+}
+
+// ArticleTagMapping 文章与标签的多对多关联
+type ArticleTagMapping struct {
+	ID         int       `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID  int       `json:"article_id" gorm:"column:article_id;uniqueIndex:uk_article_tag"`
+	TagID      int       `json:"tag_id" gorm:"column:tag_id;uniqueIndex:uk_article_tag;index:idx_tag_id"`
+	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+}
+
+// TableName 设置表名
+func (*ArticleTagMapping) TableName() string {
+	return "article_tag_mappings"
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleEngagementRepository 文章浏览、点赞、收藏数据访问接口
+type ArticleEngagementRepository interface {
+	// RecordView 记录一次浏览，同一浏览者在同一时间窗口内只计一次，返回本次是否计入浏览量
+	RecordView(ctx context.Context, articleID int, viewerKey string, windowStart time.Time) (bool, error)
+	// Like 点赞文章，重复点赞不重复计数
+	Like(ctx context.Context, articleID int, userID int) error
+	// Unlike 取消点赞
+	Unlike(ctx context.Context, articleID int, userID int) error
+	// Favorite 收藏文章，重复收藏不重复计数
+	Favorite(ctx context.Context, articleID int, userID int) error
+	// Unfavorite 取消收藏
+	Unfavorite(ctx context.Context, articleID int, userID int) error
+	// GetEngagement 查询文章的点赞、收藏数及指定用户的点赞、收藏状态
+	GetEngagement(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// ListUserFavorites 分页查询用户收藏的已发布文章
+	ListUserFavorites(ctx context.Context, page, pageSize int, userID int) ([]dto.FavoriteArticleResponse, int64, error)
+}
+
+// ArticleEngagementRepositoryImpl 实现接口的具体结构体
+type ArticleEngagementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewArticleEngagementRepository 创建数据访问实例
+func NewArticleEngagementRepository(db *gorm.DB) ArticleEngagementRepository {
+	return &ArticleEngagementRepositoryImpl{db: db}
+}
+
+// RecordView 记录一次浏览
+// 依赖 (article_id, viewer_key, window_start) 唯一索引去重，插入成功时才累加浏览量
+func (repo *ArticleEngagementRepositoryImpl) RecordView(ctx context.Context, articleID int, viewerKey string, windowStart time.Time) (bool, error) {
+	counted := false
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		viewLog := &model.ArticleViewLog{ArticleID: articleID, ViewerKey: viewerKey, WindowStart: windowStart}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(viewLog)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		counted = true
+		return tx.Model(&model.Article{}).
+			Where("article_id = ?", articleID).
+			UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+	})
+	if err != nil {
+		return false, utils.NewSystemError(fmt.Errorf("记录文章浏览失败: %w", err))
+	}
+
+	return counted, nil
+}
+
+// addRecord 写入点赞/收藏记录，写入成功时累加文章对应的计数字段
+func (repo *ArticleEngagementRepositoryImpl) addRecord(ctx context.Context, record interface{}, articleID int, countColumn string, action string) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return utils.NewSystemError(fmt.Errorf("写入%s记录失败: %w", action, result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return nil // 已存在，不重复计数
+		}
+
+		if err := tx.Model(&model.Article{}).
+			Where("article_id = ?", articleID).
+			UpdateColumn(countColumn, gorm.Expr(countColumn+" + 1")).Error; err != nil {
+			return utils.NewSystemError(fmt.Errorf("更新文章%s数失败: %w", action, err))
+		}
+		return nil
+	})
+}
+
+// removeRecord 删除点赞/收藏记录，删除成功时扣减文章对应的计数字段
+func (repo *ArticleEngagementRepositoryImpl) removeRecord(ctx context.Context, record interface{}, articleID int, userID int, countColumn string, action string) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("article_id = ? AND user_id = ?", articleID, userID).Delete(record)
+		if result.Error != nil {
+			return utils.NewSystemError(fmt.Errorf("删除%s记录失败: %w", action, result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return nil // 不存在，无需扣减
+		}
+
+		if err := tx.Model(&model.Article{}).
+			Where("article_id = ? AND "+countColumn+" > 0", articleID).
+			UpdateColumn(countColumn, gorm.Expr(countColumn+" - 1")).Error; err != nil {
+			return utils.NewSystemError(fmt.Errorf("更新文章%s数失败: %w", action, err))
+		}
+		return nil
+	})
+}
+
+// Like 点赞文章
+func (repo *ArticleEngagementRepositoryImpl) Like(ctx context.Context, articleID int, userID int) error {
+	return repo.addRecord(ctx, &model.ArticleLike{ArticleID: articleID, UserID: userID}, articleID, "like_count", "点赞")
+}
+
+// Unlike 取消点赞
+func (repo *ArticleEngagementRepositoryImpl) Unlike(ctx context.Context, articleID int, userID int) error {
+	return repo.removeRecord(ctx, &model.ArticleLike{}, articleID, userID, "like_count", "点赞")
+}
+
+// Favorite 收藏文章
+func (repo *ArticleEngagementRepositoryImpl) Favorite(ctx context.Context, articleID int, userID int) error {
+	return repo.addRecord(ctx, &model.ArticleFavorite{ArticleID: articleID, UserID: userID}, articleID, "favorite_count", "收藏")
+}
+
+// Unfavorite 取消收藏
+func (repo *ArticleEngagementRepositoryImpl) Unfavorite(ctx context.Context, articleID int, userID int) error {
+	return repo.removeRecord(ctx, &model.ArticleFavorite{}, articleID, userID, "favorite_count", "收藏")
+}
+
+// GetEngagement 查询文章的点赞、收藏数及指定用户的点赞、收藏状态，userID为0时不查询用户状态
+func (repo *ArticleEngagementRepositoryImpl) GetEngagement(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	var article model.Article
+	if err := repo.db.WithContext(ctx).
+		Select("article_id, like_count, favorite_count").
+		Where("article_id = ?", articleID).
+		First(&article).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	res := &dto.ArticleEngagementResponse{
+		ArticleID:     article.ArticleID,
+		LikeCount:     article.LikeCount,
+		FavoriteCount: article.FavoriteCount,
+	}
+	if userID == 0 {
+		return res, nil
+	}
+
+	var likeCount, favoriteCount int64
+	if err := repo.db.WithContext(ctx).Model(&model.ArticleLike{}).
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Count(&likeCount).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询点赞状态失败: %v", err))
+	}
+	if err := repo.db.WithContext(ctx).Model(&model.ArticleFavorite{}).
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Count(&favoriteCount).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询收藏状态失败: %v", err))
+	}
+	res.IsLiked = likeCount > 0
+	res.IsFavorited = favoriteCount > 0
+
+	return res, nil
+}
+
+// ListUserFavorites 分页查询用户收藏的已发布文章，按收藏时间降序排列
+func (repo *ArticleEngagementRepositoryImpl) ListUserFavorites(ctx context.Context, page, pageSize int, userID int) ([]dto.FavoriteArticleResponse, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var favorites []dto.FavoriteArticleResponse
+
+	query := repo.db.WithContext(ctx).Table("article_favorites fav").
+		Select(articleListColumns+", fav.create_time AS favorite_time").
+		Joins("JOIN articles a ON a.article_id = fav.article_id").
+		Joins("LEFT JOIN field_types f ON a.field_type = f.field_code").
+		Joins("LEFT JOIN article_types at ON a.article_type = at.type_code").
+		Where("fav.user_id = ?", userID).
+		Where("a.is_deleted = ? AND a.status = ? AND a.release_time <= ?", utils.DeletedFlagNo, model.ArticleStatusPublished, time.Now()).
+		Order("fav.create_time DESC")
+
+	// 计算总数
+	var total int64
+	countQuery := query.Session(&gorm.Session{})
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 查询数据
+	if err := query.Offset(offset).Limit(pageSize).Find(&favorites).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return favorites, total, nil
+}
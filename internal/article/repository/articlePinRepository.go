@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// ArticlePinRepository 数据访问接口，定义数据访问的方法集
+type ArticlePinRepository interface {
+	// ListPins 查询指定列表的置顶，包含已过期的置顶
+	ListPins(ctx context.Context, scope string, scopeValue string) ([]dto.PinResponse, error)
+	// GetPinByID 根据ID查询置顶
+	GetPinByID(ctx context.Context, pinID int) (*model.ArticlePin, error)
+	// CreatePin 创建置顶
+	CreatePin(ctx context.Context, pin *model.ArticlePin) error
+	// UpdatePin 更新置顶
+	UpdatePin(ctx context.Context, pinID int, updateFields map[string]interface{}) error
+	// DeletePin 删除置顶
+	DeletePin(ctx context.Context, pinID int) error
+	// ReorderPins 按ID列表顺序重设排序值
+	ReorderPins(ctx context.Context, pinIDs []int, userID int) error
+}
+
+// ArticlePinRepositoryImpl 实现接口的具体结构体
+type ArticlePinRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewArticlePinRepository 创建数据访问实例
+func NewArticlePinRepository(db *gorm.DB) ArticlePinRepository {
+	return &ArticlePinRepositoryImpl{db: db}
+}
+
+// ListPins 查询指定列表的置顶，按生效顺序排列
+func (repo *ArticlePinRepositoryImpl) ListPins(ctx context.Context, scope string, scopeValue string) ([]dto.PinResponse, error) {
+	pins := make([]dto.PinResponse, 0)
+	if err := repo.db.WithContext(ctx).Table("article_pins p").
+		Select("p.id, p.article_id, a.article_title, a.status, a.is_deleted, p.scope, p.scope_value, p.sort_order, p.expire_time, p.update_time, p.update_user").
+		Joins("LEFT JOIN articles a ON a.article_id = p.article_id").
+		Where("p.scope = ? AND p.scope_value = ?", scope, scopeValue).
+		Order("p.sort_order ASC, p.id ASC").
+		Scan(&pins).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询置顶列表失败: %w", err))
+	}
+	return pins, nil
+}
+
+// GetPinByID 根据ID查询置顶，不存在时返回nil
+func (repo *ArticlePinRepositoryImpl) GetPinByID(ctx context.Context, pinID int) (*model.ArticlePin, error) {
+	var pin model.ArticlePin
+	if err := repo.db.WithContext(ctx).First(&pin, pinID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询置顶失败: %w", err))
+	}
+	return &pin, nil
+}
+
+// CreatePin 创建置顶
+func (repo *ArticlePinRepositoryImpl) CreatePin(ctx context.Context, pin *model.ArticlePin) error {
+	if err := repo.db.WithContext(ctx).Create(pin).Error; err != nil {
+		if ok, _ := utils.IsUniqueConstraintError(err); ok {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "该文章已在此列表中置顶")
+		}
+		return utils.NewSystemError(fmt.Errorf("创建置顶失败: %w", err))
+	}
+	return nil
+}
+
+// UpdatePin 更新置顶
+func (repo *ArticlePinRepositoryImpl) UpdatePin(ctx context.Context, pinID int, updateFields map[string]interface{}) error {
+	if err := repo.db.WithContext(ctx).Model(&model.ArticlePin{}).
+		Where("id = ?", pinID).
+		Updates(updateFields).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("更新置顶失败: %w", err))
+	}
+	return nil
+}
+
+// DeletePin 删除置顶，置顶不保留历史，直接物理删除
+func (repo *ArticlePinRepositoryImpl) DeletePin(ctx context.Context, pinID int) error {
+	if err := repo.db.WithContext(ctx).Delete(&model.ArticlePin{}, pinID).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("删除置顶失败: %w", err))
+	}
+	return nil
+}
+
+// ReorderPins 按ID列表顺序将排序值依次设为1、2、3……
+func (repo *ArticlePinRepositoryImpl) ReorderPins(ctx context.Context, pinIDs []int, userID int) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, pinID := range pinIDs {
+			if err := tx.Model(&model.ArticlePin{}).
+				Where("id = ?", pinID).
+				Updates(map[string]interface{}{"sort_order": i + 1, "update_user": userID}).Error; err != nil {
+				return utils.NewSystemError(fmt.Errorf("更新置顶排序失败: %w", err))
+			}
+		}
+		return nil
+	})
+}
+
+// movePins 将旧范围值下的置顶迁移到新范围值，用于领域、文章类型修改编码或删除时迁移文章
+// 文章已在新范围值下置顶的，丢弃旧范围值下的置顶，避免违反唯一索引
+func movePins(ctx context.Context, tx *gorm.DB, scope string, oldValue string, newValue string) error {
+	if err := tx.WithContext(ctx).
+		Where("scope = ? AND scope_value = ? AND article_id IN (?)", scope, oldValue,
+			tx.Raw("SELECT article_id FROM (SELECT article_id FROM article_pins WHERE scope = ? AND scope_value = ?) t", scope, newValue)).
+		Delete(&model.ArticlePin{}).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("清理重复置顶失败: %w", err))
+	}
+	if err := tx.WithContext(ctx).Model(&model.ArticlePin{}).
+		Where("scope = ? AND scope_value = ?", scope, oldValue).
+		UpdateColumn("scope_value", newValue).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("迁移置顶失败: %w", err))
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"news-release/internal/article/dto"
 	"news-release/internal/article/model"
 	"news-release/internal/utils"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -15,19 +16,46 @@ import (
 // ArticleRepository 数据访问接口，定义数据访问的方法集
 type ArticleRepository interface {
 	// List 分页查询
-	List(ctx context.Context, page, pageSize int, articleTitle string, articleType string, releaseTime string, fieldType string, isSelection int, queryScope string) ([]dto.ArticleListResponse, int64, error)
+	List(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error)
 	// GetArticleContent 内容查询
 	GetArticleContent(ctx context.Context, articleID int) (*dto.ArticleContentDTO, error)
 	// GetArticleByTitle 根据标题查询文章
 	GetArticleByTitle(ctx context.Context, title string) (*model.Article, error)
+	// GetArticleBySlug 根据别名查询文章，包含已删除的文章
+	GetArticleBySlug(ctx context.Context, slug string) (*model.Article, error)
 	// CreateArticle 创建文章
 	CreateArticle(ctx context.Context, tx *gorm.DB, article *model.Article) error
 	// UpdateArticle 更新文章
 	UpdateArticle(ctx context.Context, tx *gorm.DB, articleID int, updateFields map[string]interface{}) error
+	// RestoreArticle 恢复已删除的文章
+	RestoreArticle(ctx context.Context, tx *gorm.DB, articleID int, userID int) error
 	// ListArticleImage 获取关联图片列表
 	ListArticleImage(ctx context.Context, bizID int) []dto.Image
+	// TransitArticleStatus 变更文章状态，仅当文章当前状态在fromStatus中时更新
+	TransitArticleStatus(ctx context.Context, articleID int, fromStatus []string, updateFields map[string]interface{}) error
+	// PublishDueArticles 将已到发布时间的定时文章更新为已发布，返回发布数量
+	PublishDueArticles(ctx context.Context, now time.Time) (int64, error)
+	// ListRelatedArticles 查询与指定文章相关的已发布文章
+	ListRelatedArticles(ctx context.Context, articleID int, fieldType string, tagIDs []int, limit int) ([]dto.ArticleListResponse, error)
+	// ListFeedArticles 查询订阅源输出的最新已发布文章
+	ListFeedArticles(ctx context.Context, req dto.FeedRequest, limit int) ([]dto.FeedArticleDTO, error)
+	// ListExportArticles 按文章ID升序分批查询待导出的文章
+	ListExportArticles(ctx context.Context, req dto.ExportArticleRequest, afterID int, limit int) ([]model.Article, error)
+	// ListArticlesWithoutText 查询尚未提取正文纯文本的文章
+	ListArticlesWithoutText(ctx context.Context, limit int) ([]model.Article, error)
+	// UpdateArticleContentText 更新文章正文纯文本，不变更更新时间
+	UpdateArticleContentText(ctx context.Context, articleID int, contentText string) error
 }
 
+// articleListColumns 文章列表查询的字段，需配合 field_types f、article_types at 的关联使用
+const articleListColumns = "a.article_id, a.article_title, a.article_type AS article_type_code, a.release_time, a.brief_content, a.is_selection, f.field_name, a.cover_image_url, a.article_source, at.type_name AS article_type, a.status, a.view_count, a.like_count, a.favorite_count, a.slug"
+
+// 热度排序权重：热度 = 浏览量 + 点赞数 * 5 + 收藏数 * 10
+const (
+	popularLikeWeight     = 5
+	popularFavoriteWeight = 10
+)
+
 // ArticleRepositoryImpl 实现接口的具体结构体
 type ArticleRepositoryImpl struct {
 	db *gorm.DB
@@ -39,31 +67,23 @@ func NewArticleRepository(db *gorm.DB) ArticleRepository {
 }
 
 // List 分页查询数据
-func (repo *ArticleRepositoryImpl) List(ctx context.Context, page, pageSize int, articleTitle string, articleType string, releaseTime string, fieldType string, isSelection int, queryScope string) ([]dto.ArticleListResponse, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	offset := (page - 1) * pageSize
+func (repo *ArticleRepositoryImpl) List(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error) {
 	var articles []dto.ArticleListResponse
 	query := repo.db.WithContext(ctx)
 
 	// 构建基础查询
 	query = query.Table("articles a").
-		Select("a.article_id, a.article_title, a.article_type AS article_type_code, a.release_time, a.brief_content, a.is_selection, f.field_name, a.cover_image_url,a.article_source,at.type_name AS article_type").
+		Select(articleListColumns).
 		Joins("LEFT JOIN field_types f ON a.field_type = f.field_code").
 		Joins("LEFT JOIN article_types at ON a.article_type = at.type_code")
 
-	if queryScope != "" {
+	if req.QueryScope != "" {
 		// 如果传入了查询范围，则添加查询条件
 		// 如果传入了查询范围为DELETED，则查询已删除的文章
-		if queryScope == utils.QueryScopeDeleted {
+		if req.QueryScope == utils.QueryScopeDeleted {
 			query = query.Where("a.is_deleted = ?", utils.DeletedFlagYes) // 查询已删除的文章
 		}
-		if queryScope == utils.QueryScopeAll {
+		if req.QueryScope == utils.QueryScopeAll {
 			// 如果传入了查询范围为ALL，则查询所有文章（包括已删除和未删除的）
 		}
 	} else {
@@ -72,38 +92,81 @@ func (repo *ArticleRepositoryImpl) List(ctx context.Context, page, pageSize int,
 	}
 
 	// 添加条件查询
-	if releaseTime != "" {
-		query = query.Where("a.release_time >= ?", releaseTime)
+	if req.ReleaseTime != "" {
+		query = query.Where("a.release_time >= ?", req.ReleaseTime)
+	}
+	if req.ArticleTitle != "" {
+		query = query.Where("a.article_title LIKE ?", "%"+req.ArticleTitle+"%")
 	}
-	if articleTitle != "" {
-		query = query.Where("a.article_title LIKE ?", "%"+articleTitle+"%")
+	if req.FieldType != "" {
+		query = query.Where("a.field_type = ?", req.FieldType)
 	}
-	if fieldType != "" {
-		query = query.Where("a.field_type = ?", fieldType)
+	if req.ArticleType != "" {
+		query = query.Where("a.article_type = ?", req.ArticleType)
 	}
-	if articleType != "" {
-		query = query.Where("a.article_type = ?", articleType)
+	if req.IsSelection != 0 {
+		query = query.Where("a.is_selection = ?", req.IsSelection)
 	}
-	if isSelection != 0 {
-		query = query.Where("a.is_selection = ?", isSelection)
+	if len(req.TagIDList) > 0 {
+		// 已删除的标签不再参与筛选
+		taggedArticles := repo.db.Table("article_tag_mappings m").
+			Select("m.article_id").
+			Joins("JOIN tags t ON t.tag_id = m.tag_id AND t.is_deleted = ?", utils.DeletedFlagNo).
+			Where("m.tag_id IN ?", req.TagIDList)
+		query = query.Where("a.article_id IN (?)", taggedArticles)
+	}
+	if req.Status != "" {
+		query = query.Where("a.status = ?", req.Status)
+		// 已发布文章还需要确认发布时间已到
+		if req.Status == model.ArticleStatusPublished {
+			query = query.Where("a.release_time <= ?", time.Now())
+		}
+	}
+
+	// 置顶文章排在最前，置顶之间按排序值升序，每篇文章在同一列表中最多一条置顶，关联不影响总数
+	if req.PinScope != "" {
+		query = query.Select(articleListColumns+", p.id IS NOT NULL AS is_pinned, COALESCE(p.sort_order, 0) AS pin_order").
+			Joins("LEFT JOIN article_pins p ON p.article_id = a.article_id AND p.scope = ? AND p.scope_value = ? AND (p.expire_time IS NULL OR p.expire_time > ?)",
+				req.PinScope, req.PinValue, time.Now()).
+			Order("p.id IS NULL").
+			Order("p.sort_order ASC")
+	}
+
+	// 计算总数，游标条件不影响总数
+	if pg.WithTotal {
+		if err := query.Session(&gorm.Session{}).Count(&pg.Total).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+		}
 	}
 
-	// 按发布时间降序排列
-	query = query.Order("a.release_time DESC")
+	// 游标分页：置顶文章依次比较排序值、发布时间和文章ID，翻过置顶文章后只查询非置顶文章
+	if pg.Cursor != nil {
+		keyset, args := pg.Cursor.After("a.release_time", "a.article_id", true)
+		switch {
+		case req.PinScope == "":
+			query = query.Where(keyset, args...)
+		case pg.Cursor.Pinned:
+			query = query.Where("(p.id IS NOT NULL AND (p.sort_order > ? OR (p.sort_order = ? AND "+keyset+"))) OR p.id IS NULL",
+				append([]interface{}{pg.Cursor.Order, pg.Cursor.Order}, args...)...)
+		default:
+			query = query.Where("p.id IS NULL AND "+keyset, args...)
+		}
+	}
 
-	// 计算总数
-	var total int64
-	countQuery := query.Session(&gorm.Session{})
-	if err := countQuery.Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	// 排序：默认按发布时间降序，热度排序时热度相同再按发布时间降序，发布时间相同按文章ID降序
+	if req.SortBy == model.ArticleSortPopular {
+		query = query.Order(fmt.Sprintf("a.view_count + a.like_count * %d + a.favorite_count * %d DESC", popularLikeWeight, popularFavoriteWeight))
 	}
+	query = query.Order("a.release_time DESC").Order("a.article_id DESC")
 
 	// 查询数据
-	if err := query.Offset(offset).Limit(pageSize).Find(&articles).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	if err := query.Offset(pg.Offset()).Limit(pg.Limit()).Find(&articles).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
 	}
 
-	return articles, total, nil
+	return utils.TrimPage(pg, articles, func(a dto.ArticleListResponse) utils.Cursor {
+		return utils.Cursor{Time: a.ReleaseTime, ID: a.ArticleID, Pinned: a.IsPinned, Order: a.PinOrder}
+	}), nil
 }
 
 // GetArticleContent 内容查询
@@ -113,7 +176,8 @@ func (repo *ArticleRepositoryImpl) GetArticleContent(ctx context.Context, articl
 	query := repo.db.WithContext(ctx).Table("articles a").
 		Select(`a.article_id, a.article_title, f.field_name, a.release_time, a.article_content, 
 				a.article_type AS article_type_code, at.type_name AS article_type, a.article_source, 
-				a.cover_image_url, a.brief_content, a.is_selection, a.field_type`).
+				a.cover_image_url, a.brief_content, a.is_selection, a.field_type, a.status, a.review_comment, a.published_time, a.is_deleted,
+				a.view_count, a.like_count, a.favorite_count, a.slug, a.meta_description, a.og_image_url`).
 		Joins("LEFT JOIN field_types f ON a.field_type = f.field_code").
 		Joins("LEFT JOIN article_types at ON a.article_type = at.type_code").
 		Where("a.article_id = ?", articleID)
@@ -157,6 +221,21 @@ func (repo *ArticleRepositoryImpl) GetArticleByTitle(ctx context.Context, title
 	return &article, nil
 }
 
+// GetArticleBySlug 根据别名查询文章
+// 别名的唯一索引包含已删除的文章，因此查询时不过滤删除标志
+func (repo *ArticleRepositoryImpl) GetArticleBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	var article model.Article
+
+	if err := repo.db.WithContext(ctx).Select("article_id, slug, is_deleted").Where("slug = ?", slug).First(&article).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &article, nil
+}
+
 // UpdateArticle 更新文章字段
 func (repo *ArticleRepositoryImpl) UpdateArticle(ctx context.Context, tx *gorm.DB, articleID int, updateFields map[string]interface{}) error {
 	// 执行更新（仅更新未删除的文章）
@@ -175,6 +254,26 @@ func (repo *ArticleRepositoryImpl) UpdateArticle(ctx context.Context, tx *gorm.D
 	return nil
 }
 
+// RestoreArticle 恢复已删除的文章（仅更新已删除的文章）
+func (repo *ArticleRepositoryImpl) RestoreArticle(ctx context.Context, tx *gorm.DB, articleID int, userID int) error {
+	result := tx.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("article_id = ? AND is_deleted = ?", articleID, utils.DeletedFlagYes).
+		Updates(map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagNo,
+			"update_user": userID,
+		})
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("恢复文章失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict, "文章已被恢复，请刷新页面后重试")
+	}
+
+	return nil
+}
+
 // ListArticleImage 获取关联图片列表
 func (repo *ArticleRepositoryImpl) ListArticleImage(ctx context.Context, bizID int) []dto.Image {
 	var images []dto.Image
@@ -191,3 +290,165 @@ func (repo *ArticleRepositoryImpl) ListArticleImage(ctx context.Context, bizID i
 
 	return images
 }
+
+// TransitArticleStatus 变更文章状态
+// 通过在更新条件中限定当前状态，保证并发操作下状态流转的正确性
+func (repo *ArticleRepositoryImpl) TransitArticleStatus(ctx context.Context, articleID int, fromStatus []string, updateFields map[string]interface{}) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("article_id = ? AND is_deleted = ?", articleID, utils.DeletedFlagNo).
+		Where("status IN (?)", fromStatus).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新文章状态失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict, "文章状态已变更，请刷新页面后重试")
+	}
+
+	return nil
+}
+
+// PublishDueArticles 将已到发布时间的定时文章更新为已发布，首次发布的文章记录发布时间
+func (repo *ArticleRepositoryImpl) PublishDueArticles(ctx context.Context, now time.Time) (int64, error) {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("status = ? AND release_time <= ? AND is_deleted = ?", model.ArticleStatusScheduled, now, utils.DeletedFlagNo).
+		Updates(map[string]interface{}{
+			"status":         model.ArticleStatusPublished,
+			"published_time": gorm.Expr("COALESCE(published_time, release_time)"),
+		})
+
+	if result.Error != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("发布定时文章失败: %w", result.Error))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// 相关文章排序权重
+const (
+	relatedTagWeight    = 3  // 每个共同标签的得分
+	relatedFieldWeight  = 2  // 领域类型相同的得分
+	relatedRecencyDays  = 30 // 时间衰减周期（天），发布越久得分越低
+	relatedArticleLimit = 5  // 默认返回数量
+)
+
+// ListRelatedArticles 查询相关文章
+// 候选范围为与指定文章有共同标签或领域类型相同的其他已发布文章，
+// 按 共同标签数 * 3 + 同领域 * 2 + 1 / (1 + 发布天数 / 30) 降序排列
+func (repo *ArticleRepositoryImpl) ListRelatedArticles(ctx context.Context, articleID int, fieldType string, tagIDs []int, limit int) ([]dto.ArticleListResponse, error) {
+	if limit < 1 {
+		limit = relatedArticleLimit
+	}
+	// 无标签时使用不存在的标签ID占位，避免 IN () 语法错误
+	if len(tagIDs) == 0 {
+		tagIDs = []int{0}
+	}
+
+	var articles []dto.ArticleListResponse
+	sharedTags := repo.db.Table("article_tag_mappings m").
+		Select("COUNT(*)").
+		Joins("JOIN tags t ON t.tag_id = m.tag_id AND t.is_deleted = ?", utils.DeletedFlagNo).
+		Where("m.article_id = a.article_id AND m.tag_id IN ?", tagIDs)
+	taggedArticles := repo.db.Table("article_tag_mappings m").
+		Select("m.article_id").
+		Joins("JOIN tags t ON t.tag_id = m.tag_id AND t.is_deleted = ?", utils.DeletedFlagNo).
+		Where("m.tag_id IN ?", tagIDs)
+
+	err := repo.db.WithContext(ctx).Table("articles a").
+		Select(articleListColumns+", "+
+			"((?) * ? + IF(a.field_type = ?, ?, 0) + 1 / (1 + DATEDIFF(NOW(), a.release_time) / ?)) AS related_score",
+			sharedTags, relatedTagWeight, fieldType, relatedFieldWeight, relatedRecencyDays).
+		Joins("LEFT JOIN field_types f ON a.field_type = f.field_code").
+		Joins("LEFT JOIN article_types at ON a.article_type = at.type_code").
+		Where("a.article_id <> ? AND a.is_deleted = ? AND a.status = ? AND a.release_time <= ?",
+			articleID, utils.DeletedFlagNo, model.ArticleStatusPublished, time.Now()).
+		Where("a.field_type = ? OR a.article_id IN (?)", fieldType, taggedArticles).
+		Order("related_score DESC, a.release_time DESC").
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return articles, nil
+}
+
+// ListFeedArticles 查询最新的已发布文章及正文，按发布时间降序排列
+func (repo *ArticleRepositoryImpl) ListFeedArticles(ctx context.Context, req dto.FeedRequest, limit int) ([]dto.FeedArticleDTO, error) {
+	var articles []dto.FeedArticleDTO
+
+	query := repo.db.WithContext(ctx).Table("articles a").
+		Select(`a.article_id, a.article_title, a.brief_content, a.field_type, f.field_name, a.release_time, a.article_content,
+				a.article_type AS article_type_code, at.type_name AS article_type, a.article_source, a.cover_image_url,
+				a.is_selection, a.status, a.slug, a.update_time`).
+		Joins("LEFT JOIN field_types f ON a.field_type = f.field_code").
+		Joins("LEFT JOIN article_types at ON a.article_type = at.type_code").
+		Where("a.is_deleted = ? AND a.status = ? AND a.release_time <= ?", utils.DeletedFlagNo, model.ArticleStatusPublished, time.Now())
+
+	if req.FieldType != "" {
+		query = query.Where("a.field_type = ?", req.FieldType)
+	}
+	if req.ArticleType != "" {
+		query = query.Where("a.article_type = ?", req.ArticleType)
+	}
+
+	if err := query.Order("a.release_time DESC, a.article_id DESC").Limit(limit).Find(&articles).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询订阅源文章失败: %v", err))
+	}
+
+	return articles, nil
+}
+
+// ListExportArticles 查询文章ID大于afterID的未删除文章，按文章ID升序排列
+func (repo *ArticleRepositoryImpl) ListExportArticles(ctx context.Context, req dto.ExportArticleRequest, afterID int, limit int) ([]model.Article, error) {
+	var articles []model.Article
+
+	query := repo.db.WithContext(ctx).
+		Where("article_id > ? AND is_deleted = ?", afterID, utils.DeletedFlagNo)
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.FieldType != "" {
+		query = query.Where("field_type = ?", req.FieldType)
+	}
+	if req.ArticleType != "" {
+		query = query.Where("article_type = ?", req.ArticleType)
+	}
+
+	if err := query.Order("article_id").Limit(limit).Find(&articles).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询导出文章失败: %v", err))
+	}
+
+	return articles, nil
+}
+
+// ListArticlesWithoutText 查询 content_text 为空值的文章，包含已删除的文章
+func (repo *ArticleRepositoryImpl) ListArticlesWithoutText(ctx context.Context, limit int) ([]model.Article, error) {
+	var articles []model.Article
+
+	if err := repo.db.WithContext(ctx).
+		Select("article_id, article_content").
+		Where("content_text IS NULL").
+		Order("article_id").
+		Limit(limit).
+		Find(&articles).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询待提取纯文本的文章失败: %v", err))
+	}
+
+	return articles, nil
+}
+
+// UpdateArticleContentText 更新文章正文纯文本
+// 纯文本由正文派生，使用 UpdateColumn 避免变更 update_time 影响订阅源和站点地图
+func (repo *ArticleRepositoryImpl) UpdateArticleContentText(ctx context.Context, articleID int, contentText string) error {
+	if err := repo.db.WithContext(ctx).
+		Model(&model.Article{}).
+		Where("article_id = ?", articleID).
+		UpdateColumn("content_text", contentText).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("更新文章纯文本失败: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleRevisionRepository 文章修订记录数据访问接口
+type ArticleRevisionRepository interface {
+	// ExecTransaction 执行事务
+	ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// CreateRevision 根据文章当前数据生成一条修订记录，必须在写入文章的同一事务中调用
+	CreateRevision(ctx context.Context, tx *gorm.DB, articleID int, action string, restoreFrom int) error
+	// ListRevisions 分页查询文章的修订记录
+	ListRevisions(ctx context.Context, page, pageSize int, articleID int) ([]dto.RevisionListResponse, int64, error)
+	// GetRevision 查询文章的指定修订记录
+	GetRevision(ctx context.Context, articleID int, revisionID int) (*model.ArticleRevision, error)
+}
+
+// ArticleRevisionRepositoryImpl 实现接口的具体结构体
+type ArticleRevisionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewArticleRevisionRepository 创建数据访问实例
+func NewArticleRevisionRepository(db *gorm.DB) ArticleRevisionRepository {
+	return &ArticleRevisionRepositoryImpl{db: db}
+}
+
+// ExecTransaction 实现事务执行（使用 GORM 的 Transaction 方法）
+func (repo *ArticleRevisionRepositoryImpl) ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return repo.db.WithContext(ctx).Transaction(fn)
+}
+
+// CreateRevision 根据文章当前数据生成一条修订记录
+// 读取文章时加行锁，保证同一文章的修订版本号在并发编辑时依次递增
+func (repo *ArticleRevisionRepositoryImpl) CreateRevision(ctx context.Context, tx *gorm.DB, articleID int, action string, restoreFrom int) error {
+	var article model.Article
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("article_id = ?", articleID).
+		First(&article).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("读取文章数据生成修订记录失败: %w", err))
+	}
+
+	// 计算下一个修订版本号
+	var latestNo int
+	if err := tx.WithContext(ctx).
+		Model(&model.ArticleRevision{}).
+		Select("COALESCE(MAX(revision_no), 0)").
+		Where("article_id = ?", articleID).
+		Scan(&latestNo).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("查询文章最新修订版本号失败: %w", err))
+	}
+
+	revision := &model.ArticleRevision{
+		ArticleID:      articleID,
+		RevisionNo:     latestNo + 1,
+		Action:         action,
+		RestoreFrom:    restoreFrom,
+		ArticleTitle:   article.ArticleTitle,
+		ArticleType:    article.ArticleType,
+		BriefContent:   article.BriefContent,
+		ArticleContent: article.ArticleContent,
+		IsSelection:    article.IsSelection,
+		FieldType:      article.FieldType,
+		CoverImageURL:  article.CoverImageURL,
+		ArticleSource:  article.ArticleSource,
+		EditUser:       article.UpdateUser,
+	}
+	if err := tx.WithContext(ctx).Create(revision).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建文章修订记录失败: %w", err))
+	}
+
+	return nil
+}
+
+// ListRevisions 分页查询文章的修订记录，按版本号降序排列
+func (repo *ArticleRevisionRepositoryImpl) ListRevisions(ctx context.Context, page, pageSize int, articleID int) ([]dto.RevisionListResponse, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var revisions []dto.RevisionListResponse
+
+	query := repo.db.WithContext(ctx).Table("article_revisions r").
+		Select("r.id, r.article_id, r.revision_no, r.action, r.restore_from, r.article_title, r.edit_user, u.nickname AS edit_user_name, r.create_time").
+		Joins("LEFT JOIN users u ON u.user_id = r.edit_user").
+		Where("r.article_id = ?", articleID).
+		Order("r.revision_no DESC")
+
+	// 计算总数
+	var total int64
+	countQuery := query.Session(&gorm.Session{})
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 查询数据
+	if err := query.Offset(offset).Limit(pageSize).Find(&revisions).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return revisions, total, nil
+}
+
+// GetRevision 查询文章的指定修订记录
+func (repo *ArticleRevisionRepositoryImpl) GetRevision(ctx context.Context, articleID int, revisionID int) (*model.ArticleRevision, error) {
+	var revision model.ArticleRevision
+
+	err := repo.db.WithContext(ctx).
+		Where("id = ? AND article_id = ?", revisionID, articleID).
+		First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "修订记录不存在")
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &revision, nil
+}
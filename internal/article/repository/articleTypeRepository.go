@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/article/model"
+	"news-release/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// ArticleTypeRepository 数据访问接口，定义数据访问的方法集
+type ArticleTypeRepository interface {
+	// ExecTransaction 在事务中执行操作
+	ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// ListArticleTypes 获取文章类型列表
+	ListArticleTypes(ctx context.Context) ([]*model.ArticleType, error)
+	// GetArticleTypeByID 根据ID查询未删除的文章类型
+	GetArticleTypeByID(ctx context.Context, typeID int) (*model.ArticleType, error)
+	// GetArticleTypeByCode 根据编码查询文章类型，包含已删除的文章类型
+	GetArticleTypeByCode(ctx context.Context, typeCode string) (*model.ArticleType, error)
+	// CreateArticleType 创建文章类型
+	CreateArticleType(ctx context.Context, articleType *model.ArticleType) error
+	// UpdateArticleType 更新文章类型
+	UpdateArticleType(ctx context.Context, tx *gorm.DB, typeID int, updateFields map[string]interface{}) error
+	// CountArticleTypeArticles 统计引用类型编码的文章数量
+	CountArticleTypeArticles(ctx context.Context, typeCode string) (int64, error)
+	// ReplaceArticleType 将引用旧编码的文章及修订记录改为新编码
+	ReplaceArticleType(ctx context.Context, tx *gorm.DB, oldCode string, newCode string) error
+	// ReorderArticleTypes 按ID列表顺序重设排序值
+	ReorderArticleTypes(ctx context.Context, typeIDs []int, userID int) error
+}
+
+// ArticleTypeRepositoryImpl 实现接口的具体结构体
+type ArticleTypeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewArticleTypeRepository 创建数据访问实例
+func NewArticleTypeRepository(db *gorm.DB) ArticleTypeRepository {
+	return &ArticleTypeRepositoryImpl{db: db}
+}
+
+// ExecTransaction 实现事务执行（使用 GORM 的 Transaction 方法）
+func (repo *ArticleTypeRepositoryImpl) ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return repo.db.WithContext(ctx).Transaction(fn)
+}
+
+// ListArticleTypes 获取未删除的文章类型列表，按排序值升序排列
+func (repo *ArticleTypeRepositoryImpl) ListArticleTypes(ctx context.Context) ([]*model.ArticleType, error) {
+	var articleTypes []*model.ArticleType
+
+	result := repo.db.WithContext(ctx).
+		Where("is_deleted = ?", utils.DeletedFlagNo).
+		Order("sort_order ASC, id ASC").
+		Find(&articleTypes)
+	err := result.Error
+
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return articleTypes, nil
+}
+
+// GetArticleTypeByID 根据ID查询未删除的文章类型，不存在时返回nil
+func (repo *ArticleTypeRepositoryImpl) GetArticleTypeByID(ctx context.Context, typeID int) (*model.ArticleType, error) {
+	var articleType model.ArticleType
+
+	if err := repo.db.WithContext(ctx).
+		Where("id = ? AND is_deleted = ?", typeID, utils.DeletedFlagNo).
+		First(&articleType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &articleType, nil
+}
+
+// GetArticleTypeByCode 根据编码查询文章类型，编码的唯一索引包含已删除的文章类型，因此不过滤删除标志
+func (repo *ArticleTypeRepositoryImpl) GetArticleTypeByCode(ctx context.Context, typeCode string) (*model.ArticleType, error) {
+	var articleType model.ArticleType
+
+	if err := repo.db.WithContext(ctx).Where("type_code = ?", typeCode).First(&articleType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &articleType, nil
+}
+
+// CreateArticleType 创建文章类型
+func (repo *ArticleTypeRepositoryImpl) CreateArticleType(ctx context.Context, articleType *model.ArticleType) error {
+	if err := repo.db.WithContext(ctx).Create(articleType).Error; err != nil {
+		ok, _ := utils.IsUniqueConstraintError(err)
+		if ok {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "文章类型已存在")
+		}
+		return utils.NewSystemError(fmt.Errorf("创建文章类型失败: %w", err))
+	}
+	return nil
+}
+
+// UpdateArticleType 更新未删除的文章类型
+func (repo *ArticleTypeRepositoryImpl) UpdateArticleType(ctx context.Context, tx *gorm.DB, typeID int, updateFields map[string]interface{}) error {
+	// 先检查记录是否存在且未被删除
+	var count int64
+	if err := tx.WithContext(ctx).
+		Model(&model.ArticleType{}).
+		Where("id = ? AND is_deleted = ?", typeID, utils.DeletedFlagNo).
+		Count(&count).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("检查文章类型存在性失败: %w", err))
+	}
+
+	if count == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章类型不存在或已被删除")
+	}
+
+	// 执行更新操作
+	result := tx.WithContext(ctx).Model(&model.ArticleType{}).
+		Where("id = ?", typeID).
+		Updates(updateFields)
+
+	err := result.Error
+	if err != nil {
+		ok, _ := utils.IsUniqueConstraintError(err)
+		if ok {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "文章类型已存在")
+		}
+		return utils.NewSystemError(fmt.Errorf("更新文章类型失败: %w", err))
+	}
+	return nil
+}
+
+// CountArticleTypeArticles 统计引用类型编码的文章数量，包含已删除的文章，避免文章恢复后引用失效
+func (repo *ArticleTypeRepositoryImpl) CountArticleTypeArticles(ctx context.Context, typeCode string) (int64, error) {
+	var count int64
+
+	if err := repo.db.WithContext(ctx).Model(&model.Article{}).
+		Where("article_type = ?", typeCode).
+		Count(&count).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("统计文章类型关联文章失败: %v", err))
+	}
+
+	return count, nil
+}
+
+// ReplaceArticleType 将引用旧编码的文章及修订记录改为新编码，并迁移文章类型列表的置顶
+// 类型调整不属于内容修改，使用 UpdateColumn 不变更文章的更新时间
+func (repo *ArticleTypeRepositoryImpl) ReplaceArticleType(ctx context.Context, tx *gorm.DB, oldCode string, newCode string) error {
+	if err := tx.WithContext(ctx).Model(&model.Article{}).
+		Where("article_type = ?", oldCode).
+		UpdateColumn("article_type", newCode).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("迁移文章的文章类型失败: %w", err))
+	}
+	if err := tx.WithContext(ctx).Model(&model.ArticleRevision{}).
+		Where("article_type = ?", oldCode).
+		UpdateColumn("article_type", newCode).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("迁移文章修订记录的文章类型失败: %w", err))
+	}
+	return movePins(ctx, tx, model.PinScopeArticleType, oldCode, newCode)
+}
+
+// ReorderArticleTypes 按ID列表顺序将排序值依次设为1、2、3……
+func (repo *ArticleTypeRepositoryImpl) ReorderArticleTypes(ctx context.Context, typeIDs []int, userID int) error {
+	return repo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		for i, typeID := range typeIDs {
+			if err := tx.Model(&model.ArticleType{}).
+				Where("id = ?", typeID).
+				Updates(map[string]interface{}{"sort_order": i + 1, "update_user": userID}).Error; err != nil {
+				return utils.NewSystemError(fmt.Errorf("更新文章类型排序失败: %w", err))
+			}
+		}
+		return nil
+	})
+}
@@ -2,20 +2,34 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"gorm.io/gorm"
 	"news-release/internal/article/model"
 	"news-release/internal/utils"
+
+	"gorm.io/gorm"
 )
 
 // FieldTypeRepository 数据访问接口，定义数据访问的方法集
 type FieldTypeRepository interface {
+	// ExecTransaction 在事务中执行操作
+	ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
 	// GetFieldType 获取领域类型列表
 	GetFieldType(ctx context.Context) ([]*model.FieldType, error)
+	// GetFieldTypeByID 根据ID查询未删除的领域类型
+	GetFieldTypeByID(ctx context.Context, fieldID int) (*model.FieldType, error)
+	// GetFieldTypeByCode 根据编码查询领域类型，包含已删除的领域类型
+	GetFieldTypeByCode(ctx context.Context, fieldCode string) (*model.FieldType, error)
 	// CreateFieldType 创建领域类型
 	CreateFieldType(ctx context.Context, fieldType *model.FieldType) error
 	// UpdateFieldType 更新领域类型
-	UpdateFieldType(ctx context.Context, fieldID int, updateFields map[string]interface{}) error
+	UpdateFieldType(ctx context.Context, tx *gorm.DB, fieldID int, updateFields map[string]interface{}) error
+	// CountFieldTypeArticles 统计引用领域编码的文章数量
+	CountFieldTypeArticles(ctx context.Context, fieldCode string) (int64, error)
+	// ReplaceArticleFieldType 将引用旧编码的文章及修订记录改为新编码
+	ReplaceArticleFieldType(ctx context.Context, tx *gorm.DB, oldCode string, newCode string) error
+	// ReorderFieldTypes 按ID列表顺序重设排序值
+	ReorderFieldTypes(ctx context.Context, fieldIDs []int, userID int) error
 }
 
 // FieldTypeRepositoryImpl 实现接口的具体结构体
@@ -28,11 +42,19 @@ func NewFieldTypeRepository(db *gorm.DB) FieldTypeRepository {
 	return &FieldTypeRepositoryImpl{db: db}
 }
 
-// GetFieldType 获取领域类型列表
+// ExecTransaction 实现事务执行（使用 GORM 的 Transaction 方法）
+func (repo *FieldTypeRepositoryImpl) ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return repo.db.WithContext(ctx).Transaction(fn)
+}
+
+// GetFieldType 获取未删除的领域类型列表，按排序值升序排列
 func (repo *FieldTypeRepositoryImpl) GetFieldType(ctx context.Context) ([]*model.FieldType, error) {
 	var fieldType []*model.FieldType
 
-	result := repo.db.WithContext(ctx).Find(&fieldType)
+	result := repo.db.WithContext(ctx).
+		Where("is_deleted = ?", utils.DeletedFlagNo).
+		Order("sort_order ASC, field_id ASC").
+		Find(&fieldType)
 	err := result.Error
 
 	if err != nil {
@@ -42,6 +64,36 @@ func (repo *FieldTypeRepositoryImpl) GetFieldType(ctx context.Context) ([]*model
 	return fieldType, nil
 }
 
+// GetFieldTypeByID 根据ID查询未删除的领域类型，不存在时返回nil
+func (repo *FieldTypeRepositoryImpl) GetFieldTypeByID(ctx context.Context, fieldID int) (*model.FieldType, error) {
+	var fieldType model.FieldType
+
+	if err := repo.db.WithContext(ctx).
+		Where("field_id = ? AND is_deleted = ?", fieldID, utils.DeletedFlagNo).
+		First(&fieldType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &fieldType, nil
+}
+
+// GetFieldTypeByCode 根据编码查询领域类型，编码的唯一索引包含已删除的领域类型，因此不过滤删除标志
+func (repo *FieldTypeRepositoryImpl) GetFieldTypeByCode(ctx context.Context, fieldCode string) (*model.FieldType, error) {
+	var fieldType model.FieldType
+
+	if err := repo.db.WithContext(ctx).Where("field_code = ?", fieldCode).First(&fieldType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &fieldType, nil
+}
+
 // CreateFieldType 创建领域类型
 func (repo *FieldTypeRepositoryImpl) CreateFieldType(ctx context.Context, fieldType *model.FieldType) error {
 	if err := repo.db.WithContext(ctx).Create(fieldType).Error; err != nil {
@@ -54,11 +106,11 @@ func (repo *FieldTypeRepositoryImpl) CreateFieldType(ctx context.Context, fieldT
 	return nil
 }
 
-// UpdateFieldType 更新领域类型
-func (repo *FieldTypeRepositoryImpl) UpdateFieldType(ctx context.Context, fieldID int, updateFields map[string]interface{}) error {
+// UpdateFieldType 更新未删除的领域类型
+func (repo *FieldTypeRepositoryImpl) UpdateFieldType(ctx context.Context, tx *gorm.DB, fieldID int, updateFields map[string]interface{}) error {
 	// 先检查记录是否存在且未被删除
 	var count int64
-	if err := repo.db.WithContext(ctx).
+	if err := tx.WithContext(ctx).
 		Model(&model.FieldType{}).
 		Where("field_id = ? AND is_deleted = ?", fieldID, utils.DeletedFlagNo).
 		Count(&count).Error; err != nil {
@@ -70,7 +122,7 @@ func (repo *FieldTypeRepositoryImpl) UpdateFieldType(ctx context.Context, fieldI
 	}
 
 	// 执行更新操作
-	result := repo.db.WithContext(ctx).Model(&model.FieldType{}).
+	result := tx.WithContext(ctx).Model(&model.FieldType{}).
 		Where("field_id = ?", fieldID).
 		Updates(updateFields)
 
@@ -84,3 +136,46 @@ func (repo *FieldTypeRepositoryImpl) UpdateFieldType(ctx context.Context, fieldI
 	}
 	return nil
 }
+
+// CountFieldTypeArticles 统计引用领域编码的文章数量，包含已删除的文章，避免文章恢复后引用失效
+func (repo *FieldTypeRepositoryImpl) CountFieldTypeArticles(ctx context.Context, fieldCode string) (int64, error) {
+	var count int64
+
+	if err := repo.db.WithContext(ctx).Model(&model.Article{}).
+		Where("field_type = ?", fieldCode).
+		Count(&count).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("统计领域类型关联文章失败: %v", err))
+	}
+
+	return count, nil
+}
+
+// ReplaceArticleFieldType 将引用旧编码的文章及修订记录改为新编码，并迁移领域列表的置顶
+// 类型调整不属于内容修改，使用 UpdateColumn 不变更文章的更新时间
+func (repo *FieldTypeRepositoryImpl) ReplaceArticleFieldType(ctx context.Context, tx *gorm.DB, oldCode string, newCode string) error {
+	if err := tx.WithContext(ctx).Model(&model.Article{}).
+		Where("field_type = ?", oldCode).
+		UpdateColumn("field_type", newCode).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("迁移文章领域类型失败: %w", err))
+	}
+	if err := tx.WithContext(ctx).Model(&model.ArticleRevision{}).
+		Where("field_type = ?", oldCode).
+		UpdateColumn("field_type", newCode).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("迁移文章修订记录领域类型失败: %w", err))
+	}
+	return movePins(ctx, tx, model.PinScopeFieldType, oldCode, newCode)
+}
+
+// ReorderFieldTypes 按ID列表顺序将排序值依次设为1、2、3……
+func (repo *FieldTypeRepositoryImpl) ReorderFieldTypes(ctx context.Context, fieldIDs []int, userID int) error {
+	return repo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		for i, fieldID := range fieldIDs {
+			if err := tx.Model(&model.FieldType{}).
+				Where("field_id = ?", fieldID).
+				Updates(map[string]interface{}{"sort_order": i + 1, "update_user": userID}).Error; err != nil {
+				return utils.NewSystemError(fmt.Errorf("更新领域类型排序失败: %w", err))
+			}
+		}
+		return nil
+	})
+}
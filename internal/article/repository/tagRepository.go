@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository 标签数据访问接口
+type TagRepository interface {
+	// ListTags 分页查询标签列表及各标签下已发布文章数量
+	ListTags(ctx context.Context, page, pageSize int, tagName string) ([]dto.TagResponse, int64, error)
+	// GetTag 查询单个标签及其已发布文章数量
+	GetTag(ctx context.Context, tagID int) (*dto.TagResponse, error)
+	// GetTagByName 根据名称查询未删除的标签
+	GetTagByName(ctx context.Context, tagName string) (*model.Tag, error)
+	// CountTags 统计ID列表中未删除的标签数量
+	CountTags(ctx context.Context, tagIDs []int) (int64, error)
+	// CreateTag 创建标签
+	CreateTag(ctx context.Context, tag *model.Tag) error
+	// UpdateTag 更新标签
+	UpdateTag(ctx context.Context, tagID int, updateFields map[string]interface{}) error
+	// ReplaceArticleTags 覆盖文章关联的标签
+	ReplaceArticleTags(ctx context.Context, tx *gorm.DB, articleID int, tagIDs []int) error
+	// ListArticleTags 批量查询文章关联的未删除标签，按文章ID分组
+	ListArticleTags(ctx context.Context, articleIDs []int) (map[int][]dto.Tag, error)
+}
+
+// TagRepositoryImpl 实现接口的具体结构体
+type TagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTagRepository 创建数据访问实例
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &TagRepositoryImpl{db: db}
+}
+
+// tagQuery 构建标签查询，统计各标签下已发布且未删除的文章数量
+func (repo *TagRepositoryImpl) tagQuery(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Table("tags t").
+		Select("t.tag_id, t.tag_name, COUNT(a.article_id) AS article_count").
+		Joins("LEFT JOIN article_tag_mappings m ON m.tag_id = t.tag_id").
+		Joins("LEFT JOIN articles a ON a.article_id = m.article_id AND a.is_deleted = ? AND a.status = ? AND a.release_time <= ?",
+			utils.DeletedFlagNo, model.ArticleStatusPublished, time.Now()).
+		Where("t.is_deleted = ?", utils.DeletedFlagNo).
+		Group("t.tag_id, t.tag_name")
+}
+
+// ListTags 分页查询标签列表，按文章数量降序排列
+func (repo *TagRepositoryImpl) ListTags(ctx context.Context, page, pageSize int, tagName string) ([]dto.TagResponse, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var tags []dto.TagResponse
+
+	// 计算总数
+	var total int64
+	countQuery := repo.db.WithContext(ctx).Model(&model.Tag{}).Where("is_deleted = ?", utils.DeletedFlagNo)
+	if tagName != "" {
+		countQuery = countQuery.Where("tag_name LIKE ?", "%"+tagName+"%")
+	}
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 查询数据
+	query := repo.tagQuery(ctx)
+	if tagName != "" {
+		query = query.Where("t.tag_name LIKE ?", "%"+tagName+"%")
+	}
+	if err := query.Order("article_count DESC, t.tag_id ASC").
+		Offset(offset).Limit(pageSize).
+		Scan(&tags).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return tags, total, nil
+}
+
+// GetTag 查询单个标签及其已发布文章数量
+func (repo *TagRepositoryImpl) GetTag(ctx context.Context, tagID int) (*dto.TagResponse, error) {
+	var tags []dto.TagResponse
+
+	if err := repo.tagQuery(ctx).Where("t.tag_id = ?", tagID).Scan(&tags).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+	if len(tags) == 0 {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "标签不存在或已被删除")
+	}
+
+	return &tags[0], nil
+}
+
+// GetTagByName 根据名称查询未删除的标签，不存在时返回nil
+func (repo *TagRepositoryImpl) GetTagByName(ctx context.Context, tagName string) (*model.Tag, error) {
+	var tag model.Tag
+
+	if err := repo.db.WithContext(ctx).Where("tag_name = ? AND is_deleted = ?", tagName, utils.DeletedFlagNo).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &tag, nil
+}
+
+// CountTags 统计ID列表中未删除的标签数量
+func (repo *TagRepositoryImpl) CountTags(ctx context.Context, tagIDs []int) (int64, error) {
+	var count int64
+
+	if err := repo.db.WithContext(ctx).Model(&model.Tag{}).
+		Where("tag_id IN ? AND is_deleted = ?", tagIDs, utils.DeletedFlagNo).
+		Count(&count).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return count, nil
+}
+
+// CreateTag 创建标签
+func (repo *TagRepositoryImpl) CreateTag(ctx context.Context, tag *model.Tag) error {
+	if err := repo.db.WithContext(ctx).Create(tag).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建标签失败: %w", err))
+	}
+	return nil
+}
+
+// UpdateTag 更新标签（仅更新未删除的标签）
+func (repo *TagRepositoryImpl) UpdateTag(ctx context.Context, tagID int, updateFields map[string]interface{}) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Tag{}).
+		Where("tag_id = ? AND is_deleted = ?", tagID, utils.DeletedFlagNo).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新标签失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "标签不存在或已被删除")
+	}
+
+	return nil
+}
+
+// ReplaceArticleTags 覆盖文章关联的标签：先删除原有关联，再写入新的关联
+func (repo *TagRepositoryImpl) ReplaceArticleTags(ctx context.Context, tx *gorm.DB, articleID int, tagIDs []int) error {
+	if err := tx.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Delete(&model.ArticleTagMapping{}).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("删除文章标签关联失败: %w", err))
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	mappings := make([]model.ArticleTagMapping, 0, len(tagIDs))
+	seen := make(map[int]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		if seen[tagID] {
+			continue
+		}
+		seen[tagID] = true
+		mappings = append(mappings, model.ArticleTagMapping{ArticleID: articleID, TagID: tagID})
+	}
+	if err := tx.WithContext(ctx).Create(&mappings).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建文章标签关联失败: %w", err))
+	}
+
+	return nil
+}
+
+// ListArticleTags 批量查询文章关联的未删除标签
+func (repo *TagRepositoryImpl) ListArticleTags(ctx context.Context, articleIDs []int) (map[int][]dto.Tag, error) {
+	result := make(map[int][]dto.Tag)
+	if len(articleIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []dto.ArticleTag
+	if err := repo.db.WithContext(ctx).Table("article_tag_mappings m").
+		Select("m.article_id, t.tag_id, t.tag_name").
+		Joins("JOIN tags t ON t.tag_id = m.tag_id").
+		Where("m.article_id IN ? AND t.is_deleted = ?", articleIDs, utils.DeletedFlagNo).
+		Order("m.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询文章标签失败: %v", err))
+	}
+
+	for _, row := range rows {
+		result[row.ArticleID] = append(result[row.ArticleID], dto.Tag{TagID: row.TagID, TagName: row.TagName})
+	}
+
+	return result, nil
+}
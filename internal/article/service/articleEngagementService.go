@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/utils"
+	"strconv"
+	"time"
+)
+
+// viewDedupWindow 浏览量去重时间窗口，同一用户/IP在同一窗口内多次浏览只计一次
+const viewDedupWindow = 30 * time.Minute
+
+// ArticleEngagementService 文章浏览、点赞、收藏服务接口
+type ArticleEngagementService interface {
+	// RecordView 记录文章浏览，userID为0时按IP去重
+	RecordView(ctx context.Context, articleID int, userID int, clientIP string) (bool, error)
+	// LikeArticle 点赞文章
+	LikeArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// UnlikeArticle 取消点赞
+	UnlikeArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// FavoriteArticle 收藏文章
+	FavoriteArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// UnfavoriteArticle 取消收藏
+	UnfavoriteArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// GetEngagement 查询文章的点赞、收藏数及用户的点赞、收藏状态
+	GetEngagement(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error)
+	// ListUserFavorites 分页查询用户收藏的文章
+	ListUserFavorites(ctx context.Context, page, pageSize int, userID int) ([]dto.FavoriteArticleResponse, int64, error)
+}
+
+// ArticleEngagementServiceImpl 实现接口的具体结构体
+type ArticleEngagementServiceImpl struct {
+	articleRepo    repository.ArticleRepository
+	engagementRepo repository.ArticleEngagementRepository
+	tagRepo        repository.TagRepository
+}
+
+// NewArticleEngagementService 创建服务实例
+func NewArticleEngagementService(articleRepo repository.ArticleRepository, engagementRepo repository.ArticleEngagementRepository, tagRepo repository.TagRepository) ArticleEngagementService {
+	return &ArticleEngagementServiceImpl{articleRepo: articleRepo, engagementRepo: engagementRepo, tagRepo: tagRepo}
+}
+
+// RecordView 记录文章浏览
+func (svc *ArticleEngagementServiceImpl) RecordView(ctx context.Context, articleID int, userID int, clientIP string) (bool, error) {
+	viewerKey := "ip:" + clientIP
+	if userID != 0 {
+		viewerKey = "user:" + strconv.Itoa(userID)
+	}
+	windowStart := time.Now().Truncate(viewDedupWindow)
+
+	return svc.engagementRepo.RecordView(ctx, articleID, viewerKey, windowStart)
+}
+
+// checkPublished 检查文章是否已发布，未发布的文章不能点赞或收藏
+func (svc *ArticleEngagementServiceImpl) checkPublished(ctx context.Context, articleID int) error {
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if article.IsDeleted == utils.DeletedFlagYes || article.Status != model.ArticleStatusPublished || article.ReleaseTime.After(time.Now()) {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+	}
+	return nil
+}
+
+// LikeArticle 点赞文章，重复点赞不报错
+func (svc *ArticleEngagementServiceImpl) LikeArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	if err := svc.checkPublished(ctx, articleID); err != nil {
+		return nil, err
+	}
+	if err := svc.engagementRepo.Like(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+	return svc.engagementRepo.GetEngagement(ctx, articleID, userID)
+}
+
+// UnlikeArticle 取消点赞，未点赞时不报错
+func (svc *ArticleEngagementServiceImpl) UnlikeArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	if err := svc.engagementRepo.Unlike(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+	return svc.engagementRepo.GetEngagement(ctx, articleID, userID)
+}
+
+// FavoriteArticle 收藏文章，重复收藏不报错
+func (svc *ArticleEngagementServiceImpl) FavoriteArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	if err := svc.checkPublished(ctx, articleID); err != nil {
+		return nil, err
+	}
+	if err := svc.engagementRepo.Favorite(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+	return svc.engagementRepo.GetEngagement(ctx, articleID, userID)
+}
+
+// UnfavoriteArticle 取消收藏，未收藏时不报错
+func (svc *ArticleEngagementServiceImpl) UnfavoriteArticle(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	if err := svc.engagementRepo.Unfavorite(ctx, articleID, userID); err != nil {
+		return nil, err
+	}
+	return svc.engagementRepo.GetEngagement(ctx, articleID, userID)
+}
+
+// GetEngagement 查询文章的点赞、收藏数及用户的点赞、收藏状态
+func (svc *ArticleEngagementServiceImpl) GetEngagement(ctx context.Context, articleID int, userID int) (*dto.ArticleEngagementResponse, error) {
+	return svc.engagementRepo.GetEngagement(ctx, articleID, userID)
+}
+
+// ListUserFavorites 分页查询用户收藏的文章并补充标签
+func (svc *ArticleEngagementServiceImpl) ListUserFavorites(ctx context.Context, page, pageSize int, userID int) ([]dto.FavoriteArticleResponse, int64, error) {
+	favorites, total, err := svc.engagementRepo.ListUserFavorites(ctx, page, pageSize, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articleIDs := make([]int, 0, len(favorites))
+	for _, favorite := range favorites {
+		articleIDs = append(articleIDs, favorite.ArticleID)
+	}
+	tags, err := svc.tagRepo.ListArticleTags(ctx, articleIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range favorites {
+		favorites[i].Tags = tags[favorites[i].ArticleID]
+		if favorites[i].Tags == nil {
+			favorites[i].Tags = make([]dto.Tag, 0)
+		}
+	}
+
+	return favorites, total, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/utils"
+	"time"
+)
+
+// ArticlePinService 服务接口，定义方法，接收 context.Context 和数据模型。
+type ArticlePinService interface {
+	// ListPins 查询指定列表的置顶
+	ListPins(ctx context.Context, req dto.ListPinsRequest) ([]dto.PinResponse, error)
+	// CreatePin 置顶文章
+	CreatePin(ctx context.Context, req dto.CreatePinRequest, userID int) (int, error)
+	// UpdatePin 更新置顶的排序值和到期时间
+	UpdatePin(ctx context.Context, pinID int, req dto.UpdatePinRequest, userID int) error
+	// DeletePin 取消置顶
+	DeletePin(ctx context.Context, pinID int) error
+	// ReorderPins 调整列表内置顶的顺序
+	ReorderPins(ctx context.Context, req dto.ReorderPinRequest, userID int) error
+}
+
+// ArticlePinServiceImpl 实现接口的具体结构体，持有数据访问层接口 Repository 的实例
+type ArticlePinServiceImpl struct {
+	pinRepo         repository.ArticlePinRepository
+	articleRepo     repository.ArticleRepository
+	fieldTypeRepo   repository.FieldTypeRepository
+	articleTypeRepo repository.ArticleTypeRepository
+}
+
+// NewArticlePinService 创建服务实例
+func NewArticlePinService(pinRepo repository.ArticlePinRepository, articleRepo repository.ArticleRepository, fieldTypeRepo repository.FieldTypeRepository, articleTypeRepo repository.ArticleTypeRepository) ArticlePinService {
+	return &ArticlePinServiceImpl{pinRepo: pinRepo, articleRepo: articleRepo, fieldTypeRepo: fieldTypeRepo, articleTypeRepo: articleTypeRepo}
+}
+
+// ListPins 查询指定列表的置顶，包含已过期及文章未发布的置顶，便于编辑维护
+func (svc *ArticlePinServiceImpl) ListPins(ctx context.Context, req dto.ListPinsRequest) ([]dto.PinResponse, error) {
+	scopeValue := pinScopeValue(req.Scope, req.ScopeValue)
+	pins, err := svc.pinRepo.ListPins(ctx, req.Scope, scopeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range pins {
+		pins[i].Expired = pins[i].ExpireTime != nil && !pins[i].ExpireTime.After(now)
+	}
+	return pins, nil
+}
+
+// CreatePin 置顶文章，文章需属于该列表对应的领域或文章类型
+func (svc *ArticlePinServiceImpl) CreatePin(ctx context.Context, req dto.CreatePinRequest, userID int) (int, error) {
+	scopeValue := pinScopeValue(req.Scope, req.ScopeValue)
+	if err := svc.checkScope(ctx, req.Scope, scopeValue); err != nil {
+		return 0, err
+	}
+
+	article, err := svc.articleRepo.GetArticleContent(ctx, req.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	if article.IsDeleted == utils.DeletedFlagYes {
+		return 0, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除")
+	}
+	if (req.Scope == model.PinScopeFieldType && article.FieldType != scopeValue) ||
+		(req.Scope == model.PinScopeArticleType && article.ArticleTypeCode != scopeValue) {
+		return 0, utils.NewBusinessError(utils.ErrCodeParamInvalid, "文章不属于该列表，无法置顶")
+	}
+
+	expireTime, err := parsePinExpireTime(req.ExpireTime)
+	if err != nil {
+		return 0, err
+	}
+
+	pin := &model.ArticlePin{
+		ArticleID:  req.ArticleID,
+		Scope:      req.Scope,
+		ScopeValue: scopeValue,
+		SortOrder:  req.SortOrder,
+		ExpireTime: expireTime,
+		CreateUser: userID,
+		UpdateUser: userID,
+	}
+	if err := svc.pinRepo.CreatePin(ctx, pin); err != nil {
+		return 0, err
+	}
+	return pin.ID, nil
+}
+
+// UpdatePin 更新置顶的排序值和到期时间
+func (svc *ArticlePinServiceImpl) UpdatePin(ctx context.Context, pinID int, req dto.UpdatePinRequest, userID int) error {
+	if _, err := svc.getPin(ctx, pinID); err != nil {
+		return err
+	}
+
+	updateFields := make(map[string]interface{})
+	if req.SortOrder != nil {
+		updateFields["sort_order"] = *req.SortOrder
+	}
+	if req.ExpireTime != nil {
+		expireTime, err := parsePinExpireTime(*req.ExpireTime)
+		if err != nil {
+			return err
+		}
+		updateFields["expire_time"] = expireTime
+	}
+
+	if len(updateFields) == 0 {
+		return nil
+	}
+	updateFields["update_user"] = userID
+
+	return svc.pinRepo.UpdatePin(ctx, pinID, updateFields)
+}
+
+// DeletePin 取消置顶
+func (svc *ArticlePinServiceImpl) DeletePin(ctx context.Context, pinID int) error {
+	if _, err := svc.getPin(ctx, pinID); err != nil {
+		return err
+	}
+	return svc.pinRepo.DeletePin(ctx, pinID)
+}
+
+// ReorderPins 调整列表内置顶的顺序，ID列表需包含该列表的全部置顶
+func (svc *ArticlePinServiceImpl) ReorderPins(ctx context.Context, req dto.ReorderPinRequest, userID int) error {
+	pins, err := svc.pinRepo.ListPins(ctx, req.Scope, pinScopeValue(req.Scope, req.ScopeValue))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(pins))
+	for _, pin := range pins {
+		ids = append(ids, pin.ID)
+	}
+	if err := checkReorderIDs(ids, req.IDList); err != nil {
+		return err
+	}
+
+	return svc.pinRepo.ReorderPins(ctx, req.IDList, userID)
+}
+
+// getPin 查询置顶，不存在时返回业务错误
+func (svc *ArticlePinServiceImpl) getPin(ctx context.Context, pinID int) (*model.ArticlePin, error) {
+	pin, err := svc.pinRepo.GetPinByID(ctx, pinID)
+	if err != nil {
+		return nil, err
+	}
+	if pin == nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "置顶不存在或已被取消")
+	}
+	return pin, nil
+}
+
+// checkScope 检查置顶范围值，领域和文章类型列表需指定未删除的编码
+func (svc *ArticlePinServiceImpl) checkScope(ctx context.Context, scope string, scopeValue string) error {
+	switch scope {
+	case model.PinScopeFieldType:
+		fieldType, err := svc.fieldTypeRepo.GetFieldTypeByCode(ctx, scopeValue)
+		if err != nil {
+			return err
+		}
+		if fieldType == nil || fieldType.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "领域类型不存在或已被删除")
+		}
+	case model.PinScopeArticleType:
+		articleType, err := svc.articleTypeRepo.GetArticleTypeByCode(ctx, scopeValue)
+		if err != nil {
+			return err
+		}
+		if articleType == nil || articleType.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "文章类型不存在或已被删除")
+		}
+	}
+	return nil
+}
+
+// pinScopeValue 首页列表没有范围值，忽略传入的值
+func pinScopeValue(scope string, scopeValue string) string {
+	if scope == model.PinScopeHome {
+		return ""
+	}
+	return scopeValue
+}
+
+// parsePinExpireTime 解析置顶到期时间，为空表示长期置顶，到期时间需晚于当前时间
+func parsePinExpireTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	expireTime, err := utils.StringToTime(value)
+	if err != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "置顶到期时间格式错误")
+	}
+	if !expireTime.After(time.Now()) {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "置顶到期时间必须晚于当前时间")
+	}
+	return &expireTime, nil
+}
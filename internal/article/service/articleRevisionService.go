@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/content"
+	"news-release/internal/utils"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// ArticleRevisionService 文章修订记录服务接口
+type ArticleRevisionService interface {
+	// ListRevisions 分页查询文章的修订记录
+	ListRevisions(ctx context.Context, page, pageSize int, articleID int) ([]dto.RevisionListResponse, int64, error)
+	// DiffRevisions 对比文章的两个修订版本
+	DiffRevisions(ctx context.Context, articleID int, fromID int, toID int) (*dto.RevisionDiffResponse, error)
+	// RestoreRevision 将文章回滚到指定的修订版本
+	RestoreRevision(ctx context.Context, articleID int, revisionID int, userID int) error
+}
+
+// ArticleRevisionServiceImpl 实现接口的具体结构体
+type ArticleRevisionServiceImpl struct {
+	articleRepo  repository.ArticleRepository
+	revisionRepo repository.ArticleRevisionRepository
+	processor    *content.Processor
+}
+
+// NewArticleRevisionService 创建服务实例
+func NewArticleRevisionService(articleRepo repository.ArticleRepository, revisionRepo repository.ArticleRevisionRepository, processor *content.Processor) ArticleRevisionService {
+	return &ArticleRevisionServiceImpl{articleRepo: articleRepo, revisionRepo: revisionRepo, processor: processor}
+}
+
+// revisionField 参与对比的修订字段
+type revisionField struct {
+	field string
+	label string
+	value func(r *model.ArticleRevision) string
+}
+
+// revisionFields 参与对比的字段列表，顺序即对比结果的输出顺序
+var revisionFields = []revisionField{
+	{"article_title", "文章标题", func(r *model.ArticleRevision) string { return r.ArticleTitle }},
+	{"article_type", "文章类型", func(r *model.ArticleRevision) string { return r.ArticleType }},
+	{"field_type", "领域类型", func(r *model.ArticleRevision) string { return r.FieldType }},
+	{"brief_content", "摘要", func(r *model.ArticleRevision) string { return r.BriefContent }},
+	{"article_content", "文章内容", func(r *model.ArticleRevision) string { return r.ArticleContent }},
+	{"is_selection", "是否精选", func(r *model.ArticleRevision) string { return strconv.Itoa(r.IsSelection) }},
+	{"cover_image_url", "封面图片", func(r *model.ArticleRevision) string { return r.CoverImageURL }},
+	{"article_source", "文章来源", func(r *model.ArticleRevision) string { return r.ArticleSource }},
+}
+
+// ListRevisions 分页查询文章的修订记录
+func (svc *ArticleRevisionServiceImpl) ListRevisions(ctx context.Context, page, pageSize int, articleID int) ([]dto.RevisionListResponse, int64, error) {
+	return svc.revisionRepo.ListRevisions(ctx, page, pageSize, articleID)
+}
+
+// DiffRevisions 对比文章的两个修订版本，只返回有差异的字段
+func (svc *ArticleRevisionServiceImpl) DiffRevisions(ctx context.Context, articleID int, fromID int, toID int) (*dto.RevisionDiffResponse, error) {
+	from, err := svc.revisionRepo.GetRevision(ctx, articleID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := svc.revisionRepo.GetRevision(ctx, articleID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.RevisionDiffResponse{
+		ArticleID:   articleID,
+		FromID:      from.ID,
+		FromNo:      from.RevisionNo,
+		ToID:        to.ID,
+		ToNo:        to.RevisionNo,
+		Differences: make([]dto.RevisionFieldDiff, 0),
+	}
+	for _, f := range revisionFields {
+		oldValue, newValue := f.value(from), f.value(to)
+		if oldValue != newValue {
+			res.Differences = append(res.Differences, dto.RevisionFieldDiff{
+				Field:    f.field,
+				Label:    f.label,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// RestoreRevision 将文章回滚到指定的修订版本
+// 更新文章内容与记录回滚修订在同一事务中完成
+func (svc *ArticleRevisionServiceImpl) RestoreRevision(ctx context.Context, articleID int, revisionID int, userID int) error {
+	// 检查文章是否存在
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if article.IsDeleted == utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新后重试")
+	}
+
+	revision, err := svc.revisionRepo.GetRevision(ctx, articleID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	// 检查标题是否与其他文章重复（仅当标题不同时）
+	if revision.ArticleTitle != article.ArticleTitle {
+		existing, err := svc.articleRepo.GetArticleByTitle(ctx, revision.ArticleTitle)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ArticleID != articleID {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名文章，无法回滚到该版本")
+		}
+	}
+
+	// 历史版本可能早于内容过滤，回滚时重新过滤并提取纯文本
+	processed := svc.processor.Process(revision.ArticleContent)
+
+	updateFields := map[string]interface{}{
+		"article_title":   revision.ArticleTitle,
+		"article_type":    revision.ArticleType,
+		"brief_content":   revision.BriefContent,
+		"article_content": processed.HTML,
+		"content_text":    processed.Text,
+		"is_selection":    revision.IsSelection,
+		"field_type":      revision.FieldType,
+		"cover_image_url": revision.CoverImageURL,
+		"article_source":  revision.ArticleSource,
+		"update_user":     userID,
+	}
+
+	// 使用 GORM 函数式事务
+	err = svc.revisionRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if err := svc.articleRepo.UpdateArticle(ctx, tx, articleID, updateFields); err != nil {
+			return err
+		}
+		return svc.revisionRepo.CreateRevision(ctx, tx, articleID, model.RevisionActionRestore, revision.ID)
+	})
+	if err != nil {
+		if _, ok := utils.GetBusinessError(err); ok {
+			return err
+		}
+		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+
+	return nil
+}
@@ -6,53 +6,268 @@ import (
 	"news-release/internal/article/dto"
 	"news-release/internal/article/model"
 	"news-release/internal/article/repository"
+	"news-release/internal/content"
 	db "news-release/internal/database"
 	filerepo "news-release/internal/file/repository"
 	"news-release/internal/utils"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+// metaDescriptionMaxLength SEO描述的最大字数
+const metaDescriptionMaxLength = 300
+
+// briefContentMaxLength 根据正文自动生成摘要的最大字数
+const briefContentMaxLength = 150
+
+// contentTextBatchSize 补全文章正文纯文本时每批处理的数量
+const contentTextBatchSize = 100
+
 // ArticleService 服务接口，定义方法，接收 context.Context 和数据模型。
 type ArticleService interface {
-	// ListArticle 分页查询文章列表
-	ListArticle(ctx context.Context, page, pageSize int, articleTitle string, articleType string, releaseTime string, fieldType string, isSelection int, queryScope string) ([]dto.ArticleListResponse, int64, error)
-	// GetArticleContent 获取文章内容
+	// ListArticle 分页查询已发布的文章列表
+	ListArticle(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error)
+	// ListAllArticles 分页查询全部状态的文章列表（管理端）
+	ListAllArticles(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error)
+	// GetArticleContent 获取已发布的文章内容
 	GetArticleContent(ctx context.Context, articleID int) (*dto.ArticleContentResponse, error)
+	// GetArticleContentBySlug 根据别名获取已发布的文章内容
+	GetArticleContentBySlug(ctx context.Context, slug string) (*dto.ArticleContentResponse, error)
+	// PreviewArticle 预览任意状态的文章内容（管理端）
+	PreviewArticle(ctx context.Context, articleID int) (*dto.ArticleContentResponse, error)
+	// ListRelatedArticles 查询与已发布文章相关的其他文章
+	ListRelatedArticles(ctx context.Context, articleID int, limit int) ([]dto.ArticleListResponse, error)
 	// CreateArticle 创建文章
-	CreateArticle(ctx context.Context, article *model.Article, imageIDList []int) error
+	CreateArticle(ctx context.Context, article *model.Article, imageIDList []int, tagIDList []int) error
 	// UpdateArticle 更新文章
 	UpdateArticle(ctx context.Context, articleID int, req dto.UpdateArticleRequest, userID int) error
 	// DeleteArticle 删除文章
 	DeleteArticle(ctx context.Context, articleID int, userID int) error
+	// RestoreArticle 恢复已删除的文章
+	RestoreArticle(ctx context.Context, articleID int, userID int) error
+	// SubmitArticle 提交文章审核
+	SubmitArticle(ctx context.Context, articleID int, userID int) error
+	// ApproveArticle 审核通过文章
+	ApproveArticle(ctx context.Context, articleID int, comment string, userID int) error
+	// RejectArticle 驳回文章
+	RejectArticle(ctx context.Context, articleID int, comment string, userID int) error
+	// ScheduleArticle 设置文章计划发布时间
+	ScheduleArticle(ctx context.Context, articleID int, releaseTime time.Time, userID int) error
+	// ArchiveArticle 归档文章
+	ArchiveArticle(ctx context.Context, articleID int, userID int) error
+	// PublishScheduledArticles 发布已到发布时间的定时文章，由后台定时任务调用
+	PublishScheduledArticles(ctx context.Context) error
+	// FillContentText 为历史文章补全正文纯文本，由后台定时任务调用
+	FillContentText(ctx context.Context) error
 }
 
 // ArticleServiceImpl 实现接口的具体结构体，持有数据访问层接口 Repository 的实例
 type ArticleServiceImpl struct {
-	articleRepo repository.ArticleRepository
-	fileRepo    filerepo.FileRepository
+	articleRepo  repository.ArticleRepository
+	fileRepo     filerepo.FileRepository
+	revisionRepo repository.ArticleRevisionRepository
+	tagRepo      repository.TagRepository
+	processor    *content.Processor
 }
 
 // NewArticleService 创建服务实例
-func NewArticleService(articleRepo repository.ArticleRepository, fileRepo filerepo.FileRepository) ArticleService {
-	return &ArticleServiceImpl{articleRepo: articleRepo, fileRepo: fileRepo}
+func NewArticleService(articleRepo repository.ArticleRepository, fileRepo filerepo.FileRepository, revisionRepo repository.ArticleRevisionRepository, tagRepo repository.TagRepository, processor *content.Processor) ArticleService {
+	return &ArticleServiceImpl{articleRepo: articleRepo, fileRepo: fileRepo, revisionRepo: revisionRepo, tagRepo: tagRepo, processor: processor}
+}
+
+// ListArticle 分页查询数据，公开接口仅返回已发布且未删除的文章
+func (svc *ArticleServiceImpl) ListArticle(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error) {
+	req.Status = model.ArticleStatusPublished
+	req.QueryScope = ""
+
+	// 默认排序时合并置顶：按领域筛选时使用领域列表的置顶，按文章类型筛选时使用类型列表的置顶，否则使用首页置顶
+	if req.SortBy != model.ArticleSortPopular {
+		switch {
+		case req.FieldType != "":
+			req.PinScope, req.PinValue = model.PinScopeFieldType, req.FieldType
+		case req.ArticleType != "":
+			req.PinScope, req.PinValue = model.PinScopeArticleType, req.ArticleType
+		default:
+			req.PinScope = model.PinScopeHome
+		}
+	}
+	return svc.listArticles(ctx, pg, req)
 }
 
-// ListArticle 分页查询数据
-func (svc *ArticleServiceImpl) ListArticle(ctx context.Context, page, pageSize int, articleTitle string, articleType string, releaseTime string, fieldType string, isSelection int, queryScope string) ([]dto.ArticleListResponse, int64, error) {
-	return svc.articleRepo.List(ctx, page, pageSize, articleTitle, articleType, releaseTime, fieldType, isSelection, queryScope)
+// ListAllArticles 分页查询全部状态的文章列表
+func (svc *ArticleServiceImpl) ListAllArticles(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error) {
+	return svc.listArticles(ctx, pg, req)
+}
+
+// listArticles 解析标签筛选条件，查询文章列表并补充各文章的标签
+func (svc *ArticleServiceImpl) listArticles(ctx context.Context, pg *utils.Pagination, req dto.ArticleListRequest) ([]dto.ArticleListResponse, error) {
+	// 热度会随时变化，无法作为稳定的游标位置
+	if pg.Cursor != nil && req.SortBy == model.ArticleSortPopular {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "按热度排序时不支持游标分页")
+	}
+	if req.Tags != "" {
+		tagIDList, err := parseTagIDs(req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		req.TagIDList = tagIDList
+	}
+
+	articles, err := svc.articleRepo.List(ctx, pg, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.fillArticleTags(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// parseTagIDs 解析以英文逗号分隔的标签ID
+func parseTagIDs(tags string) ([]int, error) {
+	var tagIDList []int
+	for _, part := range strings.Split(tags, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tagID, err := strconv.Atoi(part)
+		if err != nil || tagID < 1 {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "标签ID格式错误，多个标签ID请以英文逗号分隔")
+		}
+		tagIDList = append(tagIDList, tagID)
+	}
+	return tagIDList, nil
+}
+
+// fillArticleTags 批量查询并填充文章列表的标签
+func (svc *ArticleServiceImpl) fillArticleTags(ctx context.Context, articles []dto.ArticleListResponse) error {
+	articleIDs := make([]int, 0, len(articles))
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ArticleID)
+	}
+
+	tags, err := svc.tagRepo.ListArticleTags(ctx, articleIDs)
+	if err != nil {
+		return err
+	}
+	for i := range articles {
+		articles[i].Tags = tags[articles[i].ArticleID]
+		if articles[i].Tags == nil {
+			articles[i].Tags = make([]dto.Tag, 0)
+		}
+	}
+	return nil
+}
+
+// checkTags 检查标签是否都存在且未被删除
+func (svc *ArticleServiceImpl) checkTags(ctx context.Context, tagIDList []int) error {
+	if len(tagIDList) == 0 {
+		return nil
+	}
+
+	unique := make(map[int]bool, len(tagIDList))
+	for _, tagID := range tagIDList {
+		unique[tagID] = true
+	}
+	count, err := svc.tagRepo.CountTags(ctx, tagIDList)
+	if err != nil {
+		return err
+	}
+	if count != int64(len(unique)) {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "标签不存在或已被删除，请刷新后重试")
+	}
+	return nil
+}
+
+// ListRelatedArticles 查询相关文章，按共同标签、领域类型和发布时间综合排序
+func (svc *ArticleServiceImpl) ListRelatedArticles(ctx context.Context, articleID int, limit int) ([]dto.ArticleListResponse, error) {
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article.IsDeleted == utils.DeletedFlagYes || article.Status != model.ArticleStatusPublished || article.ReleaseTime.After(time.Now()) {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+	}
+
+	tags, err := svc.tagRepo.ListArticleTags(ctx, []int{articleID})
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]int, 0, len(tags[articleID]))
+	for _, tag := range tags[articleID] {
+		tagIDs = append(tagIDs, tag.TagID)
+	}
+
+	articles, err := svc.articleRepo.ListRelatedArticles(ctx, articleID, article.FieldType, tagIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.fillArticleTags(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
 }
 
-// GetArticleContent 获取文章内容
+// GetArticleContent 获取文章内容，仅返回已发布且已到发布时间的文章
 func (svc *ArticleServiceImpl) GetArticleContent(ctx context.Context, articleID int) (*dto.ArticleContentResponse, error) {
 	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
 	if err != nil {
 		return nil, err
 	}
+	if article.IsDeleted == utils.DeletedFlagYes || article.Status != model.ArticleStatusPublished || article.ReleaseTime.After(time.Now()) {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+	}
+
+	return svc.buildContentResponse(ctx, article), nil
+}
+
+// GetArticleContentBySlug 根据别名获取已发布的文章内容
+func (svc *ArticleServiceImpl) GetArticleContentBySlug(ctx context.Context, slug string) (*dto.ArticleContentResponse, error) {
+	article, err := svc.articleRepo.GetArticleBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if article == nil || article.IsDeleted == utils.DeletedFlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+	}
+
+	return svc.GetArticleContent(ctx, article.ArticleID)
+}
+
+// PreviewArticle 预览任意状态的文章内容
+func (svc *ArticleServiceImpl) PreviewArticle(ctx context.Context, articleID int) (*dto.ArticleContentResponse, error) {
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 审核状态和审核意见仅在后台预览时返回，前台文章详情不展示
+	res := svc.buildContentResponse(ctx, article)
+	res.Status = article.Status
+	res.ReviewComment = article.ReviewComment
+	return res, nil
+}
+
+// buildContentResponse 拼接文章内容和图片列表
+func (svc *ArticleServiceImpl) buildContentResponse(ctx context.Context, article *dto.ArticleContentDTO) *dto.ArticleContentResponse {
+	articleID := article.ArticleID
 
 	// 获取关联图片列表
 	images := svc.articleRepo.ListArticleImage(ctx, articleID)
 
+	// 获取关联标签列表，查询失败只记录日志，不影响文章内容的返回
+	tags, err := svc.tagRepo.ListArticleTags(ctx, []int{articleID})
+	if err != nil {
+		logrus.Errorf("获取文章关联标签失败: %v", err)
+	}
+
 	// 拼接文章内容和图片列表
 	res := dto.ArticleContentResponse{
 		ArticleID:       article.ArticleID,
@@ -67,6 +282,23 @@ func (svc *ArticleServiceImpl) GetArticleContent(ctx context.Context, articleID
 		ArticleSource:   article.ArticleSource,
 		IsSelection:     article.IsSelection,
 		CoverImageURL:   article.CoverImageURL,
+		ViewCount:       article.ViewCount,
+		LikeCount:       article.LikeCount,
+		FavoriteCount:   article.FavoriteCount,
+		Slug:            article.Slug,
+		MetaDescription: article.MetaDescription,
+		OgImageURL:      article.OgImageURL,
+	}
+	// SEO描述和分享图片未设置时分别取摘要和封面图片
+	if res.MetaDescription == "" {
+		res.MetaDescription = utils.TruncateRunes(article.BriefContent, metaDescriptionMaxLength)
+	}
+	if res.OgImageURL == "" {
+		res.OgImageURL = article.CoverImageURL
+	}
+	res.Tags = tags[articleID]
+	if res.Tags == nil {
+		res.Tags = make([]dto.Tag, 0)
 	}
 	res.Images = make([]dto.Image, 0, len(images)) // 预分配空间，提高性能
 	for _, img := range images {
@@ -75,11 +307,11 @@ func (svc *ArticleServiceImpl) GetArticleContent(ctx context.Context, articleID
 			URL:     img.URL,
 		})
 	}
-	return &res, err
+	return &res
 }
 
 // CreateArticle 创建文章
-func (svc *ArticleServiceImpl) CreateArticle(ctx context.Context, article *model.Article, imageIDList []int) error {
+func (svc *ArticleServiceImpl) CreateArticle(ctx context.Context, article *model.Article, imageIDList []int, tagIDList []int) error {
 	// 检查是否存在重复标题的文章
 	existingArticle, err := svc.articleRepo.GetArticleByTitle(ctx, article.ArticleTitle)
 	if err != nil {
@@ -89,6 +321,32 @@ func (svc *ArticleServiceImpl) CreateArticle(ctx context.Context, article *model
 		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名文章，请修改标题后重试")
 	}
 
+	// 检查标签是否有效
+	if err := svc.checkTags(ctx, tagIDList); err != nil {
+		return err
+	}
+
+	// 过滤正文中的不安全内容并提取纯文本，摘要为空时根据正文生成
+	processed := svc.processor.Process(article.ArticleContent)
+	if strings.TrimSpace(processed.HTML) == "" {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "文章内容不能为空")
+	}
+	article.ArticleContent = processed.HTML
+	article.ContentText = processed.Text
+	if strings.TrimSpace(article.BriefContent) == "" {
+		article.BriefContent = content.Summary(processed.Text, briefContentMaxLength)
+	}
+
+	// 检查或生成别名
+	slug, err := svc.resolveSlug(ctx, article.Slug, article.ArticleTitle, 0)
+	if err != nil {
+		return err
+	}
+	article.Slug = slug
+
+	// 新建文章统一为草稿状态，需提交审核后才能发布
+	article.Status = model.ArticleStatusDraft
+
 	// 开启事务
 	tx := db.GetDB().Begin()
 	if tx.Error != nil {
@@ -107,6 +365,20 @@ func (svc *ArticleServiceImpl) CreateArticle(ctx context.Context, article *model
 		return err
 	}
 
+	// 记录修订版本
+	if err := svc.revisionRepo.CreateRevision(ctx, tx, article.ArticleID, model.RevisionActionCreate, 0); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 关联标签
+	if len(tagIDList) > 0 {
+		if err := svc.tagRepo.ReplaceArticleTags(ctx, tx, article.ArticleID, tagIDList); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// 如果有图片，更新images表的biz_id和biz_type
 	if len(imageIDList) > 0 {
 		if err := svc.fileRepo.BatchUpdateImageBizID(ctx, tx, imageIDList, article.ArticleID, utils.TypeArticle); err != nil {
@@ -123,7 +395,7 @@ func (svc *ArticleServiceImpl) CreateArticle(ctx context.Context, article *model
 	return nil
 }
 
-// UpdateArticle 更新文章
+// UpdateArticle 更新文章，已审核通过或已发布的文章修改后重新进入待审核状态，重新审核通过时保留原发布时间，已归档的文章不能修改
 func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int, req dto.UpdateArticleRequest, userID int) error {
 	// 检查文章是否存在
 	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
@@ -133,6 +405,9 @@ func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int,
 	if article == nil {
 		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除")
 	}
+	if article.Status == model.ArticleStatusArchived {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "已归档的文章不能修改")
+	}
 
 	// 检查标题是否重复（仅当标题被修改时）
 	if req.ArticleTitle != nil && *req.ArticleTitle != article.ArticleTitle {
@@ -151,19 +426,70 @@ func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int,
 		return err
 	}
 
+	// 过滤正文中的不安全内容并提取纯文本
+	contentText := ""
+	if req.ArticleContent != nil {
+		processed := svc.processor.Process(*req.ArticleContent)
+		if strings.TrimSpace(processed.HTML) == "" {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "文章内容不能为空")
+		}
+		updateFields["article_content"] = processed.HTML
+		updateFields["content_text"] = processed.Text
+		contentText = processed.Text
+	}
+
+	// 摘要被清空，或正文修改后摘要仍为空时，根据正文生成摘要
+	briefContent := article.BriefContent
+	if req.BriefContent != nil {
+		briefContent = *req.BriefContent
+	}
+	if strings.TrimSpace(briefContent) == "" && (req.BriefContent != nil || req.ArticleContent != nil) {
+		if req.ArticleContent == nil {
+			contentText = svc.processor.Process(article.ArticleContent).Text
+		}
+		updateFields["brief_content"] = content.Summary(contentText, briefContentMaxLength)
+	}
+
+	// 处理别名，传空字符串表示清除别名；标题修改时不自动变更别名，保证已有链接可用
+	if req.Slug != nil {
+		if *req.Slug == "" {
+			updateFields["slug"] = gorm.Expr("NULL")
+		} else if *req.Slug != article.Slug {
+			if _, err := svc.resolveSlug(ctx, *req.Slug, "", articleID); err != nil {
+				return err
+			}
+			updateFields["slug"] = *req.Slug
+		}
+	}
+
 	// 处理图片ID列表
 	var imageIDList []int
 	if req.ImageIDList != nil {
 		imageIDList = *req.ImageIDList
 	}
 
-	if len(updateFields) == 0 && len(imageIDList) == 0 {
+	// 处理标签ID列表，传空数组表示清空标签
+	if req.TagIDList != nil {
+		if err := svc.checkTags(ctx, *req.TagIDList); err != nil {
+			return err
+		}
+	}
+
+	if len(updateFields) == 0 && len(imageIDList) == 0 && req.TagIDList == nil {
 		return nil // 无更新内容
 	}
 
 	// 设置更新人
 	updateFields["update_user"] = userID
 
+	// 已审核通过的文章修改后需要重新审核，审核通过前不再对外展示
+	if article.Status == model.ArticleStatusScheduled || article.Status == model.ArticleStatusPublished {
+		updateFields["status"] = model.ArticleStatusPendingReview
+		updateFields["review_comment"] = ""
+		updateFields["review_user"] = 0
+		updateFields["review_time"] = nil
+	}
+
 	// 开启事务
 	tx := db.GetDB().Begin()
 	if tx.Error != nil {
@@ -182,6 +508,12 @@ func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int,
 		return err
 	}
 
+	// 记录修订版本
+	if err := svc.revisionRepo.CreateRevision(ctx, tx, articleID, model.RevisionActionUpdate, 0); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// 更新图片关联（如果有）
 	if len(imageIDList) > 0 {
 		if err := svc.fileRepo.BatchUpdateImageBizID(ctx, tx, imageIDList, articleID, utils.TypeArticle); err != nil {
@@ -190,6 +522,14 @@ func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int,
 		}
 	}
 
+	// 更新标签关联（如果有）
+	if req.TagIDList != nil {
+		if err := svc.tagRepo.ReplaceArticleTags(ctx, tx, articleID, *req.TagIDList); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
@@ -198,6 +538,30 @@ func (svc *ArticleServiceImpl) UpdateArticle(ctx context.Context, articleID int,
 	return nil
 }
 
+// resolveSlug 校验指定的别名是否可用；未指定别名时根据标题生成
+// 标题无法生成别名（如纯中文标题）时返回空字符串，文章链接使用数字ID
+func (svc *ArticleServiceImpl) resolveSlug(ctx context.Context, slug string, title string, articleID int) (string, error) {
+	taken := func(candidate string) (bool, error) {
+		existing, err := svc.articleRepo.GetArticleBySlug(ctx, candidate)
+		if err != nil {
+			return false, err
+		}
+		return existing != nil && existing.ArticleID != articleID, nil
+	}
+
+	if slug == "" {
+		return utils.UniqueSlug(title, taken)
+	}
+	occupied, err := taken(slug)
+	if err != nil {
+		return "", err
+	}
+	if occupied {
+		return "", utils.NewBusinessError(utils.ErrCodeResourceExists, "别名已被使用，请修改后重试")
+	}
+	return slug, nil
+}
+
 // 辅助函数：构建更新字段映射
 func makeArticleUpdateFields(req dto.UpdateArticleRequest) (map[string]interface{}, error) {
 	updateFields := make(map[string]interface{})
@@ -212,9 +576,6 @@ func makeArticleUpdateFields(req dto.UpdateArticleRequest) (map[string]interface
 	if req.BriefContent != nil {
 		updateFields["brief_content"] = *req.BriefContent
 	}
-	if req.ArticleContent != nil {
-		updateFields["article_content"] = *req.ArticleContent
-	}
 	if req.IsSelection != nil {
 		updateFields["is_selection"] = *req.IsSelection
 	}
@@ -227,6 +588,12 @@ func makeArticleUpdateFields(req dto.UpdateArticleRequest) (map[string]interface
 	if req.ArticleSource != nil {
 		updateFields["article_source"] = *req.ArticleSource
 	}
+	if req.MetaDescription != nil {
+		updateFields["meta_description"] = *req.MetaDescription
+	}
+	if req.OgImageURL != nil {
+		updateFields["og_image_url"] = *req.OgImageURL
+	}
 
 	return updateFields, nil
 }
@@ -264,6 +631,12 @@ func (svc *ArticleServiceImpl) DeleteArticle(ctx context.Context, articleID int,
 		return err
 	}
 
+	// 记录修订版本
+	if err := svc.revisionRepo.CreateRevision(ctx, tx, articleID, model.RevisionActionDelete, 0); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
@@ -271,3 +644,167 @@ func (svc *ArticleServiceImpl) DeleteArticle(ctx context.Context, articleID int,
 	}
 	return nil
 }
+
+// RestoreArticle 恢复已删除的文章，恢复前重新检查标题是否与未删除的文章重复
+func (svc *ArticleServiceImpl) RestoreArticle(ctx context.Context, articleID int, userID int) error {
+	// 检查文章是否存在且已删除
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if article.IsDeleted != utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "文章未被删除，无需恢复")
+	}
+
+	// 删除期间可能已创建同名文章
+	existing, err := svc.articleRepo.GetArticleByTitle(ctx, article.ArticleTitle)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名文章，请修改同名文章的标题后重试")
+	}
+
+	// 开启事务
+	tx := db.GetDB().Begin()
+	if tx.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("开启事务失败: %w", tx.Error))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			logrus.Panic("事务回滚，发生异常: ", r)
+		}
+	}()
+
+	if err := svc.articleRepo.RestoreArticle(ctx, tx, articleID, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 记录修订版本
+	if err := svc.revisionRepo.CreateRevision(ctx, tx, articleID, model.RevisionActionRecover, 0); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 提交事务
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return utils.NewSystemError(fmt.Errorf("提交事务失败: %w", err))
+	}
+	return nil
+}
+
+// SubmitArticle 提交文章审核，仅草稿状态可提交
+func (svc *ArticleServiceImpl) SubmitArticle(ctx context.Context, articleID int, userID int) error {
+	updateFields := map[string]interface{}{
+		"status":      model.ArticleStatusPendingReview,
+		"update_user": userID,
+	}
+	return svc.articleRepo.TransitArticleStatus(ctx, articleID, []string{model.ArticleStatusDraft}, updateFields)
+}
+
+// ApproveArticle 审核通过文章
+// 计划发布时间晚于当前时间的文章进入定时发布状态，否则立即发布；已发布过的文章修改后重新审核通过时保留原发布时间
+func (svc *ArticleServiceImpl) ApproveArticle(ctx context.Context, articleID int, comment string, userID int) error {
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if article.Status != model.ArticleStatusPendingReview {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "仅待审核的文章可以审核通过")
+	}
+
+	now := time.Now()
+	updateFields := map[string]interface{}{
+		"review_comment": comment,
+		"review_user":    userID,
+		"review_time":    now,
+		"update_user":    userID,
+	}
+	if article.ReleaseTime.After(now) {
+		updateFields["status"] = model.ArticleStatusScheduled
+	} else {
+		updateFields["status"] = model.ArticleStatusPublished
+		// 首次发布时以审核通过的时间为发布时间
+		if article.PublishedTime == nil {
+			updateFields["release_time"] = now
+			updateFields["published_time"] = now
+		}
+	}
+
+	return svc.articleRepo.TransitArticleStatus(ctx, articleID, []string{model.ArticleStatusPendingReview}, updateFields)
+}
+
+// RejectArticle 驳回文章，文章退回草稿状态并记录驳回意见
+func (svc *ArticleServiceImpl) RejectArticle(ctx context.Context, articleID int, comment string, userID int) error {
+	updateFields := map[string]interface{}{
+		"status":         model.ArticleStatusDraft,
+		"review_comment": comment,
+		"review_user":    userID,
+		"review_time":    time.Now(),
+		"update_user":    userID,
+	}
+	return svc.articleRepo.TransitArticleStatus(ctx, articleID, []string{model.ArticleStatusPendingReview}, updateFields)
+}
+
+// ScheduleArticle 设置文章计划发布时间，已发布或已归档的文章不能再设置
+func (svc *ArticleServiceImpl) ScheduleArticle(ctx context.Context, articleID int, releaseTime time.Time, userID int) error {
+	if !releaseTime.After(time.Now()) {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "计划发布时间必须晚于当前时间")
+	}
+
+	updateFields := map[string]interface{}{
+		"release_time": releaseTime,
+		"update_user":  userID,
+	}
+	fromStatus := []string{model.ArticleStatusDraft, model.ArticleStatusPendingReview, model.ArticleStatusScheduled}
+	return svc.articleRepo.TransitArticleStatus(ctx, articleID, fromStatus, updateFields)
+}
+
+// ArchiveArticle 归档文章，仅已发布的文章可归档
+func (svc *ArticleServiceImpl) ArchiveArticle(ctx context.Context, articleID int, userID int) error {
+	updateFields := map[string]interface{}{
+		"status":      model.ArticleStatusArchived,
+		"update_user": userID,
+	}
+	return svc.articleRepo.TransitArticleStatus(ctx, articleID, []string{model.ArticleStatusPublished}, updateFields)
+}
+
+// PublishScheduledArticles 发布已到发布时间的定时文章
+func (svc *ArticleServiceImpl) PublishScheduledArticles(ctx context.Context) error {
+	count, err := svc.articleRepo.PublishDueArticles(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		logrus.Infof("定时发布文章 %d 篇", count)
+	}
+	return nil
+}
+
+// FillContentText 分批为尚未提取纯文本的文章补全正文纯文本，直到全部处理完成
+func (svc *ArticleServiceImpl) FillContentText(ctx context.Context) error {
+	total := 0
+	for {
+		articles, err := svc.articleRepo.ListArticlesWithoutText(ctx, contentTextBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, article := range articles {
+			if err := svc.articleRepo.UpdateArticleContentText(ctx, article.ArticleID, svc.processor.Process(article.ArticleContent).Text); err != nil {
+				return err
+			}
+		}
+		total += len(articles)
+		if len(articles) < contentTextBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logrus.Infof("已补全 %d 篇文章的正文纯文本", total)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"news-release/internal/article/dto"
+	"news-release/internal/utils"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// importMaxRows 单次导入的最大文章数
+const importMaxRows = 1000
+
+// transferListSeparator CSV 中多值字段（标签、图片）的分隔符
+const transferListSeparator = "|"
+
+// utf8BOM 导出CSV时写入的字节顺序标记，便于 Excel 正确识别中文
+const utf8BOM = "\ufeff"
+
+// transferColumns CSV 的列名，顺序即导出时的列顺序
+var transferColumns = []string{
+	"article_title", "article_type", "brief_content", "article_content", "is_selection", "field_type",
+	"cover_image_url", "article_source", "release_time", "status", "slug", "meta_description", "og_image_url",
+	"tags", "images",
+}
+
+// transferRequiredColumns 导入CSV必须包含的列
+var transferRequiredColumns = []string{"article_title", "article_type", "article_content"}
+
+// importRow 解析得到的一行导入数据
+type importRow struct {
+	Line   int                       // 所在行号
+	Record dto.ArticleTransferRecord // 文章记录
+	Err    error                     // 解析失败的原因
+}
+
+// transferFormat 根据文件扩展名识别导入导出格式
+func transferFormat(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".jsonl", ".ndjson":
+		return dto.TransferFormatJSONL
+	case ".csv":
+		return dto.TransferFormatCSV
+	case ".zip":
+		return dto.TransferFormatZIP
+	}
+	return ""
+}
+
+// readTransferRows 按格式解析文章清单
+func readTransferRows(r io.Reader, format string) ([]importRow, error) {
+	if format == dto.TransferFormatCSV {
+		return readCSVRows(r)
+	}
+	return readJSONLRows(r)
+}
+
+// readJSONLRows 解析 JSON Lines，每个非空行为一篇文章
+func readJSONLRows(r io.Reader) ([]importRow, error) {
+	rows := make([]importRow, 0)
+	reader := bufio.NewReader(r)
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "读取导入文件失败")
+		}
+		if line == 1 {
+			data = bytes.TrimPrefix(data, []byte(utf8BOM))
+		}
+
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 {
+			if len(rows) >= importMaxRows {
+				return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("单次最多导入%d篇文章", importMaxRows))
+			}
+			row := importRow{Line: line}
+			if jsonErr := json.Unmarshal(trimmed, &row.Record); jsonErr != nil {
+				row.Err = fmt.Errorf("JSON格式错误: %v", jsonErr)
+			}
+			rows = append(rows, row)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+	}
+}
+
+// readCSVRows 解析CSV，第一行为列名，列顺序不限，未知列忽略
+func readCSVRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "CSV文件缺少列名")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, utf8BOM)
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range transferRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "CSV文件缺少必需的列: "+name)
+		}
+	}
+
+	rows := make([]importRow, 0)
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "读取导入文件失败")
+		}
+		if err == nil && isBlankCSVRow(fields) {
+			continue
+		}
+		if len(rows) >= importMaxRows {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("单次最多导入%d篇文章", importMaxRows))
+		}
+
+		var row importRow
+		if err != nil {
+			row.Line, row.Err = parseErr.StartLine, fmt.Errorf("CSV格式错误: %v", parseErr.Err)
+		} else {
+			row.Line, _ = reader.FieldPos(0)
+			row.Record, row.Err = csvToRecord(columns, fields)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// isBlankCSVRow 判断CSV行是否所有列均为空
+func isBlankCSVRow(fields []string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// csvToRecord 将CSV行转换为文章记录
+func csvToRecord(columns map[string]int, fields []string) (dto.ArticleTransferRecord, error) {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(fields) {
+			return fields[i]
+		}
+		return ""
+	}
+
+	record := dto.ArticleTransferRecord{
+		ArticleTitle:    strings.TrimSpace(get("article_title")),
+		ArticleType:     strings.TrimSpace(get("article_type")),
+		BriefContent:    get("brief_content"),
+		ArticleContent:  get("article_content"),
+		FieldType:       strings.TrimSpace(get("field_type")),
+		CoverImageURL:   strings.TrimSpace(get("cover_image_url")),
+		ArticleSource:   get("article_source"),
+		ReleaseTime:     strings.TrimSpace(get("release_time")),
+		Status:          strings.TrimSpace(get("status")),
+		Slug:            strings.TrimSpace(get("slug")),
+		MetaDescription: get("meta_description"),
+		OgImageURL:      strings.TrimSpace(get("og_image_url")),
+		Tags:            splitTransferList(get("tags")),
+		Images:          splitTransferList(get("images")),
+	}
+	if value := strings.TrimSpace(get("is_selection")); value != "" {
+		isSelection, err := strconv.Atoi(value)
+		if err != nil {
+			return record, fmt.Errorf("is_selection必须为数字")
+		}
+		record.IsSelection = isSelection
+	}
+	return record, nil
+}
+
+// recordToCSV 将文章记录转换为CSV行，列顺序与 transferColumns 一致
+func recordToCSV(record dto.ArticleTransferRecord) []string {
+	isSelection := ""
+	if record.IsSelection != 0 {
+		isSelection = strconv.Itoa(record.IsSelection)
+	}
+	return []string{
+		record.ArticleTitle, record.ArticleType, record.BriefContent, record.ArticleContent, isSelection, record.FieldType,
+		record.CoverImageURL, record.ArticleSource, record.ReleaseTime, record.Status, record.Slug, record.MetaDescription, record.OgImageURL,
+		strings.Join(record.Tags, transferListSeparator), strings.Join(record.Images, transferListSeparator),
+	}
+}
+
+// splitTransferList 拆分CSV中以 | 分隔的多值字段，忽略空值
+func splitTransferList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, transferListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// zipBundle 导入用的ZIP压缩包，根目录下包含一个文章清单，其余文件为图片
+type zipBundle struct {
+	reader   *zip.ReadCloser
+	files    map[string]*zip.File
+	manifest *zip.File
+}
+
+// openZipBundle 打开压缩包并定位根目录下的 .jsonl 或 .csv 文章清单
+func openZipBundle(filePath string) (*zipBundle, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "无法读取ZIP压缩包，请检查文件是否完整")
+	}
+
+	bundle := &zipBundle{reader: reader, files: make(map[string]*zip.File)}
+	manifests := 0
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		name := bundlePath(file.Name)
+		bundle.files[name] = file
+		if !strings.Contains(name, "/") && transferFormat(name) != "" && transferFormat(name) != dto.TransferFormatZIP {
+			bundle.manifest = file
+			manifests++
+		}
+	}
+	if manifests != 1 {
+		_ = reader.Close()
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "ZIP压缩包根目录下需包含且仅包含一个 .jsonl 或 .csv 文章清单")
+	}
+	return bundle, nil
+}
+
+// rows 解析压缩包中的文章清单
+func (b *zipBundle) rows() ([]importRow, error) {
+	file, err := b.manifest.Open()
+	if err != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "读取ZIP压缩包中的文章清单失败")
+	}
+	defer file.Close()
+	return readTransferRows(file, transferFormat(b.manifest.Name))
+}
+
+// file 查找压缩包内的文件，不存在时返回nil
+func (b *zipBundle) file(name string) *zip.File {
+	return b.files[bundlePath(name)]
+}
+
+// Close 关闭压缩包
+func (b *zipBundle) Close() error {
+	return b.reader.Close()
+}
+
+// bundlePath 规范化压缩包内的路径，去除 ./、../ 及开头的 /，并解码URL转义字符
+func bundlePath(name string) string {
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	name = strings.ReplaceAll(name, "\\", "/")
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,615 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/content"
+	filemodel "news-release/internal/file/model"
+	filerepo "news-release/internal/file/repository"
+	filesvc "news-release/internal/file/service"
+	"news-release/internal/utils"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+// exportBatchSize 导出时每批查询的文章数
+const exportBatchSize = 200
+
+// importMaxImageSize 导入压缩包中单张图片的最大字节数
+const importMaxImageSize = 20 << 20
+
+// transferTimeFormat 导入导出文件中的时间格式
+const transferTimeFormat = "2006-01-02 15:04:05"
+
+// importImageExts 导入压缩包中允许的图片扩展名，与文件上传保持一致
+var importImageExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".bmp": true, ".webp": true}
+
+// imageSrcPattern 匹配正文中 img 标签的 src 属性值
+var imageSrcPattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\bsrc\s*=\s*["'])([^"']+)(["'])`)
+
+// ArticleTransferService 文章批量导入导出服务接口
+type ArticleTransferService interface {
+	// ImportArticles 从 JSON Lines、CSV 或包含图片的 ZIP 文件批量导入文章
+	ImportArticles(ctx context.Context, file ImportFile, dryRun bool, onDuplicate string, userID int) (*dto.ImportResult, error)
+	// ExportArticles 按指定格式导出文章并写入w
+	ExportArticles(ctx context.Context, w io.Writer, req dto.ExportArticleRequest) error
+}
+
+// ImportFile 待导入的文件
+type ImportFile struct {
+	FileName string // 原始文件名，用于识别文件格式
+	Path     string // 临时文件路径
+}
+
+// importState 一次导入过程中跨行共享的状态
+type importState struct {
+	titles map[string]int // 已出现的标题及所在行号
+	slugs  map[string]int // 已出现的别名及所在行号
+	tagIDs map[string]int // 标签名称与ID的缓存，0表示标签不存在
+}
+
+// ArticleTransferServiceImpl 实现接口的具体结构体
+type ArticleTransferServiceImpl struct {
+	articleSvc  ArticleService
+	articleRepo repository.ArticleRepository
+	tagRepo     repository.TagRepository
+	fileRepo    filerepo.FileRepository
+	minioRepo   filerepo.MinIORepository
+	fileSvc     filesvc.FileService
+	processor   *content.Processor
+}
+
+// NewArticleTransferService 创建服务实例
+func NewArticleTransferService(
+	articleSvc ArticleService,
+	articleRepo repository.ArticleRepository,
+	tagRepo repository.TagRepository,
+	fileRepo filerepo.FileRepository,
+	minioRepo filerepo.MinIORepository,
+	fileSvc filesvc.FileService,
+	processor *content.Processor,
+) ArticleTransferService {
+	return &ArticleTransferServiceImpl{
+		articleSvc:  articleSvc,
+		articleRepo: articleRepo,
+		tagRepo:     tagRepo,
+		fileRepo:    fileRepo,
+		minioRepo:   minioRepo,
+		fileSvc:     fileSvc,
+		processor:   processor,
+	}
+}
+
+// ImportArticles 逐行校验并创建文章，单行失败不影响其他行
+// 试运行时只做校验；标题与已有文章或文件中前面的行重复时，按onDuplicate跳过或记为错误
+func (svc *ArticleTransferServiceImpl) ImportArticles(ctx context.Context, file ImportFile, dryRun bool, onDuplicate string, userID int) (*dto.ImportResult, error) {
+	var rows []importRow
+	var bundle *zipBundle
+	var err error
+
+	switch format := transferFormat(file.FileName); format {
+	case dto.TransferFormatJSONL, dto.TransferFormatCSV:
+		f, openErr := os.Open(file.Path)
+		if openErr != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("打开导入文件失败: %w", openErr))
+		}
+		defer f.Close()
+		rows, err = readTransferRows(f, format)
+	case dto.TransferFormatZIP:
+		bundle, err = openZipBundle(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer bundle.Close()
+		rows, err = bundle.rows()
+	default:
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "仅支持导入 .jsonl、.csv 或 .zip 格式的文件")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "导入文件中没有文章数据")
+	}
+
+	state := &importState{titles: make(map[string]int), slugs: make(map[string]int), tagIDs: make(map[string]int)}
+	result := &dto.ImportResult{DryRun: dryRun, Total: len(rows), Rows: make([]dto.ImportRowResult, 0, len(rows))}
+	for _, row := range rows {
+		rowResult, err := svc.importRow(ctx, row, bundle, state, dryRun, onDuplicate, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch rowResult.Status {
+		case dto.ImportRowValid:
+			result.Valid++
+		case dto.ImportRowCreated:
+			result.Created++
+		case dto.ImportRowSkipped:
+			result.Skipped++
+		case dto.ImportRowFailed:
+			result.Failed++
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	return result, nil
+}
+
+// importRow 校验并导入一行数据，仅在查询失败等系统错误时返回error
+func (svc *ArticleTransferServiceImpl) importRow(ctx context.Context, row importRow, bundle *zipBundle, state *importState, dryRun bool, onDuplicate string, userID int) (dto.ImportRowResult, error) {
+	record := row.Record
+	res := dto.ImportRowResult{Row: row.Line, ArticleTitle: record.ArticleTitle}
+	if row.Err != nil {
+		res.Status, res.Errors = dto.ImportRowFailed, []string{row.Err.Error()}
+		return res, nil
+	}
+
+	// 标题重复检查，与单篇创建一致使用 GetArticleByTitle，同时检查文件中前面的行
+	duplicate := ""
+	if line, ok := state.titles[record.ArticleTitle]; ok {
+		duplicate = fmt.Sprintf("与第%d行的标题重复", line)
+	} else if record.ArticleTitle != "" {
+		state.titles[record.ArticleTitle] = row.Line
+		existing, err := svc.articleRepo.GetArticleByTitle(ctx, record.ArticleTitle)
+		if err != nil {
+			return res, err
+		}
+		if existing != nil {
+			duplicate = fmt.Sprintf("已存在同名文章(ID: %d)", existing.ArticleID)
+		}
+	}
+	if duplicate != "" && onDuplicate == dto.DuplicateSkip {
+		res.Status, res.Errors = dto.ImportRowSkipped, []string{duplicate}
+		return res, nil
+	}
+
+	errs := validateTransferRecord(record)
+	if duplicate != "" {
+		errs = append(errs, duplicate)
+	}
+
+	// 别名检查
+	if record.Slug != "" && utils.IsValidSlug(record.Slug) {
+		if line, ok := state.slugs[record.Slug]; ok {
+			errs = append(errs, fmt.Sprintf("别名与第%d行重复", line))
+		} else {
+			state.slugs[record.Slug] = row.Line
+			existing, err := svc.articleRepo.GetArticleBySlug(ctx, record.Slug)
+			if err != nil {
+				return res, err
+			}
+			if existing != nil {
+				errs = append(errs, "别名已被使用")
+			}
+		}
+	}
+
+	// 按名称查找标签
+	tagIDs, tagErrs, err := svc.resolveTags(ctx, record.Tags, state)
+	if err != nil {
+		return res, err
+	}
+	errs = append(errs, tagErrs...)
+
+	// 检查压缩包中的图片
+	var imagePaths []string
+	if bundle != nil {
+		var imageErrs []string
+		imagePaths, imageErrs = bundleImagePaths(bundle, record)
+		errs = append(errs, imageErrs...)
+	}
+
+	if len(errs) > 0 {
+		res.Status, res.Errors = dto.ImportRowFailed, errs
+		return res, nil
+	}
+	if dryRun {
+		res.Status = dto.ImportRowValid
+		return res, nil
+	}
+
+	articleID, err := svc.createArticle(ctx, record, tagIDs, bundle, imagePaths, userID)
+	if err != nil {
+		res.Status, res.Errors = dto.ImportRowFailed, []string{importErrorMessage(err)}
+		return res, nil
+	}
+	res.Status, res.ArticleID = dto.ImportRowCreated, articleID
+	return res, nil
+}
+
+// validateTransferRecord 按字段规则校验文章记录，返回错误提示列表
+func validateTransferRecord(record dto.ArticleTransferRecord) []string {
+	errs := make([]string, 0)
+	if err := binding.Validator.ValidateStruct(&record); err != nil {
+		if validationErrs, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrs {
+				errs = append(errs, utils.GetValidationErrorMsg(e))
+			}
+		} else {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// resolveTags 按名称查找标签ID，返回不存在的标签提示
+func (svc *ArticleTransferServiceImpl) resolveTags(ctx context.Context, names []string, state *importState) ([]int, []string, error) {
+	tagIDs := make([]int, 0, len(names))
+	errs := make([]string, 0)
+	for _, name := range names {
+		tagID, ok := state.tagIDs[name]
+		if !ok {
+			tag, err := svc.tagRepo.GetTagByName(ctx, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if tag != nil {
+				tagID = tag.TagID
+			}
+			state.tagIDs[name] = tagID
+		}
+
+		if tagID == 0 {
+			errs = append(errs, fmt.Sprintf("标签[%s]不存在", name))
+			continue
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	return tagIDs, errs, nil
+}
+
+// bundleImagePaths 收集记录引用的压缩包内图片
+// images 与封面图片中的相对路径必须存在于压缩包中；正文中的图片地址仅在压缩包中存在同名文件时才视为引用
+func bundleImagePaths(bundle *zipBundle, record dto.ArticleTransferRecord) ([]string, []string) {
+	paths := make([]string, 0)
+	errs := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(name string, required bool) {
+		file := bundle.file(name)
+		if file == nil {
+			if required {
+				errs = append(errs, "压缩包中不存在图片: "+name)
+			}
+			return
+		}
+		key := bundlePath(name)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		if !importImageExts[strings.ToLower(path.Ext(key))] {
+			errs = append(errs, "不支持的图片格式: "+name)
+		} else if file.UncompressedSize64 > importMaxImageSize {
+			errs = append(errs, fmt.Sprintf("图片超过%dMB: %s", importMaxImageSize>>20, name))
+		} else {
+			paths = append(paths, key)
+		}
+	}
+
+	for _, name := range record.Images {
+		add(name, true)
+	}
+	if record.CoverImageURL != "" && !isAbsoluteURL(record.CoverImageURL) {
+		add(record.CoverImageURL, true)
+	}
+	for _, match := range imageSrcPattern.FindAllStringSubmatch(record.ArticleContent, -1) {
+		if !isAbsoluteURL(match[2]) {
+			add(match[2], false)
+		}
+	}
+	return paths, errs
+}
+
+// isAbsoluteURL 判断地址是否为 http(s) 或协议相对的完整地址
+func isAbsoluteURL(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "//")
+}
+
+// createArticle 上传压缩包中的图片并创建文章，创建失败时删除已上传的图片
+func (svc *ArticleTransferServiceImpl) createArticle(ctx context.Context, record dto.ArticleTransferRecord, tagIDs []int, bundle *zipBundle, imagePaths []string, userID int) (int, error) {
+	imageIDs := make([]int, 0, len(imagePaths))
+	imageURLs := make(map[string]string, len(imagePaths))
+	cleanup := func() {
+		for _, imageID := range imageIDs {
+			if err := svc.fileSvc.DeleteImage(ctx, imageID, userID); err != nil {
+				logrus.Warnf("导入文章失败，清理已上传图片%d失败: %v", imageID, err)
+			}
+		}
+	}
+
+	for _, name := range imagePaths {
+		uploaded, err := svc.uploadBundleImage(ctx, bundle.file(name), userID)
+		if err != nil {
+			cleanup()
+			return 0, err
+		}
+		imageIDs = append(imageIDs, uploaded.ID)
+		imageURLs[name] = uploaded.URL
+	}
+
+	// 将压缩包内的图片路径替换为上传后的地址
+	articleContent := imageSrcPattern.ReplaceAllStringFunc(record.ArticleContent, func(tag string) string {
+		match := imageSrcPattern.FindStringSubmatch(tag)
+		if url, ok := imageURLs[bundlePath(match[2])]; ok && !isAbsoluteURL(match[2]) {
+			return match[1] + url + match[3]
+		}
+		return tag
+	})
+	coverImageURL := record.CoverImageURL
+	if url, ok := imageURLs[bundlePath(coverImageURL)]; ok && !isAbsoluteURL(coverImageURL) {
+		coverImageURL = url
+	}
+
+	releaseTime := time.Now()
+	if record.ReleaseTime != "" {
+		var err error
+		if releaseTime, err = utils.StringToTime(record.ReleaseTime); err != nil {
+			cleanup()
+			return 0, err
+		}
+	}
+
+	article := &model.Article{
+		ArticleTitle:    record.ArticleTitle,
+		ArticleType:     record.ArticleType,
+		BriefContent:    record.BriefContent,
+		ArticleContent:  articleContent,
+		IsSelection:     record.IsSelection,
+		FieldType:       record.FieldType,
+		CoverImageURL:   coverImageURL,
+		ArticleSource:   record.ArticleSource,
+		Slug:            record.Slug,
+		MetaDescription: record.MetaDescription,
+		OgImageURL:      record.OgImageURL,
+		ReleaseTime:     releaseTime,
+		CreateUser:      userID,
+		UpdateUser:      userID,
+	}
+	if err := svc.articleSvc.CreateArticle(ctx, article, imageIDs, tagIDs); err != nil {
+		cleanup()
+		return 0, err
+	}
+	return article.ArticleID, nil
+}
+
+// uploadBundleImage 将压缩包中的图片解压到临时文件后上传
+func (svc *ArticleTransferServiceImpl) uploadBundleImage(ctx context.Context, file *zip.File, userID int) (*uploadedImage, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "读取压缩包中的图片失败: "+file.Name)
+	}
+	defer src.Close()
+
+	ext := strings.ToLower(path.Ext(file.Name))
+	tmp, err := os.CreateTemp("", "article-import-*"+ext)
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("创建临时文件失败: %w", err))
+	}
+	defer os.Remove(tmp.Name())
+
+	// 限制实际解压的大小，防止压缩包头部信息与内容不符
+	size, err := io.Copy(tmp, io.LimitReader(src, importMaxImageSize+1))
+	closeErr := tmp.Close()
+	if err != nil || closeErr != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "解压图片失败: "+file.Name)
+	}
+	if size > importMaxImageSize {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("图片超过%dMB: %s", importMaxImageSize>>20, file.Name))
+	}
+
+	resp, err := svc.fileSvc.UploadFile(ctx, &filesvc.FileHeader{
+		OriginalFileName: path.Base(bundlePath(file.Name)),
+		ContentType:      mime.TypeByExtension(ext),
+		Size:             size,
+		TemporaryFile:    tmp.Name(),
+	}, utils.TypeArticle, 0, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadedImage{ID: resp.ID, URL: resp.URL}, nil
+}
+
+// uploadedImage 导入时上传的图片
+type uploadedImage struct {
+	ID  int
+	URL string
+}
+
+// importErrorMessage 生成导入失败行的错误提示，系统错误只记录日志不向调用方暴露细节
+func importErrorMessage(err error) string {
+	if bizErr, ok := utils.GetBusinessError(err); ok {
+		return bizErr.Msg
+	}
+	logrus.Errorf("导入文章失败: %v", err)
+	return "创建文章失败，请稍后重试"
+}
+
+// ExportArticles 按文章ID升序分批导出，ZIP格式包含 articles.jsonl 及文章关联的图片
+func (svc *ArticleTransferServiceImpl) ExportArticles(ctx context.Context, w io.Writer, req dto.ExportArticleRequest) error {
+	switch req.Format {
+	case dto.TransferFormatCSV:
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.Write(transferColumns); err != nil {
+			return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+		}
+		err := svc.eachExportRecord(ctx, req, nil, func(record dto.ArticleTransferRecord) error {
+			return writer.Write(recordToCSV(record))
+		})
+		if err != nil {
+			return err
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+		}
+		return nil
+
+	case dto.TransferFormatZIP:
+		// 压缩包内的文件需顺序写入，文章清单先写入缓冲区，图片写完后再写入清单
+		archive := zip.NewWriter(w)
+		var manifest bytes.Buffer
+		if err := svc.eachExportRecord(ctx, req, archive, jsonlWriter(&manifest)); err != nil {
+			return err
+		}
+		entry, err := archive.Create("articles.jsonl")
+		if err == nil {
+			_, err = manifest.WriteTo(entry)
+		}
+		if err == nil {
+			err = archive.Close()
+		}
+		if err != nil {
+			return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+		}
+		return nil
+
+	default:
+		return svc.eachExportRecord(ctx, req, nil, jsonlWriter(w))
+	}
+}
+
+// jsonlWriter 返回按 JSON Lines 格式逐行写入记录的函数，不转义正文中的HTML字符
+func jsonlWriter(w io.Writer) func(dto.ArticleTransferRecord) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return func(record dto.ArticleTransferRecord) error {
+		return encoder.Encode(record)
+	}
+}
+
+// eachExportRecord 分批查询文章并转换为导出记录
+// archive 不为空时将文章关联的图片写入压缩包，并将记录中的图片地址替换为压缩包内的路径
+func (svc *ArticleTransferServiceImpl) eachExportRecord(ctx context.Context, req dto.ExportArticleRequest, archive *zip.Writer, write func(dto.ArticleTransferRecord) error) error {
+	afterID := 0
+	for {
+		articles, err := svc.articleRepo.ListExportArticles(ctx, req, afterID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(articles) == 0 {
+			return nil
+		}
+
+		articleIDs := make([]int, 0, len(articles))
+		for _, article := range articles {
+			articleIDs = append(articleIDs, article.ArticleID)
+		}
+		tags, err := svc.tagRepo.ListArticleTags(ctx, articleIDs)
+		if err != nil {
+			return err
+		}
+		images, err := svc.fileRepo.ListImagesByBizIDs(ctx, utils.TypeArticle, articleIDs)
+		if err != nil {
+			return err
+		}
+		articleImages := make(map[int][]filemodel.Image)
+		for _, image := range images {
+			articleImages[image.BizID] = append(articleImages[image.BizID], image)
+		}
+
+		for _, article := range articles {
+			record := toTransferRecord(article, tags[article.ArticleID], articleImages[article.ArticleID])
+			if archive != nil {
+				svc.archiveImages(ctx, archive, article.ArticleID, articleImages[article.ArticleID], &record)
+			}
+			if err := write(record); err != nil {
+				return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+			}
+		}
+		afterID = articles[len(articles)-1].ArticleID
+	}
+}
+
+// toTransferRecord 将文章转换为导出记录
+func toTransferRecord(article model.Article, tags []dto.Tag, images []filemodel.Image) dto.ArticleTransferRecord {
+	record := dto.ArticleTransferRecord{
+		ArticleTitle:    article.ArticleTitle,
+		ArticleType:     article.ArticleType,
+		BriefContent:    article.BriefContent,
+		ArticleContent:  article.ArticleContent,
+		IsSelection:     article.IsSelection,
+		FieldType:       article.FieldType,
+		CoverImageURL:   article.CoverImageURL,
+		ArticleSource:   article.ArticleSource,
+		Status:          article.Status,
+		Slug:            article.Slug,
+		MetaDescription: article.MetaDescription,
+		OgImageURL:      article.OgImageURL,
+		Tags:            make([]string, 0, len(tags)),
+		Images:          make([]string, 0, len(images)),
+	}
+	if !article.ReleaseTime.IsZero() {
+		record.ReleaseTime = article.ReleaseTime.Format(transferTimeFormat)
+	}
+	for _, tag := range tags {
+		record.Tags = append(record.Tags, tag.TagName)
+	}
+	for _, image := range images {
+		record.Images = append(record.Images, image.URL)
+	}
+	return record
+}
+
+// archiveImages 将文章图片写入压缩包 images/<文章ID>/ 目录，并替换记录中的图片地址
+// 正文中的图片地址保存时已规范化，按规范地址匹配；单张图片读取失败时保留原地址并记录日志，不中断导出
+func (svc *ArticleTransferServiceImpl) archiveImages(ctx context.Context, archive *zip.Writer, articleID int, images []filemodel.Image, record *dto.ArticleTransferRecord) {
+	for i, image := range images {
+		name := fmt.Sprintf("images/%d/%s", articleID, path.Base(image.ObjectName))
+		if err := svc.archiveObject(ctx, archive, name, image.ObjectName); err != nil {
+			logrus.Warnf("导出文章%d的图片%d失败: %v", articleID, image.ID, err)
+			continue
+		}
+
+		// 原地址可能以规范地址为前缀，需先替换原地址
+		canonical := svc.processor.CanonicalObjectURL(image.URL)
+		record.Images[i] = name
+		record.ArticleContent = strings.ReplaceAll(record.ArticleContent, image.URL, name)
+		record.ArticleContent = strings.ReplaceAll(record.ArticleContent, canonical, name)
+		if svc.processor.CanonicalObjectURL(record.CoverImageURL) == canonical {
+			record.CoverImageURL = name
+		}
+	}
+}
+
+// archiveObject 从MinIO读取对象并以不压缩的方式写入压缩包，图片本身已压缩
+// 先完整读取对象再写入，避免读取中途失败在压缩包中留下不完整的文件
+func (svc *ArticleTransferServiceImpl) archiveObject(ctx context.Context, archive *zip.Writer, name string, objectName string) error {
+	object, err := svc.minioRepo.GetFile(ctx, objectName)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return err
+	}
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
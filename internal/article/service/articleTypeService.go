@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// ArticleTypeService 服务接口，定义方法，接收 context.Context 和数据模型。
+type ArticleTypeService interface {
+	// ListArticleTypes 获取文章类型列表
+	ListArticleTypes(ctx context.Context) ([]*model.ArticleType, error)
+	// CreateArticleType 创建文章类型
+	CreateArticleType(ctx context.Context, articleType *model.ArticleType) error
+	// UpdateArticleType 更新文章类型
+	UpdateArticleType(ctx context.Context, typeID int, req dto.UpdateArticleTypeRequest, userID int) error
+	// DeleteArticleType 删除文章类型
+	DeleteArticleType(ctx context.Context, typeID int, req dto.DeleteTypeRequest, userID int) error
+	// ReorderArticleTypes 调整文章类型顺序
+	ReorderArticleTypes(ctx context.Context, req dto.ReorderTypeRequest, userID int) error
+}
+
+// ArticleTypeServiceImpl 实现接口的具体结构体，持有数据访问层接口 Repository 的实例
+type ArticleTypeServiceImpl struct {
+	articleTypeRepo repository.ArticleTypeRepository
+}
+
+// NewArticleTypeService 创建服务实例
+func NewArticleTypeService(articleTypeRepo repository.ArticleTypeRepository) ArticleTypeService {
+	return &ArticleTypeServiceImpl{articleTypeRepo: articleTypeRepo}
+}
+
+// ListArticleTypes 获取文章类型列表
+func (svc *ArticleTypeServiceImpl) ListArticleTypes(ctx context.Context) ([]*model.ArticleType, error) {
+	return svc.articleTypeRepo.ListArticleTypes(ctx)
+}
+
+// CreateArticleType 创建文章类型
+func (svc *ArticleTypeServiceImpl) CreateArticleType(ctx context.Context, articleType *model.ArticleType) error {
+	if err := svc.checkTypeCodeAvailable(ctx, articleType.TypeCode); err != nil {
+		return err
+	}
+	return svc.articleTypeRepo.CreateArticleType(ctx, articleType)
+}
+
+// UpdateArticleType 更新文章类型
+// 修改编码时若仍有文章引用旧编码，需指定 cascade_articles 在同一事务中同步更新文章，否则拒绝修改
+// 编码修改后，该列表下的置顶随编码一并迁移
+func (svc *ArticleTypeServiceImpl) UpdateArticleType(ctx context.Context, typeID int, req dto.UpdateArticleTypeRequest, userID int) error {
+	articleType, err := svc.articleTypeRepo.GetArticleTypeByID(ctx, typeID)
+	if err != nil {
+		return err
+	}
+	if articleType == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章类型不存在或已被删除")
+	}
+
+	updateFields := make(map[string]interface{})
+	var articleCount int64
+	if req.TypeCode != "" && req.TypeCode != articleType.TypeCode {
+		if err := svc.checkTypeCodeAvailable(ctx, req.TypeCode); err != nil {
+			return err
+		}
+		articleCount, err = svc.articleTypeRepo.CountArticleTypeArticles(ctx, articleType.TypeCode)
+		if err != nil {
+			return err
+		}
+		if articleCount > 0 && !req.CascadeArticles {
+			return utils.NewBusinessError(utils.ErrCodeResourceConflict,
+				fmt.Sprintf("该类型编码仍被%d篇文章引用，如需同步修改文章请设置cascade_articles", articleCount))
+		}
+		updateFields["type_code"] = req.TypeCode
+	}
+	if req.TypeName != "" {
+		updateFields["type_name"] = req.TypeName
+	}
+	if req.SortOrder != nil {
+		updateFields["sort_order"] = *req.SortOrder
+	}
+
+	if len(updateFields) == 0 {
+		return nil
+	}
+	updateFields["update_user"] = userID
+
+	return svc.articleTypeRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if err := svc.articleTypeRepo.UpdateArticleType(ctx, tx, typeID, updateFields); err != nil {
+			return err
+		}
+		if _, recoded := updateFields["type_code"]; recoded {
+			return svc.articleTypeRepo.ReplaceArticleType(ctx, tx, articleType.TypeCode, req.TypeCode)
+		}
+		return nil
+	})
+}
+
+// DeleteArticleType 删除文章类型(通过更新is_deleted字段实现)
+// 仍有文章引用时需通过 replace_with 指定迁移目标，文章迁移与删除在同一事务中完成，否则拒绝删除
+func (svc *ArticleTypeServiceImpl) DeleteArticleType(ctx context.Context, typeID int, req dto.DeleteTypeRequest, userID int) error {
+	articleType, err := svc.articleTypeRepo.GetArticleTypeByID(ctx, typeID)
+	if err != nil {
+		return err
+	}
+	if articleType == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章类型不存在或已被删除")
+	}
+
+	if req.ReplaceWith != "" {
+		target, err := svc.articleTypeRepo.GetArticleTypeByCode(ctx, req.ReplaceWith)
+		if err != nil {
+			return err
+		}
+		if target == nil || target.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "迁移目标文章类型不存在或已被删除")
+		}
+		if target.ID == typeID {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "迁移目标不能是待删除的文章类型")
+		}
+	}
+
+	articleCount, err := svc.articleTypeRepo.CountArticleTypeArticles(ctx, articleType.TypeCode)
+	if err != nil {
+		return err
+	}
+	if articleCount > 0 && req.ReplaceWith == "" {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict,
+			fmt.Sprintf("该文章类型仍被%d篇文章引用，请通过replace_with指定迁移目标后再删除", articleCount))
+	}
+
+	updateFields := map[string]interface{}{
+		"is_deleted":  utils.DeletedFlagYes,
+		"update_user": userID,
+	}
+	return svc.articleTypeRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if articleCount > 0 {
+			if err := svc.articleTypeRepo.ReplaceArticleType(ctx, tx, articleType.TypeCode, req.ReplaceWith); err != nil {
+				return err
+			}
+		}
+		return svc.articleTypeRepo.UpdateArticleType(ctx, tx, typeID, updateFields)
+	})
+}
+
+// ReorderArticleTypes 调整文章类型顺序，ID列表需包含全部未删除的文章类型
+func (svc *ArticleTypeServiceImpl) ReorderArticleTypes(ctx context.Context, req dto.ReorderTypeRequest, userID int) error {
+	articleTypes, err := svc.articleTypeRepo.ListArticleTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(articleTypes))
+	for _, articleType := range articleTypes {
+		ids = append(ids, articleType.ID)
+	}
+	if err := checkReorderIDs(ids, req.IDList); err != nil {
+		return err
+	}
+
+	return svc.articleTypeRepo.ReorderArticleTypes(ctx, req.IDList, userID)
+}
+
+// checkTypeCodeAvailable 检查类型编码是否可用，已删除的文章类型仍占用编码
+func (svc *ArticleTypeServiceImpl) checkTypeCodeAvailable(ctx context.Context, typeCode string) error {
+	existing, err := svc.articleTypeRepo.GetArticleTypeByCode(ctx, typeCode)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	if existing.IsDeleted == utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "类型编码已被已删除的文章类型占用")
+	}
+	return utils.NewBusinessError(utils.ErrCodeResourceExists, "类型编码已存在")
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"mime"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/repository"
+	"news-release/internal/config"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFeedTitle = "最新发布"
+	defaultFeedLimit = 20
+	defaultImageType = "image/jpeg"
+)
+
+// FeedService 文章订阅源服务接口
+type FeedService interface {
+	// GetArticleFeed 查询最新的已发布文章并组装为订阅源，配置了站点地址时忽略传入的siteURL
+	GetArticleFeed(ctx context.Context, req dto.FeedRequest, siteURL string, requestURI string) (*dto.Feed, error)
+}
+
+// FeedServiceImpl 实现接口的具体结构体
+type FeedServiceImpl struct {
+	articleRepo repository.ArticleRepository
+	tagRepo     repository.TagRepository
+	cfg         config.FeedConfig
+	siteCfg     config.SiteConfig
+}
+
+// NewFeedService 创建服务实例
+func NewFeedService(articleRepo repository.ArticleRepository, tagRepo repository.TagRepository, cfg *config.Config) FeedService {
+	feedCfg := cfg.Feed
+	if feedCfg.Title == "" {
+		feedCfg.Title = defaultFeedTitle
+	}
+	if feedCfg.Limit <= 0 {
+		feedCfg.Limit = defaultFeedLimit
+	}
+	return &FeedServiceImpl{articleRepo: articleRepo, tagRepo: tagRepo, cfg: feedCfg, siteCfg: cfg.Site}
+}
+
+// GetArticleFeed 查询最新的已发布文章并组装为订阅源
+func (svc *FeedServiceImpl) GetArticleFeed(ctx context.Context, req dto.FeedRequest, siteURL string, requestURI string) (*dto.Feed, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = svc.cfg.Limit
+	}
+
+	articles, err := svc.articleRepo.ListFeedArticles(ctx, req, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	articleIDs := make([]int, 0, len(articles))
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ArticleID)
+	}
+	tags, err := svc.tagRepo.ListArticleTags(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	siteURL = svc.siteCfg.BaseURL(siteURL)
+
+	feed := &dto.Feed{
+		Title:       svc.cfg.Title,
+		Description: svc.cfg.Description,
+		SiteURL:     siteURL,
+		SelfURL:     siteURL + requestURI,
+		Items:       make([]dto.FeedItem, 0, len(articles)),
+	}
+	for _, article := range articles {
+		item := dto.FeedItem{
+			ArticleID:   article.ArticleID,
+			Title:       article.ArticleTitle,
+			Link:        svc.siteCfg.ArticleLink(siteURL, article.ArticleID, article.Slug),
+			Summary:     article.BriefContent,
+			ContentHTML: article.ArticleContent,
+			Author:      article.ArticleSource,
+			Published:   article.ReleaseTime,
+			Updated:     latestTime(article.ReleaseTime, article.UpdateTime),
+			ImageURL:    article.CoverImageURL,
+		}
+
+		// 领域、文章类型和标签均作为分类输出
+		for _, category := range []string{article.FieldName, article.ArticleType} {
+			if category != "" {
+				item.Categories = append(item.Categories, category)
+			}
+		}
+		for _, tag := range tags[article.ArticleID] {
+			item.Categories = append(item.Categories, tag.TagName)
+		}
+
+		if item.ImageURL != "" {
+			item.ImageType = imageMimeType(item.ImageURL)
+		}
+
+		feed.Updated = latestTime(feed.Updated, item.Updated)
+		feed.Items = append(feed.Items, item)
+	}
+
+	// 没有文章时使用固定时间，保证订阅源内容不变时 Last-Modified 不变
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Unix(0, 0)
+	}
+
+	return feed, nil
+}
+
+// latestTime 返回两个时间中较晚的一个
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// imageMimeType 根据图片地址的扩展名推断MIME类型，无法推断时按JPEG处理
+func imageMimeType(imageURL string) string {
+	if idx := strings.IndexAny(imageURL, "?#"); idx >= 0 {
+		imageURL = imageURL[:idx]
+	}
+	if mimeType := mime.TypeByExtension(strings.ToLower(path.Ext(imageURL))); strings.HasPrefix(mimeType, "image/") {
+		return mimeType
+	}
+	return defaultImageType
+}
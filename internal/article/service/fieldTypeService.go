@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"news-release/internal/article/dto"
 	"news-release/internal/article/model"
 	"news-release/internal/article/repository"
 	"news-release/internal/utils"
+
+	"gorm.io/gorm"
 )
 
 // FieldTypeService 服务接口，定义方法，接收 context.Context 和数据模型。
@@ -15,9 +18,11 @@ type FieldTypeService interface {
 	// CreateFieldType 创建领域类型
 	CreateFieldType(ctx context.Context, fieldType *model.FieldType) error
 	// UpdateFieldType 更新领域类型
-	UpdateFieldType(ctx context.Context, fieldID int, req dto.UpdateFieldTypeRequest) error
+	UpdateFieldType(ctx context.Context, fieldID int, req dto.UpdateFieldTypeRequest, userID int) error
 	// DeleteFieldType 删除领域类型
-	DeleteFieldType(ctx context.Context, fieldID int) error
+	DeleteFieldType(ctx context.Context, fieldID int, req dto.DeleteTypeRequest, userID int) error
+	// ReorderFieldTypes 调整领域类型顺序
+	ReorderFieldTypes(ctx context.Context, req dto.ReorderTypeRequest, userID int) error
 }
 
 // FieldTypeServiceImpl 实现接口的具体结构体，持有数据访问层接口 Repository 的实例
@@ -37,31 +42,162 @@ func (svc *FieldTypeServiceImpl) GetFieldType(ctx context.Context) ([]*model.Fie
 
 // CreateFieldType 创建领域类型
 func (svc *FieldTypeServiceImpl) CreateFieldType(ctx context.Context, fieldType *model.FieldType) error {
+	if err := svc.checkFieldCodeAvailable(ctx, fieldType.FieldCode); err != nil {
+		return err
+	}
 	return svc.fieldTypeRepo.CreateFieldType(ctx, fieldType)
 }
 
 // UpdateFieldType 更新领域类型
-func (svc *FieldTypeServiceImpl) UpdateFieldType(ctx context.Context, fieldID int, req dto.UpdateFieldTypeRequest) error {
+// 修改编码时若仍有文章引用旧编码，需指定 cascade_articles 在同一事务中同步更新文章，否则拒绝修改
+// 编码修改后，该列表下的置顶随编码一并迁移
+func (svc *FieldTypeServiceImpl) UpdateFieldType(ctx context.Context, fieldID int, req dto.UpdateFieldTypeRequest, userID int) error {
+	fieldType, err := svc.fieldTypeRepo.GetFieldTypeByID(ctx, fieldID)
+	if err != nil {
+		return err
+	}
+	if fieldType == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "领域类型不存在或已被删除")
+	}
+
 	updateFields := make(map[string]interface{})
-	if req.FieldCode != "" {
+	var articleCount int64
+	if req.FieldCode != "" && req.FieldCode != fieldType.FieldCode {
+		if err := svc.checkFieldCodeAvailable(ctx, req.FieldCode); err != nil {
+			return err
+		}
+		articleCount, err = svc.fieldTypeRepo.CountFieldTypeArticles(ctx, fieldType.FieldCode)
+		if err != nil {
+			return err
+		}
+		if articleCount > 0 && !req.CascadeArticles {
+			return utils.NewBusinessError(utils.ErrCodeResourceConflict,
+				fmt.Sprintf("该领域编码仍被%d篇文章引用，如需同步修改文章请设置cascade_articles", articleCount))
+		}
 		updateFields["field_code"] = req.FieldCode
 	}
 	if req.FieldName != "" {
 		updateFields["field_name"] = req.FieldName
 	}
+	if req.SortOrder != nil {
+		updateFields["sort_order"] = *req.SortOrder
+	}
 
 	if len(updateFields) == 0 {
 		return nil
 	}
+	updateFields["update_user"] = userID
 
-	return svc.fieldTypeRepo.UpdateFieldType(ctx, fieldID, updateFields)
+	return svc.fieldTypeRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if err := svc.fieldTypeRepo.UpdateFieldType(ctx, tx, fieldID, updateFields); err != nil {
+			return err
+		}
+		if _, recoded := updateFields["field_code"]; recoded {
+			return svc.fieldTypeRepo.ReplaceArticleFieldType(ctx, tx, fieldType.FieldCode, req.FieldCode)
+		}
+		return nil
+	})
 }
 
 // DeleteFieldType 删除领域类型(通过更新is_deleted字段实现)
-func (svc *FieldTypeServiceImpl) DeleteFieldType(ctx context.Context, fieldID int) error {
-	// 复用更新方法，设置is_deleted标志
+// 仍有文章引用时需通过 replace_with 指定迁移目标，文章迁移与删除在同一事务中完成，否则拒绝删除
+func (svc *FieldTypeServiceImpl) DeleteFieldType(ctx context.Context, fieldID int, req dto.DeleteTypeRequest, userID int) error {
+	fieldType, err := svc.fieldTypeRepo.GetFieldTypeByID(ctx, fieldID)
+	if err != nil {
+		return err
+	}
+	if fieldType == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "领域类型不存在或已被删除")
+	}
+
+	if req.ReplaceWith != "" {
+		target, err := svc.fieldTypeRepo.GetFieldTypeByCode(ctx, req.ReplaceWith)
+		if err != nil {
+			return err
+		}
+		if target == nil || target.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "迁移目标领域类型不存在或已被删除")
+		}
+		if target.FieldID == fieldID {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "迁移目标不能是待删除的领域类型")
+		}
+	}
+
+	articleCount, err := svc.fieldTypeRepo.CountFieldTypeArticles(ctx, fieldType.FieldCode)
+	if err != nil {
+		return err
+	}
+	if articleCount > 0 && req.ReplaceWith == "" {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict,
+			fmt.Sprintf("该领域类型仍被%d篇文章引用，请通过replace_with指定迁移目标后再删除", articleCount))
+	}
+
 	updateFields := map[string]interface{}{
-		"is_deleted": utils.DeletedFlagYes,
+		"is_deleted":  utils.DeletedFlagYes,
+		"update_user": userID,
+	}
+	return svc.fieldTypeRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if articleCount > 0 {
+			if err := svc.fieldTypeRepo.ReplaceArticleFieldType(ctx, tx, fieldType.FieldCode, req.ReplaceWith); err != nil {
+				return err
+			}
+		}
+		return svc.fieldTypeRepo.UpdateFieldType(ctx, tx, fieldID, updateFields)
+	})
+}
+
+// ReorderFieldTypes 调整领域类型顺序，ID列表需包含全部未删除的领域类型
+func (svc *FieldTypeServiceImpl) ReorderFieldTypes(ctx context.Context, req dto.ReorderTypeRequest, userID int) error {
+	fieldTypes, err := svc.fieldTypeRepo.GetFieldType(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(fieldTypes))
+	for _, fieldType := range fieldTypes {
+		ids = append(ids, fieldType.FieldID)
+	}
+	if err := checkReorderIDs(ids, req.IDList); err != nil {
+		return err
+	}
+
+	return svc.fieldTypeRepo.ReorderFieldTypes(ctx, req.IDList, userID)
+}
+
+// checkFieldCodeAvailable 检查领域编码是否可用，已删除的领域类型仍占用编码
+func (svc *FieldTypeServiceImpl) checkFieldCodeAvailable(ctx context.Context, fieldCode string) error {
+	existing, err := svc.fieldTypeRepo.GetFieldTypeByCode(ctx, fieldCode)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	if existing.IsDeleted == utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "领域编码已被已删除的领域类型占用")
+	}
+	return utils.NewBusinessError(utils.ErrCodeResourceExists, "领域编码已存在")
+}
+
+// checkReorderIDs 检查排序ID列表与现有ID集合完全一致且没有重复
+func checkReorderIDs(existingIDs []int, idList []int) error {
+	existing := make(map[int]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+
+	seen := make(map[int]bool, len(idList))
+	for _, id := range idList {
+		if !existing[id] {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("ID为%d的类型不存在或已被删除", id))
+		}
+		if seen[id] {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, fmt.Sprintf("ID %d 重复", id))
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(existing) {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "排序列表需包含全部未删除的类型")
 	}
-	return svc.fieldTypeRepo.UpdateFieldType(ctx, fieldID, updateFields)
+	return nil
 }
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/article/dto"
+	"news-release/internal/article/model"
+	"news-release/internal/article/repository"
+	"news-release/internal/utils"
+	"strings"
+)
+
+// TagService 标签服务接口
+type TagService interface {
+	// ListTags 分页查询标签列表
+	ListTags(ctx context.Context, page, pageSize int, tagName string) ([]dto.TagResponse, int64, error)
+	// GetTag 查询标签详情
+	GetTag(ctx context.Context, tagID int) (*dto.TagResponse, error)
+	// CreateTag 创建标签
+	CreateTag(ctx context.Context, tag *model.Tag) error
+	// UpdateTag 更新标签
+	UpdateTag(ctx context.Context, tagID int, req dto.UpdateTagRequest, userID int) error
+	// DeleteTag 删除标签
+	DeleteTag(ctx context.Context, tagID int, userID int) error
+}
+
+// TagServiceImpl 实现接口的具体结构体
+type TagServiceImpl struct {
+	tagRepo repository.TagRepository
+}
+
+// NewTagService 创建服务实例
+func NewTagService(tagRepo repository.TagRepository) TagService {
+	return &TagServiceImpl{tagRepo: tagRepo}
+}
+
+// ListTags 分页查询标签列表
+func (svc *TagServiceImpl) ListTags(ctx context.Context, page, pageSize int, tagName string) ([]dto.TagResponse, int64, error) {
+	return svc.tagRepo.ListTags(ctx, page, pageSize, tagName)
+}
+
+// GetTag 查询标签详情
+func (svc *TagServiceImpl) GetTag(ctx context.Context, tagID int) (*dto.TagResponse, error) {
+	return svc.tagRepo.GetTag(ctx, tagID)
+}
+
+// CreateTag 创建标签，标签名称不能与未删除的标签重复
+func (svc *TagServiceImpl) CreateTag(ctx context.Context, tag *model.Tag) error {
+	tag.TagName = strings.TrimSpace(tag.TagName)
+
+	existing, err := svc.tagRepo.GetTagByName(ctx, tag.TagName)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "标签已存在")
+	}
+
+	return svc.tagRepo.CreateTag(ctx, tag)
+}
+
+// UpdateTag 更新标签名称
+func (svc *TagServiceImpl) UpdateTag(ctx context.Context, tagID int, req dto.UpdateTagRequest, userID int) error {
+	tagName := strings.TrimSpace(req.TagName)
+
+	existing, err := svc.tagRepo.GetTagByName(ctx, tagName)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.TagID != tagID {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "标签已存在")
+	}
+
+	updateFields := map[string]interface{}{
+		"tag_name":    tagName,
+		"update_user": userID,
+	}
+	return svc.tagRepo.UpdateTag(ctx, tagID, updateFields)
+}
+
+// DeleteTag 软删除标签，已删除的标签不再出现在文章的标签列表中
+func (svc *TagServiceImpl) DeleteTag(ctx context.Context, tagID int, userID int) error {
+	updateFields := map[string]interface{}{
+		"is_deleted":  utils.DeletedFlagYes,
+		"update_user": userID,
+	}
+	return svc.tagRepo.UpdateTag(ctx, tagID, updateFields)
+}
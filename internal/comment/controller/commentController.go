@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"news-release/internal/comment/dto"
+	"news-release/internal/comment/model"
+	"news-release/internal/comment/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController 评论控制器
+type CommentController struct {
+	commentService service.CommentService
+}
+
+// NewCommentController 创建控制器实例
+func NewCommentController(commentService service.CommentService) *CommentController {
+	return &CommentController{commentService: commentService}
+}
+
+// pageParams 处理分页参数默认值
+func pageParams(page, pageSize int) (int, int) {
+	// page 默认1
+	if page == 0 {
+		page = 1
+	}
+	// pageSize 默认10
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
+// ListArticleComments 分页查询文章下的评论
+func (ctr *CommentController) ListArticleComments(ctx *gin.Context) {
+	var urlReq dto.ArticleUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	var req dto.ListCommentRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+	page, pageSize := pageParams(req.Page, req.PageSize)
+
+	results, total, err := ctr.commentService.ListArticleComments(ctx, urlReq.ArticleID, page, pageSize)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// ListReplies 分页查询评论下的回复
+func (ctr *CommentController) ListReplies(ctx *gin.Context) {
+	var urlReq dto.CommentUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	var req dto.ListCommentRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+	page, pageSize := pageParams(req.Page, req.PageSize)
+
+	results, total, err := ctr.commentService.ListReplies(ctx, urlReq.ID, page, pageSize)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// CreateComment 发表评论或回复
+func (ctr *CommentController) CreateComment(ctx *gin.Context) {
+	var req dto.CreateCommentRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := ctr.commentService.CreateComment(ctx, req, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	message := "评论发表成功"
+	if result.Status == model.CommentStatusPending {
+		message = "评论已提交，审核通过后展示"
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data":    result,
+	})
+}
+
+// DeleteComment 删除本人发表的评论
+func (ctr *CommentController) DeleteComment(ctx *gin.Context) {
+	var urlReq dto.CommentUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.commentService.DeleteComment(ctx, urlReq.ID, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "评论删除成功",
+	})
+}
+
+// ListModeration 分页查询评论审核队列
+func (ctr *CommentController) ListModeration(ctx *gin.Context) {
+	var req dto.ModerationListRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+	page, pageSize := pageParams(req.Page, req.PageSize)
+
+	results, total, err := ctr.commentService.ListModeration(ctx, page, pageSize, req)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// ApproveComment 审核通过评论
+func (ctr *CommentController) ApproveComment(ctx *gin.Context) {
+	var urlReq dto.CommentUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.commentService.ApproveComment(ctx, urlReq.ID, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "评论审核通过",
+	})
+}
+
+// RejectComment 驳回评论
+func (ctr *CommentController) RejectComment(ctx *gin.Context) {
+	ctr.handleModerate(ctx, ctr.commentService.RejectComment, "评论已驳回")
+}
+
+// HideComment 隐藏评论
+func (ctr *CommentController) HideComment(ctx *gin.Context) {
+	ctr.handleModerate(ctx, ctr.commentService.HideComment, "评论已隐藏")
+}
+
+// handleModerate 处理需要填写原因的审核操作
+func (ctr *CommentController) handleModerate(ctx *gin.Context, action func(ctx context.Context, commentID int, reason string, userID int) error, message string) {
+	var urlReq dto.CommentUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	var req dto.ModerateCommentRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取当前用户ID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := action(ctx, urlReq.ID, req.Reason, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+	})
+}
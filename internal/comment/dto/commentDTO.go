@@ -0,0 +1,87 @@
+package dto
+
+import "time"
+
+// CommentUrlID 评论ID路径参数
+type CommentUrlID struct {
+	ID int `uri:"id" binding:"required,numeric"` // 评论ID
+}
+
+// ArticleUrlID 文章ID路径参数
+type ArticleUrlID struct {
+	ArticleID int `uri:"id" binding:"required,numeric"` // 文章ID
+}
+
+// ListCommentRequest 评论列表查询请求参数
+type ListCommentRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`             // 页码，最小为1
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=50"` // 页大小，1-50
+}
+
+// ModerationListRequest 审核队列查询请求参数
+type ModerationListRequest struct {
+	Page      int    `form:"page" binding:"omitempty,min=1"`                                    // 页码，最小为1
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=100"`                       // 页大小，1-100
+	Status    string `form:"status" binding:"omitempty,oneof=PENDING APPROVED REJECTED HIDDEN"` // 评论状态，默认待审核
+	ArticleID int    `form:"article_id" binding:"omitempty,min=1"`                              // 文章ID
+	Keyword   string `form:"keyword" binding:"omitempty,max=50"`                                // 评论内容关键词
+}
+
+// CreateCommentRequest 发表评论请求参数
+type CreateCommentRequest struct {
+	ArticleID int    `json:"article_id" binding:"required,min=1"`                  // 文章ID
+	ParentID  int    `json:"parent_id" binding:"omitempty,min=1"`                  // 被回复的评论ID，为空时发表顶层评论
+	Content   string `json:"content" binding:"required,non_empty_string,max=1000"` // 评论内容
+}
+
+// ModerateCommentRequest 驳回/隐藏评论请求参数
+type ModerateCommentRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=500"` // 驳回或隐藏原因
+}
+
+// CommentResponse 评论响应结构体
+type CommentResponse struct {
+	ID              int               `json:"id"`
+	ArticleID       int               `json:"article_id"`
+	ParentID        int               `json:"parent_id"`
+	RootID          int               `json:"root_id"`
+	Content         string            `json:"content"`
+	UserID          int               `json:"user_id"`
+	Nickname        string            `json:"nickname"`
+	AvatarURL       string            `json:"avatar_url"`
+	ReplyToUser     int               `json:"reply_to_user"`
+	ReplyToNickname string            `json:"reply_to_nickname"`
+	CreateTime      time.Time         `json:"create_time"`
+	ReplyCount      int64             `json:"reply_count" gorm:"-"`       // 回复数，仅顶层评论返回
+	Replies         []CommentResponse `json:"replies,omitempty" gorm:"-"` // 最新的几条回复，仅顶层评论返回
+}
+
+// CreateCommentResponse 发表评论响应结构体
+type CreateCommentResponse struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"` // 评论状态，PENDING 表示需审核后才会展示
+}
+
+// ModerationCommentResponse 审核队列评论响应结构体
+type ModerationCommentResponse struct {
+	ID               int        `json:"id"`
+	ArticleID        int        `json:"article_id"`
+	ArticleTitle     string     `json:"article_title"`
+	ParentID         int        `json:"parent_id"`
+	RootID           int        `json:"root_id"`
+	Content          string     `json:"content"`
+	Status           string     `json:"status"`
+	HitWords         string     `json:"hit_words"`
+	ModerationReason string     `json:"moderation_reason"`
+	ModerateUser     int        `json:"moderate_user"`
+	ModerateTime     *time.Time `json:"moderate_time"`
+	UserID           int        `json:"user_id"`
+	Nickname         string     `json:"nickname"`
+	CreateTime       time.Time  `json:"create_time"`
+}
+
+// ReplyCount 顶层评论的回复数统计
+type ReplyCount struct {
+	RootID int
+	Count  int64
+}
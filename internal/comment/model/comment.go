@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// 评论状态常量定义
+const (
+	CommentStatusPending  = "PENDING"  // 待审核（命中敏感词的评论进入审核队列）
+	CommentStatusApproved = "APPROVED" // 已通过，公开展示
+	CommentStatusRejected = "REJECTED" // 已驳回
+	CommentStatusHidden   = "HIDDEN"   // 已隐藏（通过后被管理员隐藏）
+)
+
+// Comment 文章评论数据模型
+// 评论按两级展示：顶层评论的 RootID 为0，回复的 RootID 指向所属顶层评论，ParentID 指向被回复的评论
+type Comment struct {
+	ID               int        `json:"id" gorm:"primaryKey;column:id"`
+	ArticleID        int        `json:"article_id" gorm:"column:article_id;index:idx_article_status"`
+	ParentID         int        `json:"parent_id" gorm:"column:parent_id;default:0"`                           // 被回复的评论ID，顶层评论为0
+	RootID           int        `json:"root_id" gorm:"column:root_id;default:0;index:idx_root_id"`             // 所属顶层评论ID，顶层评论为0
+	ReplyToUser      int        `json:"reply_to_user" gorm:"column:reply_to_user;default:0"`                   // 被回复的用户ID
+	Content          string     `json:"content" gorm:"type:varchar(1000);not null;column:content"`             // 评论内容
+	Status           string     `json:"status" gorm:"type:varchar(20);column:status;index:idx_article_status"` // 评论状态
+	HitWords         string     `json:"hit_words" gorm:"type:varchar(500);column:hit_words"`                   // 命中的敏感词，以英文逗号分隔
+	ModerationReason string     `json:"moderation_reason" gorm:"type:varchar(500);column:moderation_reason"`   // 驳回或隐藏原因
+	ModerateUser     int        `json:"moderate_user" gorm:"column:moderate_user"`                             // 审核人ID
+	ModerateTime     *time.Time `json:"moderate_time" gorm:"column:moderate_time"`                             // 审核时间
+	IsDeleted        string     `json:"is_deleted" gorm:"column:is_deleted;default:N"`                         // 软删除标志，默认值为N
+	CreateTime       time.Time  `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+	UpdateTime       time.Time  `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser       int        `json:"create_user" gorm:"column:create_user;index:idx_create_user"` // 评论人ID
+	UpdateUser       int        `json:"update_user" gorm:"column:update_user"`                       // 最后更新人ID
+}
+
+// TableName 设置表名
+func (*Comment) TableName() string {
+	return "comments"
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/comment/dto"
+	"news-release/internal/comment/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// commentColumns 评论公开展示所需的查询字段
+const commentColumns = `c.id, c.article_id, c.parent_id, c.root_id, c.content, c.create_user AS user_id,
+	u.nickname, u.avatar_url, c.reply_to_user, ru.nickname AS reply_to_nickname, c.create_time`
+
+// CommentRepository 评论数据访问接口
+type CommentRepository interface {
+	// CreateComment 创建评论
+	CreateComment(ctx context.Context, comment *model.Comment) error
+	// GetComment 查询未删除的评论
+	GetComment(ctx context.Context, commentID int) (*model.Comment, error)
+	// CountUserCommentsSince 统计用户在指定时间之后发表的评论数，用于发表频率限制
+	CountUserCommentsSince(ctx context.Context, userID int, since time.Time) (int64, error)
+	// ListRootComments 分页查询文章下已通过的顶层评论
+	ListRootComments(ctx context.Context, articleID int, page, pageSize int) ([]dto.CommentResponse, int64, error)
+	// ListReplies 分页查询顶层评论下已通过的回复
+	ListReplies(ctx context.Context, rootID int, page, pageSize int) ([]dto.CommentResponse, int64, error)
+	// ListLatestReplies 批量查询各顶层评论下最新的若干条回复，按顶层评论ID分组
+	ListLatestReplies(ctx context.Context, rootIDs []int, limit int) (map[int][]dto.CommentResponse, error)
+	// CountReplies 批量统计各顶层评论下已通过的回复数
+	CountReplies(ctx context.Context, rootIDs []int) (map[int]int64, error)
+	// ListModeration 分页查询审核队列
+	ListModeration(ctx context.Context, page, pageSize int, req dto.ModerationListRequest) ([]dto.ModerationCommentResponse, int64, error)
+	// TransitCommentStatus 变更评论状态
+	TransitCommentStatus(ctx context.Context, commentID int, fromStatus []string, updateFields map[string]interface{}) error
+	// UpdateComment 更新评论
+	UpdateComment(ctx context.Context, commentID int, updateFields map[string]interface{}) error
+}
+
+// CommentRepositoryImpl 实现接口的具体结构体
+type CommentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository 创建数据访问实例
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &CommentRepositoryImpl{db: db}
+}
+
+// CreateComment 创建评论
+func (repo *CommentRepositoryImpl) CreateComment(ctx context.Context, comment *model.Comment) error {
+	if err := repo.db.WithContext(ctx).Create(comment).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建评论失败: %w", err))
+	}
+	return nil
+}
+
+// GetComment 查询未删除的评论
+func (repo *CommentRepositoryImpl) GetComment(ctx context.Context, commentID int) (*model.Comment, error) {
+	var comment model.Comment
+	err := repo.db.WithContext(ctx).
+		Where("id = ? AND is_deleted = ?", commentID, utils.DeletedFlagNo).
+		First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "评论不存在或已被删除，请刷新页面后重试")
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+	return &comment, nil
+}
+
+// CountUserCommentsSince 统计用户在指定时间之后发表的评论数，已删除的评论同样计入
+func (repo *CommentRepositoryImpl) CountUserCommentsSince(ctx context.Context, userID int, since time.Time) (int64, error) {
+	var count int64
+	if err := repo.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("create_user = ? AND create_time >= ?", userID, since).
+		Count(&count).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("统计用户评论数失败: %v", err))
+	}
+	return count, nil
+}
+
+// publicQuery 构建公开评论查询，仅返回已通过且未删除的评论
+func (repo *CommentRepositoryImpl) publicQuery(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).Table("comments c").
+		Select(commentColumns).
+		Joins("LEFT JOIN users u ON u.user_id = c.create_user").
+		Joins("LEFT JOIN users ru ON ru.user_id = c.reply_to_user").
+		Where("c.is_deleted = ? AND c.status = ?", utils.DeletedFlagNo, model.CommentStatusApproved)
+}
+
+// paginate 计算总数并查询分页数据
+func paginate(query *gorm.DB, page, pageSize int, dest interface{}) (int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	// 计算总数
+	var total int64
+	countQuery := query.Session(&gorm.Session{})
+	if err := countQuery.Count(&total).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 查询数据
+	if err := query.Offset(offset).Limit(pageSize).Find(dest).Error; err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return total, nil
+}
+
+// ListRootComments 分页查询文章下已通过的顶层评论，按发表时间降序排列
+func (repo *CommentRepositoryImpl) ListRootComments(ctx context.Context, articleID int, page, pageSize int) ([]dto.CommentResponse, int64, error) {
+	var comments []dto.CommentResponse
+	query := repo.publicQuery(ctx).
+		Where("c.article_id = ? AND c.root_id = 0", articleID).
+		Order("c.create_time DESC, c.id DESC")
+
+	total, err := paginate(query, page, pageSize, &comments)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ListReplies 分页查询顶层评论下已通过的回复，按发表时间升序排列
+func (repo *CommentRepositoryImpl) ListReplies(ctx context.Context, rootID int, page, pageSize int) ([]dto.CommentResponse, int64, error) {
+	var replies []dto.CommentResponse
+	query := repo.publicQuery(ctx).
+		Where("c.root_id = ?", rootID).
+		Order("c.create_time ASC, c.id ASC")
+
+	total, err := paginate(query, page, pageSize, &replies)
+	if err != nil {
+		return nil, 0, err
+	}
+	return replies, total, nil
+}
+
+// ListLatestReplies 批量查询各顶层评论下最新的若干条回复
+// 通过相关子查询统计比当前回复更新的回复数，只保留前 limit 条
+func (repo *CommentRepositoryImpl) ListLatestReplies(ctx context.Context, rootIDs []int, limit int) (map[int][]dto.CommentResponse, error) {
+	res := make(map[int][]dto.CommentResponse, len(rootIDs))
+	if len(rootIDs) == 0 || limit <= 0 {
+		return res, nil
+	}
+
+	var replies []dto.CommentResponse
+	err := repo.publicQuery(ctx).
+		Where("c.root_id IN (?)", rootIDs).
+		Where(`(SELECT COUNT(*) FROM comments c2 WHERE c2.root_id = c.root_id AND c2.is_deleted = ? AND c2.status = ? AND c2.id > c.id) < ?`,
+			utils.DeletedFlagNo, model.CommentStatusApproved, limit).
+		Order("c.root_id, c.id ASC").
+		Find(&replies).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询评论回复失败: %v", err))
+	}
+
+	for _, reply := range replies {
+		res[reply.RootID] = append(res[reply.RootID], reply)
+	}
+	return res, nil
+}
+
+// CountReplies 批量统计各顶层评论下已通过的回复数
+func (repo *CommentRepositoryImpl) CountReplies(ctx context.Context, rootIDs []int) (map[int]int64, error) {
+	res := make(map[int]int64, len(rootIDs))
+	if len(rootIDs) == 0 {
+		return res, nil
+	}
+
+	var counts []dto.ReplyCount
+	err := repo.db.WithContext(ctx).Model(&model.Comment{}).
+		Select("root_id, COUNT(*) AS count").
+		Where("root_id IN (?) AND is_deleted = ? AND status = ?", rootIDs, utils.DeletedFlagNo, model.CommentStatusApproved).
+		Group("root_id").
+		Find(&counts).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("统计评论回复数失败: %v", err))
+	}
+
+	for _, count := range counts {
+		res[count.RootID] = count.Count
+	}
+	return res, nil
+}
+
+// ListModeration 分页查询审核队列，待审核的评论按发表时间升序排列，其余按审核时间降序排列
+func (repo *CommentRepositoryImpl) ListModeration(ctx context.Context, page, pageSize int, req dto.ModerationListRequest) ([]dto.ModerationCommentResponse, int64, error) {
+	var comments []dto.ModerationCommentResponse
+
+	query := repo.db.WithContext(ctx).Table("comments c").
+		Select(`c.id, c.article_id, a.article_title, c.parent_id, c.root_id, c.content, c.status, c.hit_words,
+			c.moderation_reason, c.moderate_user, c.moderate_time, c.create_user AS user_id, u.nickname, c.create_time`).
+		Joins("LEFT JOIN articles a ON a.article_id = c.article_id").
+		Joins("LEFT JOIN users u ON u.user_id = c.create_user").
+		Where("c.is_deleted = ? AND c.status = ?", utils.DeletedFlagNo, req.Status)
+
+	if req.ArticleID != 0 {
+		query = query.Where("c.article_id = ?", req.ArticleID)
+	}
+	if req.Keyword != "" {
+		query = query.Where("c.content LIKE ?", "%"+req.Keyword+"%")
+	}
+	if req.Status == model.CommentStatusPending {
+		query = query.Order("c.create_time ASC, c.id ASC")
+	} else {
+		query = query.Order("c.moderate_time DESC, c.id DESC")
+	}
+
+	total, err := paginate(query, page, pageSize, &comments)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// TransitCommentStatus 变更评论状态
+// 通过在更新条件中限定当前状态，保证并发审核下状态流转的正确性
+func (repo *CommentRepositoryImpl) TransitCommentStatus(ctx context.Context, commentID int, fromStatus []string, updateFields map[string]interface{}) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("id = ? AND is_deleted = ?", commentID, utils.DeletedFlagNo).
+		Where("status IN (?)", fromStatus).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新评论状态失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict, "评论状态已变更，请刷新页面后重试")
+	}
+
+	return nil
+}
+
+// UpdateComment 更新评论
+func (repo *CommentRepositoryImpl) UpdateComment(ctx context.Context, commentID int, updateFields map[string]interface{}) error {
+	result := repo.db.WithContext(ctx).
+		Model(&model.Comment{}).
+		Where("id = ? AND is_deleted = ?", commentID, utils.DeletedFlagNo).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新评论失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "评论不存在或已被删除，请刷新页面后重试")
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/comment/dto"
+	"news-release/internal/comment/model"
+	"news-release/internal/comment/repository"
+	"news-release/internal/config"
+	"news-release/internal/utils"
+	"strings"
+	"time"
+
+	articlemodel "news-release/internal/article/model"
+	articlerepo "news-release/internal/article/repository"
+)
+
+const (
+	defaultRateLimitCount  = 5           // 默认时间窗口内最多发表的评论数
+	defaultRateLimitWindow = time.Minute // 默认发表频率限制的时间窗口
+	latestReplyLimit       = 3           // 顶层评论列表中附带的最新回复数
+)
+
+// CommentService 评论服务接口
+type CommentService interface {
+	// ListArticleComments 分页查询文章下的顶层评论，附带回复数和最新的几条回复
+	ListArticleComments(ctx context.Context, articleID int, page, pageSize int) ([]dto.CommentResponse, int64, error)
+	// ListReplies 分页查询顶层评论下的回复
+	ListReplies(ctx context.Context, rootID int, page, pageSize int) ([]dto.CommentResponse, int64, error)
+	// CreateComment 发表评论或回复
+	CreateComment(ctx context.Context, req dto.CreateCommentRequest, userID int) (*dto.CreateCommentResponse, error)
+	// DeleteComment 删除本人发表的评论
+	DeleteComment(ctx context.Context, commentID int, userID int) error
+	// ListModeration 分页查询审核队列
+	ListModeration(ctx context.Context, page, pageSize int, req dto.ModerationListRequest) ([]dto.ModerationCommentResponse, int64, error)
+	// ApproveComment 审核通过评论
+	ApproveComment(ctx context.Context, commentID int, userID int) error
+	// RejectComment 驳回待审核的评论
+	RejectComment(ctx context.Context, commentID int, reason string, userID int) error
+	// HideComment 隐藏已通过的评论
+	HideComment(ctx context.Context, commentID int, reason string, userID int) error
+}
+
+// CommentServiceImpl 实现接口的具体结构体
+type CommentServiceImpl struct {
+	commentRepo     repository.CommentRepository
+	articleRepo     articlerepo.ArticleRepository
+	filter          *SensitiveFilter
+	rateLimitCount  int
+	rateLimitWindow time.Duration
+}
+
+// NewCommentService 创建服务实例
+func NewCommentService(commentRepo repository.CommentRepository, articleRepo articlerepo.ArticleRepository, cfg *config.Config) CommentService {
+	svc := &CommentServiceImpl{
+		commentRepo:     commentRepo,
+		articleRepo:     articleRepo,
+		filter:          NewSensitiveFilter(cfg.Comment.SensitiveWords),
+		rateLimitCount:  cfg.Comment.RateLimitCount,
+		rateLimitWindow: cfg.Comment.RateLimitWindow,
+	}
+	if svc.rateLimitCount <= 0 {
+		svc.rateLimitCount = defaultRateLimitCount
+	}
+	if svc.rateLimitWindow <= 0 {
+		svc.rateLimitWindow = defaultRateLimitWindow
+	}
+	return svc
+}
+
+// ListArticleComments 分页查询文章下的顶层评论，附带回复数和最新的几条回复
+func (svc *CommentServiceImpl) ListArticleComments(ctx context.Context, articleID int, page, pageSize int) ([]dto.CommentResponse, int64, error) {
+	comments, total, err := svc.commentRepo.ListRootComments(ctx, articleID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rootIDs := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		rootIDs = append(rootIDs, comment.ID)
+	}
+	replyCounts, err := svc.commentRepo.CountReplies(ctx, rootIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	latestReplies, err := svc.commentRepo.ListLatestReplies(ctx, rootIDs, latestReplyLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range comments {
+		comments[i].ReplyCount = replyCounts[comments[i].ID]
+		comments[i].Replies = latestReplies[comments[i].ID]
+		if comments[i].Replies == nil {
+			comments[i].Replies = make([]dto.CommentResponse, 0)
+		}
+	}
+
+	return comments, total, nil
+}
+
+// ListReplies 分页查询顶层评论下的回复
+func (svc *CommentServiceImpl) ListReplies(ctx context.Context, rootID int, page, pageSize int) ([]dto.CommentResponse, int64, error) {
+	root, err := svc.commentRepo.GetComment(ctx, rootID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if root.RootID != 0 || root.Status != model.CommentStatusApproved {
+		return nil, 0, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "评论不存在或已被删除，请刷新页面后重试")
+	}
+
+	return svc.commentRepo.ListReplies(ctx, rootID, page, pageSize)
+}
+
+// checkArticle 检查文章是否已发布，未发布的文章不能评论
+func (svc *CommentServiceImpl) checkArticle(ctx context.Context, articleID int) error {
+	article, err := svc.articleRepo.GetArticleContent(ctx, articleID)
+	if err != nil {
+		return err
+	}
+	if article.IsDeleted == utils.DeletedFlagYes || article.Status != articlemodel.ArticleStatusPublished || article.ReleaseTime.After(time.Now()) {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "文章不存在或已被删除，请刷新页面后重试")
+	}
+	return nil
+}
+
+// checkRateLimit 检查用户在时间窗口内发表的评论数是否超限
+func (svc *CommentServiceImpl) checkRateLimit(ctx context.Context, userID int) error {
+	count, err := svc.commentRepo.CountUserCommentsSince(ctx, userID, time.Now().Add(-svc.rateLimitWindow))
+	if err != nil {
+		return err
+	}
+	if count >= int64(svc.rateLimitCount) {
+		return utils.NewBusinessError(utils.ErrCodeRateLimitExceeded, "评论过于频繁，请稍后再试")
+	}
+	return nil
+}
+
+// CreateComment 发表评论或回复
+// 命中敏感词的评论进入待审核状态，审核通过后才会公开展示；其余评论直接通过
+func (svc *CommentServiceImpl) CreateComment(ctx context.Context, req dto.CreateCommentRequest, userID int) (*dto.CreateCommentResponse, error) {
+	if err := svc.checkArticle(ctx, req.ArticleID); err != nil {
+		return nil, err
+	}
+	if err := svc.checkRateLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{
+		ArticleID:  req.ArticleID,
+		Content:    strings.TrimSpace(req.Content),
+		Status:     model.CommentStatusApproved,
+		IsDeleted:  utils.DeletedFlagNo,
+		CreateUser: userID,
+		UpdateUser: userID,
+	}
+
+	// 回复评论时，被回复的评论必须属于同一文章且已公开展示
+	if req.ParentID != 0 {
+		parent, err := svc.commentRepo.GetComment(ctx, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ArticleID != req.ArticleID {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "被回复的评论不属于该文章")
+		}
+		if parent.Status != model.CommentStatusApproved {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "评论不存在或已被删除，请刷新页面后重试")
+		}
+
+		comment.ParentID = parent.ID
+		comment.RootID = parent.RootID
+		if comment.RootID == 0 {
+			comment.RootID = parent.ID
+		}
+		comment.ReplyToUser = parent.CreateUser
+	}
+
+	if hits := svc.filter.Match(comment.Content); len(hits) > 0 {
+		comment.Status = model.CommentStatusPending
+		comment.HitWords = strings.Join(hits, ",")
+	}
+
+	if err := svc.commentRepo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateCommentResponse{ID: comment.ID, Status: comment.Status}, nil
+}
+
+// DeleteComment 软删除本人发表的评论，顶层评论删除后其回复随之不再展示
+func (svc *CommentServiceImpl) DeleteComment(ctx context.Context, commentID int, userID int) error {
+	comment, err := svc.commentRepo.GetComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.CreateUser != userID {
+		return utils.NewBusinessError(utils.ErrCodePermissionDenied, "只能删除本人发表的评论")
+	}
+
+	updateFields := map[string]interface{}{
+		"is_deleted":  utils.DeletedFlagYes,
+		"update_user": userID,
+	}
+	return svc.commentRepo.UpdateComment(ctx, commentID, updateFields)
+}
+
+// ListModeration 分页查询审核队列，未指定状态时查询待审核的评论
+func (svc *CommentServiceImpl) ListModeration(ctx context.Context, page, pageSize int, req dto.ModerationListRequest) ([]dto.ModerationCommentResponse, int64, error) {
+	if req.Status == "" {
+		req.Status = model.CommentStatusPending
+	}
+	req.Keyword = strings.TrimSpace(req.Keyword)
+	return svc.commentRepo.ListModeration(ctx, page, pageSize, req)
+}
+
+// moderate 变更评论审核状态并记录审核人、审核时间和原因
+func (svc *CommentServiceImpl) moderate(ctx context.Context, commentID int, fromStatus []string, toStatus string, reason string, userID int) error {
+	updateFields := map[string]interface{}{
+		"status":            toStatus,
+		"moderation_reason": strings.TrimSpace(reason),
+		"moderate_user":     userID,
+		"moderate_time":     time.Now(),
+		"update_user":       userID,
+	}
+	if err := svc.commentRepo.TransitCommentStatus(ctx, commentID, fromStatus, updateFields); err != nil {
+		if bizErr, ok := utils.GetBusinessError(err); ok && bizErr.Code == utils.ErrCodeResourceConflict {
+			// 区分评论不存在与状态不允许变更
+			if _, getErr := svc.commentRepo.GetComment(ctx, commentID); getErr != nil {
+				return getErr
+			}
+			return utils.NewBusinessError(utils.ErrCodeResourceConflict, fmt.Sprintf("当前评论状态不允许变更为%s", toStatus))
+		}
+		return err
+	}
+	return nil
+}
+
+// ApproveComment 审核通过评论，待审核、已驳回和已隐藏的评论均可重新通过
+func (svc *CommentServiceImpl) ApproveComment(ctx context.Context, commentID int, userID int) error {
+	fromStatus := []string{model.CommentStatusPending, model.CommentStatusRejected, model.CommentStatusHidden}
+	return svc.moderate(ctx, commentID, fromStatus, model.CommentStatusApproved, "", userID)
+}
+
+// RejectComment 驳回待审核的评论
+func (svc *CommentServiceImpl) RejectComment(ctx context.Context, commentID int, reason string, userID int) error {
+	fromStatus := []string{model.CommentStatusPending}
+	return svc.moderate(ctx, commentID, fromStatus, model.CommentStatusRejected, reason, userID)
+}
+
+// HideComment 隐藏已通过的评论
+func (svc *CommentServiceImpl) HideComment(ctx context.Context, commentID int, reason string, userID int) error {
+	fromStatus := []string{model.CommentStatusApproved}
+	return svc.moderate(ctx, commentID, fromStatus, model.CommentStatusHidden, reason, userID)
+}
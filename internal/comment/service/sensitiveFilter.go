@@ -0,0 +1,82 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// trieNode 敏感词前缀树节点
+type trieNode struct {
+	children map[rune]*trieNode
+	word     string // 以当前节点结尾的敏感词，为空表示非结尾节点
+}
+
+// SensitiveFilter 基于前缀树的敏感词过滤器
+// 匹配时忽略大小写，并跳过空白和标点符号，避免通过插入符号绕过过滤
+type SensitiveFilter struct {
+	root *trieNode
+}
+
+// NewSensitiveFilter 根据敏感词列表创建过滤器
+func NewSensitiveFilter(words []string) *SensitiveFilter {
+	filter := &SensitiveFilter{root: &trieNode{children: make(map[rune]*trieNode)}}
+	for _, word := range words {
+		filter.add(word)
+	}
+	return filter
+}
+
+// isSkippable 判断字符是否在匹配时跳过
+func isSkippable(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// add 添加敏感词
+func (f *SensitiveFilter) add(word string) {
+	word = strings.TrimSpace(word)
+	node := f.root
+	for _, r := range strings.ToLower(word) {
+		if isSkippable(r) {
+			continue
+		}
+		child, ok := node.children[r]
+		if !ok {
+			child = &trieNode{children: make(map[rune]*trieNode)}
+			node.children[r] = child
+		}
+		node = child
+	}
+	if node != f.root {
+		node.word = word
+	}
+}
+
+// Match 返回文本中命中的敏感词，按首次出现顺序去重
+func (f *SensitiveFilter) Match(text string) []string {
+	runes := []rune(strings.ToLower(text))
+	seen := make(map[string]bool)
+	var hits []string
+
+	for start := range runes {
+		if isSkippable(runes[start]) {
+			continue
+		}
+		node := f.root
+		for i := start; i < len(runes); i++ {
+			if isSkippable(runes[i]) {
+				continue
+			}
+			next, ok := node.children[runes[i]]
+			if !ok {
+				break
+			}
+			node = next
+			if node.word != "" && !seen[node.word] {
+				seen[node.word] = true
+				hits = append(hits, node.word)
+			}
+		}
+	}
+
+	return hits
+}
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// defaultCheckInEarlyWindow 活动开始前默认可提前签到的时长
+const defaultCheckInEarlyWindow = 2 * time.Hour
+
+// OpenBefore 返回活动开始前可提前签到的时长，未配置时为2小时
+func (c CheckInConfig) OpenBefore() time.Duration {
+	if c.EarlyWindow > 0 {
+		return c.EarlyWindow
+	}
+	return defaultCheckInEarlyWindow
+}
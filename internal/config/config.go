@@ -11,6 +11,15 @@ type Config struct {
 	MinIO    MinIOConfig    `yaml:"minio"`
 	Wechat   WechatConfig   `yaml:"wechat"` // 添加 Wechat 字段
 	JWT      JWTConfig      `yaml:"jwt"`
+	Comment  CommentConfig  `yaml:"comment"`
+	Feed     FeedConfig     `yaml:"feed"`
+	Site     SiteConfig     `yaml:"site"`
+	I18n     I18nConfig     `yaml:"i18n"`
+	Purge    PurgeConfig    `yaml:"purge"`
+	CheckIn  CheckInConfig  `yaml:"check_in"`
+	Payment  PaymentConfig  `yaml:"payment"`
+	Calendar CalendarConfig `yaml:"calendar"`
+	Reminder ReminderConfig `yaml:"reminder"`
 }
 
 // AppConfig 应用配置
@@ -42,6 +51,7 @@ type MinIOConfig struct {
 	SecretAccessKey string `yaml:"secret_access_key"`
 	UseSSL          bool   `yaml:"use_ssl"`
 	BucketName      string `yaml:"bucket_name"`
+	PublicURL       string `yaml:"public_url"` // 对外访问地址，如CDN域名，未配置时使用Endpoint
 }
 
 // WechatConfig 微信配置
@@ -55,3 +65,69 @@ type JWTConfig struct {
 	JwtSecret       string `yaml:"jwt_secret"`
 	ExpirationHours int    `yaml:"expiration_hours"`
 }
+
+// CommentConfig 评论配置，未配置时使用默认值
+type CommentConfig struct {
+	SensitiveWords  []string      `yaml:"sensitive_words"`   // 敏感词列表，命中的评论进入审核队列
+	RateLimitCount  int           `yaml:"rate_limit_count"`  // 时间窗口内单个用户最多可发表的评论数
+	RateLimitWindow time.Duration `yaml:"rate_limit_window"` // 发表频率限制的时间窗口
+}
+
+// FeedConfig 文章订阅源配置，未配置时使用默认值
+type FeedConfig struct {
+	Title       string `yaml:"title"`       // 订阅源标题
+	Description string `yaml:"description"` // 订阅源描述
+	Limit       int    `yaml:"limit"`       // 默认输出的文章数量
+
+	// 以下配置已迁移到 site，仅为兼容旧配置文件保留，site 中未配置时使用
+	SiteURL           string `yaml:"site_url"`            // 已废弃，请使用 site.url
+	ArticleLinkFormat string `yaml:"article_link_format"` // 已废弃，请使用 site.article_link_format
+}
+
+// SiteConfig 站点配置，用于生成订阅源、站点地图中的链接
+type SiteConfig struct {
+	URL               string `yaml:"url"`                 // 站点地址，为空时根据请求的协议和域名生成
+	ArticleLinkFormat string `yaml:"article_link_format"` // 文章链接格式，%s 替换为文章别名或ID，为空时使用文章详情接口地址
+	EventLinkFormat   string `yaml:"event_link_format"`   // 活动链接格式，%s 替换为活动别名或ID，为空时使用活动详情接口地址
+	SitemapPageSize   int    `yaml:"sitemap_page_size"`   // 每个站点地图文件包含的链接数，最大50000
+}
+
+// I18nConfig 多语言配置
+type I18nConfig struct {
+	DefaultLang string `yaml:"default_lang"` // 默认语言，文章、活动、公告的原文使用该语言撰写，未配置时为zh
+}
+
+// PurgeConfig 已删除数据清理配置，未配置保留天数时不清理
+type PurgeConfig struct {
+	RetentionDays int           `yaml:"retention_days"` // 删除后保留的天数，超过后彻底删除数据及关联图片
+	Interval      time.Duration `yaml:"interval"`       // 清理任务的执行间隔，默认24小时
+	BatchSize     int           `yaml:"batch_size"`     // 每批清理的数据条数，默认100
+}
+
+// CheckInConfig 活动签到配置，未配置时使用默认值
+type CheckInConfig struct {
+	Secret      string        `yaml:"secret"`       // 签到码签名密钥，为空时由JWT密钥派生
+	EarlyWindow time.Duration `yaml:"early_window"` // 活动开始前可提前签到的时长，默认2小时
+}
+
+// PaymentConfig 活动报名支付配置，未配置支付渠道时不收取报名费用
+type PaymentConfig struct {
+	Provider       string        `yaml:"provider"`        // 支付渠道，目前支持 fake（本地模拟支付，仅用于开发测试）
+	CallbackSecret string        `yaml:"callback_secret"` // 支付回调签名密钥
+	OrderTimeout   time.Duration `yaml:"order_timeout"`   // 订单支付时限，超时未支付的订单自动关闭并释放名额，默认30分钟
+	RefundBefore   time.Duration `yaml:"refund_before"`   // 活动开始前多久之前取消报名可以退款，默认24小时
+	CheckInterval  time.Duration `yaml:"check_interval"`  // 关闭超时订单和重试退款的执行间隔，默认1分钟
+}
+
+// CalendarConfig 活动日历订阅配置，未配置时使用默认值
+type CalendarConfig struct {
+	Secret string `yaml:"secret"` // 日历订阅链接签名密钥，为空时由JWT密钥派生，修改后已发放的订阅链接全部失效
+}
+
+// ReminderConfig 活动提醒配置，未配置时使用默认值
+type ReminderConfig struct {
+	Disabled           bool            `yaml:"disabled"`            // 是否关闭活动提醒
+	EventBefore        []time.Duration `yaml:"event_before"`        // 活动开始前发送提醒的时间点，默认24小时和1小时
+	RegistrationBefore time.Duration   `yaml:"registration_before"` // 报名截止前多久发送报名即将截止通知，默认24小时，设为负数时不发送
+	CheckInterval      time.Duration   `yaml:"check_interval"`      // 检查待发送提醒的执行间隔，默认1分钟
+}
@@ -0,0 +1,11 @@
+package config
+
+import "news-release/internal/utils"
+
+// Default 返回默认语言，未配置或配置了不支持的语言时为中文
+func (c I18nConfig) Default() string {
+	if utils.IsSupportedLang(c.DefaultLang) {
+		return c.DefaultLang
+	}
+	return utils.LangZh
+}
@@ -27,6 +27,9 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("解析配置文件失败: %w", err)
 	}
 
+	// 兼容旧版本的配置项
+	applyDeprecatedConfig(&config)
+
 	// 验证配置
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("配置验证失败: %w", err)
@@ -52,6 +55,17 @@ func replaceEnvVariables(data []byte) []byte {
 	}))
 }
 
+// applyDeprecatedConfig 将已废弃的配置项迁移到新的位置，新配置项已配置时以新配置为准
+func applyDeprecatedConfig(config *Config) {
+	if config.Site.URL == "" && config.Feed.SiteURL != "" {
+		config.Site.URL = config.Feed.SiteURL
+	}
+	// 旧的文章链接格式使用 %d 替换文章ID，现在替换为文章别名或ID
+	if config.Site.ArticleLinkFormat == "" && config.Feed.ArticleLinkFormat != "" {
+		config.Site.ArticleLinkFormat = strings.ReplaceAll(config.Feed.ArticleLinkFormat, "%d", "%s")
+	}
+}
+
 // validateConfig 验证配置的有效性
 func validateConfig(config *Config) error {
 	// 检查必要的数据库配置
@@ -95,5 +109,10 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("JWT 过期时间必须大于 0")
 	}
 
+	// 检查支付配置
+	if config.Payment.Enabled() && config.Payment.CallbackSecret == "" {
+		return fmt.Errorf("支付回调签名密钥不能为空")
+	}
+
 	return nil
 }
@@ -0,0 +1,14 @@
+package config
+
+import "strings"
+
+// ObjectBaseURL 返回存储对象的规范访问地址前缀，不含存储桶名称
+func (c MinIOConfig) ObjectBaseURL() string {
+	if c.PublicURL != "" {
+		return strings.TrimRight(c.PublicURL, "/")
+	}
+	if c.UseSSL {
+		return "https://" + c.Endpoint
+	}
+	return "http://" + c.Endpoint
+}
@@ -0,0 +1,38 @@
+package config
+
+import "time"
+
+const (
+	defaultOrderTimeout         = 30 * time.Minute // 订单默认支付时限
+	defaultRefundBefore         = 24 * time.Hour   // 默认可退款的截止时间距活动开始的时长
+	defaultPaymentCheckInterval = time.Minute      // 关闭超时订单和重试退款的默认执行间隔
+)
+
+// Enabled 是否启用报名支付
+func (c PaymentConfig) Enabled() bool {
+	return c.Provider != ""
+}
+
+// PayTimeout 返回订单支付时限，未配置时为30分钟
+func (c PaymentConfig) PayTimeout() time.Duration {
+	if c.OrderTimeout > 0 {
+		return c.OrderTimeout
+	}
+	return defaultOrderTimeout
+}
+
+// RefundDeadline 返回可退款的截止时间距活动开始的时长，未配置时为24小时
+func (c PaymentConfig) RefundDeadline() time.Duration {
+	if c.RefundBefore > 0 {
+		return c.RefundBefore
+	}
+	return defaultRefundBefore
+}
+
+// RunInterval 返回关闭超时订单和重试退款的执行间隔，未配置时为1分钟
+func (c PaymentConfig) RunInterval() time.Duration {
+	if c.CheckInterval > 0 {
+		return c.CheckInterval
+	}
+	return defaultPaymentCheckInterval
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// defaultPurgeInterval 清理任务的默认执行间隔
+const defaultPurgeInterval = 24 * time.Hour
+
+// Enabled 是否启用已删除数据清理
+func (c PurgeConfig) Enabled() bool {
+	return c.RetentionDays > 0
+}
+
+// Retention 返回已删除数据的保留时长
+func (c PurgeConfig) Retention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// RunInterval 返回清理任务的执行间隔，未配置时为24小时
+func (c PurgeConfig) RunInterval() time.Duration {
+	if c.Interval > 0 {
+		return c.Interval
+	}
+	return defaultPurgeInterval
+}
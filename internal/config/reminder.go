@@ -0,0 +1,54 @@
+package config
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	defaultRegistrationReminder = 24 * time.Hour // 报名截止前默认发送通知的时长
+	defaultReminderInterval     = time.Minute    // 检查待发送提醒的默认执行间隔
+)
+
+// defaultEventReminders 活动开始前默认发送提醒的时间点
+var defaultEventReminders = []time.Duration{24 * time.Hour, time.Hour}
+
+// Enabled 是否启用活动提醒
+func (c ReminderConfig) Enabled() bool {
+	return !c.Disabled
+}
+
+// EventOffsets 返回活动开始前发送提醒的时间点，按距开始时间从远到近排序，忽略非正数，未配置时为24小时和1小时
+func (c ReminderConfig) EventOffsets() []time.Duration {
+	offsets := make([]time.Duration, 0, len(c.EventBefore))
+	for _, offset := range c.EventBefore {
+		if offset > 0 && !slices.Contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+	if len(c.EventBefore) == 0 {
+		offsets = append(offsets, defaultEventReminders...)
+	}
+	slices.Sort(offsets)
+	slices.Reverse(offsets)
+	return offsets
+}
+
+// RegistrationOffset 返回报名截止前发送通知的时长，未配置时为24小时，为0表示不发送
+func (c ReminderConfig) RegistrationOffset() time.Duration {
+	if c.RegistrationBefore < 0 {
+		return 0
+	}
+	if c.RegistrationBefore > 0 {
+		return c.RegistrationBefore
+	}
+	return defaultRegistrationReminder
+}
+
+// RunInterval 返回检查待发送提醒的执行间隔，未配置时为1分钟
+func (c ReminderConfig) RunInterval() time.Duration {
+	if c.CheckInterval > 0 {
+		return c.CheckInterval
+	}
+	return defaultReminderInterval
+}
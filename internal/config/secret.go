@@ -0,0 +1,22 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// 签名密钥用途，未单独配置密钥时按用途从JWT密钥派生，不同用途的签名不能互相冒用
+const (
+	SigningPurposeCheckIn  = "check-in" // 活动签到码
+	SigningPurposeCalendar = "calendar" // 日历订阅链接
+)
+
+// SigningSecret 返回指定用途的签名密钥，优先使用单独配置的密钥，未配置时使用 HMAC(JWT密钥, 用途) 派生
+func SigningSecret(secret string, jwt JWTConfig, purpose string) []byte {
+	if secret != "" {
+		return []byte(secret)
+	}
+	mac := hmac.New(sha256.New, []byte(jwt.JwtSecret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
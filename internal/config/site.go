@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaseURL 返回站点地址，未配置时使用根据请求生成的地址
+func (c SiteConfig) BaseURL(requestSiteURL string) string {
+	if c.URL != "" {
+		return strings.TrimRight(c.URL, "/")
+	}
+	return strings.TrimRight(requestSiteURL, "/")
+}
+
+// ArticleLink 生成文章链接，优先使用别名
+func (c SiteConfig) ArticleLink(baseURL string, articleID int, slug string) string {
+	if c.ArticleLinkFormat != "" {
+		return fmt.Sprintf(c.ArticleLinkFormat, linkKey(articleID, slug))
+	}
+	return baseURL + "/api/articles/" + linkKey(articleID, slug)
+}
+
+// EventLink 生成活动链接，优先使用别名
+func (c SiteConfig) EventLink(baseURL string, eventID int, slug string) string {
+	if c.EventLinkFormat != "" {
+		return fmt.Sprintf(c.EventLinkFormat, linkKey(eventID, slug))
+	}
+	return baseURL + "/api/event/" + linkKey(eventID, slug)
+}
+
+// linkKey 链接中标识数据的部分，未设置别名时使用数字ID
+func linkKey(id int, slug string) string {
+	if slug != "" {
+		return slug
+	}
+	return strconv.Itoa(id)
+}
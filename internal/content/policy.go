@@ -0,0 +1,156 @@
+package content
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// allowedTags 允许保留的标签及其专属属性，全局属性见 globalAttrs
+var allowedTags = map[string]map[string]bool{
+	"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil, "section": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"blockquote": nil, "pre": nil, "code": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "sub": nil, "sup": nil,
+	"ul": nil, "li": nil,
+	"ol":         {"start": true},
+	"a":          {"href": true, "title": true, "target": true},
+	"img":        {"src": true, "alt": true, "title": true, "width": true, "height": true},
+	"table":      nil,
+	"thead":      nil,
+	"tbody":      nil,
+	"tfoot":      nil,
+	"tr":         nil,
+	"caption":    nil,
+	"th":         {"colspan": true, "rowspan": true, "align": true},
+	"td":         {"colspan": true, "rowspan": true, "align": true},
+	"figure":     nil,
+	"figcaption": nil,
+	"video":      {"src": true, "poster": true, "controls": true, "width": true, "height": true},
+	"audio":      {"src": true, "controls": true},
+	"source":     {"src": true, "type": true},
+}
+
+// globalAttrs 所有允许标签都可使用的属性
+var globalAttrs = map[string]bool{"class": true, "style": true}
+
+// droppedTags 连同内容一起丢弃的标签
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true,
+	"template": true, "head": true, "meta": true, "link": true, "title": true,
+	"frame": true, "frameset": true, "applet": true, "svg": true, "math": true,
+}
+
+// voidTags 没有结束标签的元素
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true, "source": true}
+
+// siblingTags 结束标签可省略的元素，遇到同名开始标签时自动闭合前一个
+var siblingTags = map[string]bool{"li": true, "p": true, "tr": true, "td": true, "th": true}
+
+// blockTags 提取纯文本时需要与前后内容分隔的块级元素
+var blockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "section": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "ul": true, "ol": true, "li": true,
+	"table": true, "tr": true, "th": true, "td": true, "caption": true, "figure": true, "figcaption": true,
+}
+
+// allowedStyles 行内样式中允许的CSS属性
+var allowedStyles = map[string]bool{
+	"color": true, "background-color": true, "text-align": true, "text-indent": true, "text-decoration": true,
+	"font-size": true, "font-weight": true, "font-style": true, "line-height": true, "letter-spacing": true,
+	"vertical-align": true, "width": true, "height": true, "max-width": true,
+	"margin": true, "margin-top": true, "margin-bottom": true, "margin-left": true, "margin-right": true,
+	"padding": true, "padding-top": true, "padding-bottom": true, "padding-left": true, "padding-right": true,
+	"border": true, "border-collapse": true,
+}
+
+var (
+	numberPattern    = regexp.MustCompile(`^[0-9]{1,4}%?$`)
+	dataImagePattern = regexp.MustCompile(`^data:image/(png|jpeg|gif|webp);base64,[a-z0-9+/=\s]+$`)
+)
+
+// unsafeStyleValues 样式值中出现即整条丢弃的片段
+var unsafeStyleValues = []string{"url(", "expression", "javascript:", "\\", "<", "@import", "behavior"}
+
+// sanitizeAttr 校验单个属性，返回清洗后的值及是否保留
+func sanitizeAttr(tag, key, val string) (string, bool) {
+	if !globalAttrs[key] && !allowedTags[tag][key] {
+		return "", false
+	}
+
+	switch key {
+	case "style":
+		style := sanitizeStyle(val)
+		return style, style != ""
+	case "href":
+		return sanitizeURL(val, "http", "https", "mailto", "tel")
+	case "src", "poster":
+		if tag == "img" && dataImagePattern.MatchString(strings.ToLower(strings.TrimSpace(val))) {
+			return strings.TrimSpace(val), true
+		}
+		return sanitizeURL(val, "http", "https")
+	case "target":
+		return "_blank", strings.EqualFold(strings.TrimSpace(val), "_blank")
+	case "width", "height", "colspan", "rowspan", "start":
+		val = strings.TrimSpace(val)
+		return val, numberPattern.MatchString(val)
+	case "align":
+		val = strings.ToLower(strings.TrimSpace(val))
+		return val, val == "left" || val == "center" || val == "right"
+	case "controls":
+		return "controls", true
+	}
+	return val, true
+}
+
+// sanitizeURL 仅保留指定协议或相对路径的链接
+func sanitizeURL(raw string, schemes ...string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.ContainsAny(raw, "\x00\t\r\n") {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	if u.Scheme == "" {
+		return raw, true
+	}
+	scheme := strings.ToLower(u.Scheme)
+	for _, s := range schemes {
+		if scheme == s {
+			return raw, true
+		}
+	}
+	return "", false
+}
+
+// sanitizeStyle 过滤行内样式，仅保留白名单内且值安全的声明
+func sanitizeStyle(style string) string {
+	declarations := make([]string, 0)
+	for _, decl := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if !allowedStyles[name] || value == "" || !safeStyleValue(value) {
+			continue
+		}
+		declarations = append(declarations, name+": "+value)
+	}
+	return strings.Join(declarations, "; ")
+}
+
+// safeStyleValue 检查样式值是否包含可执行脚本或外部资源引用
+func safeStyleValue(value string) bool {
+	lower := strings.ToLower(value)
+	for _, unsafe := range unsafeStyleValues {
+		if strings.Contains(lower, unsafe) {
+			return false
+		}
+	}
+	return true
+}
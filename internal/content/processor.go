@@ -0,0 +1,183 @@
+// Package content 处理富文本内容：白名单过滤、图片地址规范化及纯文本提取
+package content
+
+import (
+	"net/url"
+	"news-release/internal/config"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Result 富文本处理结果
+type Result struct {
+	HTML string // 过滤后的HTML
+	Text string // 提取的纯文本，用于摘要和搜索
+}
+
+// Processor 富文本处理器
+type Processor struct {
+	objectBaseURL string          // 存储对象的规范访问地址前缀
+	bucketPath    string          // 存储桶路径前缀，如 /news/
+	objectHosts   map[string]bool // 视为本站存储的主机名
+}
+
+// NewProcessor 创建富文本处理器
+func NewProcessor(cfg config.MinIOConfig) *Processor {
+	proc := &Processor{
+		objectBaseURL: cfg.ObjectBaseURL(),
+		bucketPath:    "/" + cfg.BucketName + "/",
+		objectHosts:   map[string]bool{strings.ToLower(cfg.Endpoint): true},
+	}
+	if u, err := url.Parse(proc.objectBaseURL); err == nil && u.Host != "" {
+		proc.objectHosts[strings.ToLower(u.Host)] = true
+	}
+	return proc
+}
+
+// Process 按白名单过滤HTML，规范化本站图片地址，并提取纯文本
+func (p *Processor) Process(raw string) Result {
+	var out, text strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(raw))
+	open := make([]string, 0)
+
+	// 丢弃标签内的内容时记录标签名及嵌套深度
+	skipTag, skipDepth := "", 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break // io.EOF 或内容无法继续解析
+		}
+		token := tokenizer.Token()
+
+		if skipDepth > 0 {
+			if token.Data == skipTag {
+				switch tokenType {
+				case html.StartTagToken:
+					skipDepth++
+				case html.EndTagToken:
+					skipDepth--
+				}
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+			text.WriteString(token.Data)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tokenType == html.StartTagToken && token.Data != "meta" && token.Data != "link" {
+					skipTag, skipDepth = token.Data, 1
+				}
+				continue
+			}
+			if blockTags[token.Data] {
+				text.WriteString(" ")
+			}
+			if _, ok := allowedTags[token.Data]; !ok {
+				continue
+			}
+			// 未闭合的同级列表项、段落、单元格在遇到下一个同名标签时自动闭合
+			if n := len(open); n > 0 && open[n-1] == token.Data && siblingTags[token.Data] {
+				out.WriteString("</" + token.Data + ">")
+				open = open[:n-1]
+			}
+			p.writeStartTag(&out, token)
+			if voidTags[token.Data] {
+				continue
+			}
+			if tokenType == html.SelfClosingTagToken {
+				out.WriteString("</" + token.Data + ">")
+				continue
+			}
+			open = append(open, token.Data)
+
+		case html.EndTagToken:
+			if blockTags[token.Data] {
+				text.WriteString(" ")
+			}
+			// 关闭到最近一个同名标签，中间未闭合的标签一并闭合
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != token.Data {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					out.WriteString("</" + open[j] + ">")
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+
+	// 补全未闭合的标签
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+
+	return Result{
+		HTML: out.String(),
+		Text: strings.Join(strings.Fields(text.String()), " "),
+	}
+}
+
+// writeStartTag 输出过滤属性后的开始标签
+func (p *Processor) writeStartTag(out *strings.Builder, token html.Token) {
+	out.WriteString("<" + token.Data)
+	seen := make(map[string]bool)
+	for _, attr := range token.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || seen[key] {
+			continue
+		}
+		val, ok := sanitizeAttr(token.Data, key, attr.Val)
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		if token.Data == "img" && key == "src" {
+			val = p.CanonicalObjectURL(val)
+		}
+		out.WriteString(" " + key + `="` + html.EscapeString(val) + `"`)
+	}
+	// 新窗口打开的链接禁止访问 window.opener
+	if token.Data == "a" && seen["target"] {
+		out.WriteString(` rel="noopener noreferrer"`)
+	}
+	out.WriteString(">")
+}
+
+// CanonicalObjectURL 将指向本站存储桶的地址统一为规范地址，去除预签名等查询参数；其他地址原样返回
+func (p *Processor) CanonicalObjectURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https") {
+		return raw
+	}
+	if u.Host != "" && !p.objectHosts[strings.ToLower(u.Host)] {
+		return raw
+	}
+	if !strings.HasPrefix(u.Path, p.bucketPath) || len(u.Path) == len(p.bucketPath) {
+		return raw
+	}
+	return p.objectBaseURL + u.EscapedPath()
+}
+
+// Summary 从纯文本截取摘要，尽量在句末断开，超出长度时追加省略号
+func Summary(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	runes := []rune(text)[:maxLength]
+	for i := len(runes) - 1; i >= maxLength/2; i-- {
+		switch runes[i] {
+		case '。', '！', '？', '；', '.', '!', '?', ';':
+			return string(runes[:i+1])
+		}
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
@@ -1,23 +1,43 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
+	"news-release/internal/content"
 	"news-release/internal/event/dto"
 	"news-release/internal/event/model"
 	"news-release/internal/event/service"
+	translationmodel "news-release/internal/translation/model"
+	translationsvc "news-release/internal/translation/service"
 	"news-release/internal/utils"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 )
 
+// translatedMetaMaxLength 使用译文时SEO描述的最大字数
+const translatedMetaMaxLength = 300
+
+// regExportContentTypes 各报名用户导出格式的响应类型
+var regExportContentTypes = map[string]string{
+	dto.RegExportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	dto.RegExportFormatCSV:  "text/csv; charset=utf-8",
+}
+
+// calendarContentType iCalendar 文件的响应类型
+const calendarContentType = "text/calendar; charset=utf-8"
+
 // EventController 定义事件控制器，处理与事件相关的 HTTP 请求
 type EventController struct {
-	eventService service.EventService // 事件服务接口
+	eventService       service.EventService              // 事件服务接口
+	translationService translationsvc.TranslationService // 译文服务接口
 }
 
 // NewEventController 创建事件控制器实例
-func NewEventController(eventService service.EventService) *EventController {
-	return &EventController{eventService: eventService}
+func NewEventController(eventService service.EventService, translationService translationsvc.TranslationService) *EventController {
+	return &EventController{eventService: eventService, translationService: translationService}
 }
 
 // ListEvent 处理分页查询事件列表的请求
@@ -28,54 +48,73 @@ func (ctr *EventController) ListEvent(ctx *gin.Context) {
 		return
 	}
 
-	// page 默认1
-	page := req.Page
-	if page == 0 {
-		page = 1
-	}
-
-	// pageSize 默认10
-	pageSize := req.PageSize
-	if pageSize == 0 {
-		pageSize = 10
+	// 页码分页与游标分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
 	// 调用服务层
-	result, total, err := ctr.eventService.ListEvent(ctx, page, pageSize, req.EventStatus, req.QueryScope)
+	result, err := ctr.eventService.ListEvent(ctx, pg, req.EventStatus, req.RegistrationStatus, req.QueryScope)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      result,
-	})
+	// 替换为请求语言的译文
+	eventIDs := make([]int, 0, len(result))
+	for _, ev := range result {
+		eventIDs = append(eventIDs, ev.ID)
+	}
+	translations, err := ctr.localize(ctx, eventIDs)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	for _, ev := range result {
+		ev.Lang = ctr.translationService.DefaultLang()
+		if translation, ok := translations[ev.ID]; ok {
+			ev.Title = translation.Title
+			ev.Lang = translation.Lang
+		}
+	}
+
+	ctx.JSON(http.StatusOK, pg.Response(result))
 }
 
 // GetEventDetail 处理获取活动详情的请求
 func (ctr *EventController) GetEventDetail(ctx *gin.Context) {
 	// 初始化参数结构体并绑定查询参数
-	var req dto.EventDetailRequest
+	var req dto.EventKeyRequest
 	if !utils.BindUrl(ctx, &req) {
 		return
 	}
 
-	// 调用服务层获取活动详情
-	event, err := ctr.eventService.GetEventDetail(ctx, req.EventID)
+	// 调用服务层获取活动详情，别名不允许为纯数字，因此纯数字按ID查询
+	var event *model.Event
+	var err error
+	if eventID, convErr := strconv.Atoi(req.Key); convErr == nil {
+		event, err = ctr.eventService.GetEventDetail(ctx, eventID)
+	} else {
+		event, err = ctr.eventService.GetEventDetailBySlug(ctx, req.Key)
+	}
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	// 获取活动状态
-	status := ctr.eventService.GetEventStatus(event.RegistrationStartTime, event.RegistrationEndTime)
+	// 获取活动状态和报名状态
+	eventStatus, registrationStatus, err := ctr.eventService.GetEventStatus(ctx, event)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
 
 	res := dto.EventDetailResponse{
+		ID:                    event.ID,
 		Title:                 event.Title,
 		Detail:                event.Detail,
 		EventStartTime:        event.EventStartTime,
@@ -84,9 +123,32 @@ func (ctr *EventController) GetEventDetail(ctx *gin.Context) {
 		RegistrationEndTime:   event.RegistrationEndTime,
 		EventAddress:          event.EventAddress,
 		RegistrationFee:       event.RegistrationFee,
-		Status:                status,
+		MaxParticipants:       event.MaxParticipants,
+		RequireApproval:       event.RequireApproval,
+		RegistrationForm:      event.RegistrationForm,
+		EventStatus:           eventStatus,
+		RegistrationStatus:    registrationStatus,
+		CancelReason:          event.CancelReason,
 		CoverImageURL:         event.CoverImageURL,
 		Images:                event.Images,
+		Slug:                  event.Slug,
+		MetaDescription:       event.MetaDescription,
+		OgImageURL:            event.OgImageURL,
+		SeriesID:              event.SeriesID,
+		Lang:                  ctr.translationService.DefaultLang(),
+	}
+
+	// 替换为请求语言的译文，原文的SEO描述为原文语言，使用译文时改取译文详情的纯文本
+	translations, err := ctr.localize(ctx, []int{event.ID})
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	if translation, ok := translations[event.ID]; ok {
+		res.Title = translation.Title
+		res.Detail = translation.Content
+		res.MetaDescription = content.Summary(utils.PlainText(translation.Content), translatedMetaMaxLength)
+		res.Lang = translation.Lang
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
@@ -111,16 +173,25 @@ func (ctr *EventController) RegistrationEvent(ctx *gin.Context) {
 	}
 
 	// 调用服务层进行活动报名
-	err = ctr.eventService.RegistrationEvent(ctx, req.EventID, userID)
+	res, err := ctr.eventService.RegistrationEvent(ctx, req.EventID, userID, req.Answers)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
+	message := "活动报名成功"
+	switch res.Status {
+	case model.RegistrationStatusWaitlisted:
+		message = fmt.Sprintf("活动人数已满，已加入候补名单，当前排在第%d位", res.WaitlistPosition)
+	case model.RegistrationStatusPending:
+		message = "报名已提交，请等待审核"
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"code":    200,
-		"message": "活动报名成功",
+		"message": message,
+		"data":    res,
 	})
 }
 
@@ -140,8 +211,8 @@ func (ctr *EventController) IsUserRegistered(ctx *gin.Context) {
 		return
 	}
 
-	// 调用服务层查询用户是否报名该活动
-	isRegistered, err := ctr.eventService.IsUserRegistered(ctx, req.EventID, userID)
+	// 调用服务层查询用户的报名状态
+	res, err := ctr.eventService.GetRegistrationStatus(ctx, req.EventID, userID)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
@@ -149,10 +220,23 @@ func (ctr *EventController) IsUserRegistered(ctx *gin.Context) {
 	}
 
 	var flag, message string
-	if isRegistered {
+	switch res.Status {
+	case model.RegistrationStatusRegistered:
 		flag = utils.FlagYes
 		message = "已报名"
-	} else {
+	case model.RegistrationStatusWaitlisted:
+		flag = utils.FlagNo
+		message = fmt.Sprintf("候补中，当前排在第%d位", res.WaitlistPosition)
+	case model.RegistrationStatusPending:
+		flag = utils.FlagNo
+		message = "审核中"
+	case model.RegistrationStatusRejected:
+		flag = utils.FlagNo
+		message = "审核未通过"
+	case model.RegistrationStatusCancelled:
+		flag = utils.FlagNo
+		message = "活动已取消"
+	default:
 		flag = utils.FlagNo
 		message = "未报名"
 	}
@@ -160,8 +244,11 @@ func (ctr *EventController) IsUserRegistered(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{
 		"code": 200,
 		"data": gin.H{
-			"is_registered": flag,
-			"message":       message,
+			"is_registered":     flag,
+			"status":            res.Status,
+			"waitlist_position": res.WaitlistPosition,
+			"review_reason":     res.ReviewReason,
+			"message":           message,
 		},
 	})
 }
@@ -225,33 +312,37 @@ func (ctr *EventController) ListUserRegisteredEvents(ctx *gin.Context) {
 	}
 
 	// 调用服务层获取用户已报名的活动列表
-	events, total, err := ctr.eventService.ListUserRegisteredEvents(ctx, req.Page, req.PageSize, userID, req.EventStatus)
+	events, total, err := ctr.eventService.ListUserRegisteredEvents(ctx, req.Page, req.PageSize, userID, req.EventStatus, req.RegistrationStatus)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	var result []dto.EventListResponse
+	// 替换为请求语言的译文
+	eventIDs := make([]int, 0, len(events))
 	for _, ev := range events {
-		result = append(result, dto.EventListResponse{
-			ID:                    ev.ID,
-			Title:                 ev.Title,
-			EventStartTime:        ev.EventStartTime,
-			EventEndTime:          ev.EventEndTime,
-			RegistrationStartTime: ev.RegistrationStartTime,
-			RegistrationEndTime:   ev.RegistrationEndTime,
-			EventAddress:          ev.EventAddress,
-			RegistrationFee:       ev.RegistrationFee,
-			CoverImageURL:         ev.CoverImageURL,
-		})
+		eventIDs = append(eventIDs, ev.ID)
+	}
+	translations, err := ctr.localize(ctx, eventIDs)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	for _, item := range events {
+		item.Lang = ctr.translationService.DefaultLang()
+		if translation, ok := translations[item.ID]; ok {
+			item.Title = translation.Title
+			item.Lang = translation.Lang
+		}
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"total":     total,
 		"page":      page,
 		"page_size": pageSize,
-		"data":      result,
+		"data":      events,
 	})
 }
 
@@ -302,7 +393,13 @@ func (ctr *EventController) CreateEvent(ctx *gin.Context) {
 		RegistrationEndTime:   registrationEndTime,
 		EventAddress:          req.EventAddress,
 		RegistrationFee:       req.RegistrationFee,
+		MaxParticipants:       req.MaxParticipants,
+		RequireApproval:       req.RequireApproval,
+		RegistrationForm:      req.RegistrationForm,
 		CoverImageURL:         req.CoverImageURL,
+		Slug:                  req.Slug,
+		MetaDescription:       req.MetaDescription,
+		OgImageURL:            req.OgImageURL,
 		CreateUser:            userID,
 		UpdateUser:            userID,
 	}
@@ -387,6 +484,69 @@ func (ctr *EventController) DeleteEvent(ctx *gin.Context) {
 	})
 }
 
+// CancelEvent 取消活动
+func (ctr *EventController) CancelEvent(ctx *gin.Context) {
+	// 获取活动ID
+	var urlReq dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	// 初始化参数结构体并绑定请求体
+	var req dto.CancelEventRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层取消活动
+	err = ctr.eventService.CancelEvent(ctx, urlReq.EventID, req.Reason, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动已取消",
+	})
+}
+
+// RestoreEvent 处理恢复活动的请求
+func (ctr *EventController) RestoreEvent(ctx *gin.Context) {
+	// 获取活动ID
+	var req dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层恢复活动
+	err = ctr.eventService.RestoreEvent(ctx, req.EventID, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动恢复成功",
+	})
+}
+
 // ListEventRegisteredUsers 获取活动报名用户列表
 func (ctr *EventController) ListEventRegisteredUsers(ctx *gin.Context) {
 	// 获取活动ID
@@ -394,15 +554,26 @@ func (ctr *EventController) ListEventRegisteredUsers(ctx *gin.Context) {
 	if !utils.BindUrl(ctx, &req) {
 		return
 	}
+	// 绑定分页和报名状态参数
+	var query dto.ListEventRegUserRequest
+	if !utils.BindQuery(ctx, &query) {
+		return
+	}
 
 	// page 默认1
-	page := 1
+	page := query.Page
+	if page == 0 {
+		page = 1
+	}
 
 	// pageSize 默认10
-	pageSize := 10
+	pageSize := query.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
 
 	// 调用服务层获取活动报名用户列表
-	users, total, err := ctr.eventService.ListEventRegisteredUser(ctx, page, pageSize, req.EventID)
+	users, total, err := ctr.eventService.ListEventRegisteredUser(ctx, page, pageSize, req.EventID, query.Status)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
@@ -416,3 +587,236 @@ func (ctr *EventController) ListEventRegisteredUsers(ctx *gin.Context) {
 		"data":      users,
 	})
 }
+
+// ExportEventRegisteredUsers 导出活动报名用户，以附件形式下载
+func (ctr *EventController) ExportEventRegisteredUsers(ctx *gin.Context) {
+	// 获取活动ID
+	var uri dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &uri) {
+		return
+	}
+	// 绑定导出格式和报名状态参数
+	var req dto.ExportRegUserRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	fileName := fmt.Sprintf("event-%d-registrants-%s.%s", uri.EventID, time.Now().Format("20060102150405"), req.Format)
+	ctx.Header("Content-Type", regExportContentTypes[req.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	if err := ctr.eventService.ExportEventRegisteredUsers(ctx, ctx.Writer, uri.EventID, req); err != nil {
+		// 尚未输出内容时返回错误信息，否则只能中断下载并记录日志
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+			utils.WrapErrorHandler(ctx, err)
+			return
+		}
+		logrus.Errorf("导出活动报名用户失败: %v", err)
+		_ = ctx.Error(err)
+	}
+}
+
+// ReviewRegistrations 处理批量审核活动报名的请求
+func (ctr *EventController) ReviewRegistrations(ctx *gin.Context) {
+	// 获取活动ID
+	var uri dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &uri) {
+		return
+	}
+	// 初始化参数结构体并绑定请求体
+	var req dto.ReviewRegistrationRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层审核报名
+	res, err := ctr.eventService.ReviewRegistrations(ctx, uri.EventID, req, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "审核完成",
+		"data":    res,
+	})
+}
+
+// CheckIn 处理工作人员扫码签到的请求
+func (ctr *EventController) CheckIn(ctx *gin.Context) {
+	// 初始化参数结构体并绑定请求体
+	var req dto.CheckInRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层签到
+	res, err := ctr.eventService.CheckIn(ctx, req.Token, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "签到成功",
+		"data":    res,
+	})
+}
+
+// GetCheckInQRCode 获取用户的活动签到二维码图片，活动现场由工作人员扫码签到
+func (ctr *EventController) GetCheckInQRCode(ctx *gin.Context) {
+	// 获取活动ID
+	var req dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	png, err := ctr.eventService.GetCheckInQRCode(ctx, req.EventID, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// GetAttendance 获取活动签到统计和未签到用户列表
+func (ctr *EventController) GetAttendance(ctx *gin.Context) {
+	// 获取活动ID
+	var uri dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &uri) {
+		return
+	}
+	// 绑定分页参数
+	var req dto.AttendanceRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 调用服务层统计签到人数
+	stats, err := ctr.eventService.GetAttendance(ctx, uri.EventID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层获取未签到用户列表
+	users, total, err := ctr.eventService.ListNoShowUsers(ctx, page, pageSize, uri.EventID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":      stats,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"no_shows":  users,
+	})
+}
+
+// ExportEventCalendar 以iCalendar文件下载单个活动，供用户添加到手机日历
+func (ctr *EventController) ExportEventCalendar(ctx *gin.Context) {
+	// 获取活动ID
+	var req dto.EventDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 调用服务层生成日历文件
+	body, err := ctr.eventService.GetEventCalendar(ctx, req.EventID, utils.RequestSiteURL(ctx))
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%d.ics"`, req.EventID))
+	ctx.Data(http.StatusOK, calendarContentType, body)
+}
+
+// GetCalendarSubscription 获取当前用户的日历订阅链接
+func (ctr *EventController) GetCalendarSubscription(ctx *gin.Context) {
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": ctr.eventService.GetCalendarSubscription(userID, utils.RequestSiteURL(ctx)),
+	})
+}
+
+// SubscribeCalendar 输出订阅令牌对应用户已报名活动的日历，由日历客户端定期拉取，无需认证
+func (ctr *EventController) SubscribeCalendar(ctx *gin.Context) {
+	// 获取订阅令牌
+	var req dto.CalendarTokenRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 调用服务层生成日历
+	body, err := ctr.eventService.GetUserCalendar(ctx, req.Token, utils.RequestSiteURL(ctx))
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, calendarContentType, body)
+}
+
+// localize 批量查询活动在请求语言下的译文，缺少译文的活动不在结果中
+func (ctr *EventController) localize(ctx *gin.Context, eventIDs []int) (map[int]*translationmodel.Translation, error) {
+	return ctr.translationService.Localize(ctx, utils.TypeEvent, utils.GetLang(ctx), eventIDs)
+}
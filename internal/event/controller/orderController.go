@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/service"
+	"news-release/internal/payment"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// callbackMaxBodySize 支付回调请求体的最大字节数
+const callbackMaxBodySize = 1 << 20
+
+// OrderController 活动订单控制器，处理订单查询、支付和支付回调请求
+type OrderController struct {
+	eventService service.EventService  // 事件服务接口
+	fakeProvider *payment.FakeProvider // 模拟支付渠道，未使用模拟支付时为nil
+}
+
+// NewOrderController 创建活动订单控制器实例
+func NewOrderController(eventService service.EventService, payProvider payment.Provider) *OrderController {
+	fakeProvider, _ := payProvider.(*payment.FakeProvider)
+	return &OrderController{eventService: eventService, fakeProvider: fakeProvider}
+}
+
+// ListUserOrders 分页查询当前用户的订单
+func (ctr *OrderController) ListUserOrders(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.OrderListRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层获取订单列表
+	orders, total, err := ctr.eventService.ListUserOrders(ctx, page, pageSize, userID, req.Status)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      orders,
+	})
+}
+
+// PayOrder 为待支付的订单发起支付
+func (ctr *OrderController) PayOrder(ctx *gin.Context) {
+	// 获取订单号
+	var req dto.OrderNoRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层发起支付
+	res, err := ctr.eventService.PayOrder(ctx, req.OrderNo, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "发起支付成功",
+		"data":    res,
+	})
+}
+
+// PaymentCallback 处理支付渠道的支付结果通知，处理失败时返回非200状态码，由支付渠道重试
+func (ctr *OrderController) PaymentCallback(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, callbackMaxBodySize))
+	if err != nil {
+		utils.WrapErrorHandler(ctx, utils.NewBusinessError(utils.ErrCodeParamInvalid, "读取支付回调失败"))
+		return
+	}
+
+	if err := ctr.eventService.HandlePaymentCallback(ctx, ctx.Param("provider"), ctx.Request.Header, body); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "成功",
+	})
+}
+
+// FakePay 模拟用户完成支付，由模拟支付渠道构造已签名的支付回调，仅用于开发和测试
+func (ctr *OrderController) FakePay(ctx *gin.Context) {
+	// 获取订单号
+	var req dto.OrderNoRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	order, err := ctr.eventService.GetUserOrder(ctx, req.OrderNo, userID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	header, body, err := ctr.fakeProvider.BuildCallback(order.OrderNo, order.Amount)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, utils.NewSystemError(fmt.Errorf("构造模拟支付回调失败: %w", err)))
+		return
+	}
+	if err := ctr.eventService.HandlePaymentCallback(ctx, ctr.fakeProvider.Name(), header, body); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "模拟支付成功",
+	})
+}
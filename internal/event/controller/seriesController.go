@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesController 活动系列控制器，处理重复活动的创建、维护和整个系列的报名请求
+type SeriesController struct {
+	eventService service.EventService // 事件服务接口
+}
+
+// NewSeriesController 创建活动系列控制器实例
+func NewSeriesController(eventService service.EventService) *SeriesController {
+	return &SeriesController{eventService: eventService}
+}
+
+// GetSeriesDetail 获取活动系列详情和场次列表
+func (ctr *SeriesController) GetSeriesDetail(ctx *gin.Context) {
+	// 获取活动系列ID
+	var req dto.SeriesDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 调用服务层获取活动系列详情
+	series, err := ctr.eventService.GetSeriesDetail(ctx, req.SeriesID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": series,
+	})
+}
+
+// ListSeries 分页查询活动系列
+func (ctr *SeriesController) ListSeries(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.SeriesListRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 调用服务层获取活动系列列表
+	series, total, err := ctr.eventService.ListSeries(ctx, page, pageSize)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      series,
+	})
+}
+
+// CreateSeries 创建活动系列
+func (ctr *SeriesController) CreateSeries(ctx *gin.Context) {
+	// 初始化参数结构体并绑定请求体
+	var req dto.CreateSeriesRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层创建活动系列
+	series, err := ctr.eventService.CreateSeries(ctx, req, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动系列创建成功",
+		"data": gin.H{
+			"series_id": series.ID,
+		},
+	})
+}
+
+// UpdateSeries 更新活动系列
+func (ctr *SeriesController) UpdateSeries(ctx *gin.Context) {
+	// 获取活动系列ID
+	var urlReq dto.SeriesDetailRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	// 初始化参数结构体并绑定请求体
+	var req dto.UpdateSeriesRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层更新活动系列
+	res, err := ctr.eventService.UpdateSeries(ctx, urlReq.SeriesID, req, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动系列更新成功",
+		"data":    res,
+	})
+}
+
+// DeleteSeries 删除活动系列
+func (ctr *SeriesController) DeleteSeries(ctx *gin.Context) {
+	// 获取活动系列ID
+	var req dto.SeriesDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层删除活动系列
+	err = ctr.eventService.DeleteSeries(ctx, req.SeriesID, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动系列删除成功",
+	})
+}
+
+// RegisterSeries 报名整个活动系列
+func (ctr *SeriesController) RegisterSeries(ctx *gin.Context) {
+	// 初始化参数结构体并绑定请求体
+	var req dto.SeriesRegistrationRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层报名活动系列
+	results, err := ctr.eventService.RegisterSeries(ctx, req.SeriesID, userID, req.Answers)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动系列报名已处理，请查看各场次的报名结果",
+		"data":    results,
+	})
+}
+
+// CancelSeriesRegistration 取消整个活动系列的报名
+func (ctr *SeriesController) CancelSeriesRegistration(ctx *gin.Context) {
+	// 获取活动系列ID
+	var req dto.SeriesDetailRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	// 获取userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 调用服务层取消活动系列报名
+	results, err := ctr.eventService.CancelSeriesRegistration(ctx, req.SeriesID, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "活动系列取消报名已处理，请查看各场次的处理结果",
+		"data":    results,
+	})
+}
@@ -4,10 +4,24 @@ import "time"
 
 // EventListRequest 活动列表查询请求参数
 type EventListRequest struct {
-	Page        int    `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
-	PageSize    int    `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
-	EventStatus string `form:"event_status" binding:"omitempty"`            // 活动状态
-	QueryScope  string `form:"query_scope" binding:"omitempty,query_scope"` // 查询范围，默认只查询未删除数据
+	Page               int    `form:"page" binding:"omitempty,min=1"`                                          // 页码，最小为1
+	PageSize           int    `form:"page_size" binding:"omitempty,min=1,max=100"`                             // 页大小，1-100
+	Cursor             string `form:"cursor" binding:"omitempty,max=200"`                                      // 游标，传入时按游标分页并忽略页码
+	WithTotal          *bool  `form:"with_total"`                                                              // 是否返回总数，页码分页默认返回，游标分页默认不返回
+	EventStatus        string `form:"event_status" binding:"omitempty,oneof=UPCOMING ONGOING ENDED CANCELLED"` // 活动状态
+	RegistrationStatus string `form:"registration_status" binding:"omitempty,oneof=NOT_OPEN OPEN CLOSED FULL"` // 报名状态
+	QueryScope         string `form:"query_scope" binding:"omitempty,query_scope"`                             // 查询范围，默认只查询未删除数据
+}
+
+// StatusLabel 状态编码及显示名称
+type StatusLabel struct {
+	Code  string `json:"code"`  // 状态编码
+	Label string `json:"label"` // 状态显示名称
+}
+
+// CancelEventRequest 取消活动请求参数
+type CancelEventRequest struct {
+	Reason string `json:"reason" binding:"required,non_empty_string,max=255"` // 取消原因，通知已报名的用户
 }
 
 // EventDetailRequest 活动详情查询请求参数
@@ -15,52 +29,165 @@ type EventDetailRequest struct {
 	EventID int `uri:"id" binding:"required,numeric"` // 活动ID，必须为数字
 }
 
+// EventKeyRequest 按活动ID或别名查询活动的请求参数
+type EventKeyRequest struct {
+	Key string `uri:"id" binding:"required,max=200"` // 活动ID或别名
+}
+
 // EventRegistrationRequest 活动报名请求参数
 type EventRegistrationRequest struct {
-	EventID int `json:"event_id" binding:"required,numeric"` // 活动ID
+	EventID int          `json:"event_id" binding:"required,numeric"`     // 活动ID
+	Answers []FormAnswer `json:"answers" binding:"omitempty,max=50,dive"` // 报名表单附加问题的答案
+}
+
+// EventRegistrationResponse 活动报名状态响应结构体
+type EventRegistrationResponse struct {
+	Status           string         `json:"status"`                      // 报名状态，未报名时为空
+	WaitlistPosition int            `json:"waitlist_position,omitempty"` // 候补位置，从1开始，仅候补中时返回
+	ReviewReason     string         `json:"review_reason,omitempty"`     // 审核意见
+	Order            *OrderResponse `json:"order,omitempty"`             // 待支付的订单，仅待支付时返回
+}
+
+// ListEventRegUserRequest 活动报名用户列表查询请求参数
+type ListEventRegUserRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`                                                           // 页码，最小为1
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`                                              // 页大小，1-100
+	Status   string `form:"status" binding:"omitempty,oneof=REGISTERED WAITLISTED PENDING REJECTED UNPAID CANCELLED"` // 报名状态，默认查询已报名用户
+}
+
+// 报名用户导出文件格式
+const (
+	RegExportFormatXLSX = "xlsx"
+	RegExportFormatCSV  = "csv"
+)
+
+// ExportRegUserRequest 导出活动报名用户请求参数
+type ExportRegUserRequest struct {
+	Format string `form:"format" binding:"required,oneof=xlsx csv"`                                                 // 导出格式
+	Status string `form:"status" binding:"omitempty,oneof=REGISTERED WAITLISTED PENDING REJECTED UNPAID CANCELLED"` // 报名状态，默认导出全部报名用户
+}
+
+// ReviewRegistrationRequest 批量审核报名请求参数
+type ReviewRegistrationRequest struct {
+	UserIDs []int  `json:"user_ids" binding:"required,min=1,max=500,dive,min=1"` // 待审核的用户ID列表
+	Action  string `json:"action" binding:"required,oneof=APPROVE REJECT"`       // 审核操作，APPROVE-通过，REJECT-驳回
+	Reason  string `json:"reason" binding:"omitempty,max=255"`                   // 审核意见，驳回时必填
+}
+
+// ReviewRegistrationResponse 批量审核报名结果
+type ReviewRegistrationResponse struct {
+	Approved   []int `json:"approved"`   // 审核通过并正式报名的用户ID
+	Waitlisted []int `json:"waitlisted"` // 审核通过但活动人数已满，进入候补名单的用户ID
+	Rejected   []int `json:"rejected"`   // 被驳回的用户ID
+	Skipped    []int `json:"skipped"`    // 不是待审核状态而跳过的用户ID
+}
+
+// CheckInRequest 扫码签到请求参数
+type CheckInRequest struct {
+	Token string `json:"token" binding:"required,max=200"` // 签到码内容
+}
+
+// CheckInResponse 扫码签到结果
+type CheckInResponse struct {
+	EventID     int       `json:"event_id"`      // 活动ID
+	EventTitle  string    `json:"event_title"`   // 活动标题
+	UserID      int       `json:"user_id"`       // 用户ID
+	Name        string    `json:"name"`          // 用户姓名
+	Nickname    string    `json:"nickname"`      // 用户昵称
+	CheckInTime time.Time `json:"check_in_time"` // 签到时间
+}
+
+// CalendarTokenRequest 日历订阅请求参数
+type CalendarTokenRequest struct {
+	Token string `uri:"token" binding:"required,max=200"` // 日历订阅令牌，可带 .ics 后缀
+}
+
+// CalendarSubscription 用户的日历订阅链接
+type CalendarSubscription struct {
+	URL       string `json:"url"`        // 订阅地址
+	WebcalURL string `json:"webcal_url"` // webcal 协议的订阅地址，可直接唤起系统日历订阅
+}
+
+// AttendanceRequest 活动签到统计查询请求参数
+type AttendanceRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`              // 未签到用户列表页码，最小为1
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"` // 未签到用户列表页大小，1-100
+}
+
+// AttendanceResponse 活动签到统计
+type AttendanceResponse struct {
+	EventID         int     `json:"event_id"`         // 活动ID
+	Title           string  `json:"title"`            // 活动标题
+	RegisteredCount int     `json:"registered_count"` // 正式报名人数
+	CheckedInCount  int     `json:"checked_in_count"` // 已签到人数
+	NoShowCount     int     `json:"no_show_count"`    // 未签到人数
+	AttendanceRate  float64 `json:"attendance_rate"`  // 签到率，百分比保留两位小数
 }
 
 // CreateEventRequest 创建活动请求参数
 type CreateEventRequest struct {
-	Title                 string  `json:"title" binding:"required,max=255"`                       // 活动标题
-	Detail                string  `json:"detail" binding:"required"`                              // 活动内容
-	EventStartTime        string  `json:"event_start_time" binding:"required,time_format"`        // 活动开始时间
-	EventEndTime          string  `json:"event_end_time" binding:"required,time_format"`          // 活动结束时间
-	RegistrationStartTime string  `json:"registration_start_time" binding:"required,time_format"` // 活动报名开始时间
-	RegistrationEndTime   string  `json:"registration_end_time" binding:"required,time_format"`   // 活动报名截止时间
-	EventAddress          string  `json:"event_address" binding:"required,max=255"`               // 活动地址
-	RegistrationFee       float64 `json:"registration_fee" binding:"gte=0"`                       // 报名费用，必须大于或等于 0
-	CoverImageURL         string  `json:"cover_image_url" binding:"url"`                          // 封面图片URL
-	ImageIDList           []int   `json:"image_id_list" binding:"omitempty,dive,min=1"`           // 图片ID列表
+	Title                 string            `json:"title" binding:"required,max=255"`                       // 活动标题
+	Detail                string            `json:"detail" binding:"required"`                              // 活动内容
+	EventStartTime        string            `json:"event_start_time" binding:"required,time_format"`        // 活动开始时间
+	EventEndTime          string            `json:"event_end_time" binding:"required,time_format"`          // 活动结束时间
+	RegistrationStartTime string            `json:"registration_start_time" binding:"required,time_format"` // 活动报名开始时间
+	RegistrationEndTime   string            `json:"registration_end_time" binding:"required,time_format"`   // 活动报名截止时间
+	EventAddress          string            `json:"event_address" binding:"required,max=255"`               // 活动地址
+	RegistrationFee       float64           `json:"registration_fee" binding:"gte=0"`                       // 报名费用，必须大于或等于 0
+	MaxParticipants       int               `json:"max_participants" binding:"gte=0"`                       // 报名人数上限，0表示不限制
+	RequireApproval       string            `json:"require_approval" binding:"omitempty,oneof=Y N"`         // 报名是否需要审核，默认不需要
+	RegistrationForm      *RegistrationForm `json:"registration_form" binding:"omitempty"`                  // 报名表单，为空时要求填写全部用户资料字段
+	CoverImageURL         string            `json:"cover_image_url" binding:"url"`                          // 封面图片URL
+	ImageIDList           []int             `json:"image_id_list" binding:"omitempty,dive,min=1"`           // 图片ID列表
+	Slug                  string            `json:"slug" binding:"omitempty,slug"`                          // 别名，为空时根据标题生成
+	MetaDescription       string            `json:"meta_description" binding:"omitempty,max=300"`           // SEO描述，为空时取活动详情的纯文本
+	OgImageURL            string            `json:"og_image_url" binding:"omitempty,url"`                   // Open Graph 分享图片，为空时取封面图片
 }
 
 // UpdateEventRequest 更新活动请求参数
 type UpdateEventRequest struct {
-	Title                 *string  `json:"title" binding:"omitempty,non_empty_string,max=255"`                       // 活动标题
-	Detail                *string  `json:"detail" binding:"omitempty,non_empty_string"`                              // 活动内容
-	EventStartTime        *string  `json:"event_start_time" binding:"omitempty,non_empty_string,time_format"`        // 活动开始时间
-	EventEndTime          *string  `json:"event_end_time" binding:"omitempty,non_empty_string,time_format"`          // 活动结束时间
-	RegistrationStartTime *string  `json:"registration_start_time" binding:"omitempty,non_empty_string,time_format"` // 活动报名开始时间
-	RegistrationEndTime   *string  `json:"registration_end_time" binding:"omitempty,non_empty_string,time_format"`   // 活动报名截止时间
-	EventAddress          *string  `json:"event_address" binding:"omitempty,non_empty_string,max=255"`               // 活动地址
-	RegistrationFee       *float64 `json:"registration_fee" binding:"omitempty,gte=0"`                               // 报名费用，必须大于或等于 0
-	CoverImageURL         *string  `json:"cover_image_url" binding:"omitempty,url"`                                  // 封面图片URL
-	ImageIDList           *[]int   `json:"image_id_list" binding:"omitempty,dive,min=1"`                             // 图片ID列表
+	Title                 *string           `json:"title" binding:"omitempty,non_empty_string,max=255"`                       // 活动标题
+	Detail                *string           `json:"detail" binding:"omitempty,non_empty_string"`                              // 活动内容
+	EventStartTime        *string           `json:"event_start_time" binding:"omitempty,non_empty_string,time_format"`        // 活动开始时间
+	EventEndTime          *string           `json:"event_end_time" binding:"omitempty,non_empty_string,time_format"`          // 活动结束时间
+	RegistrationStartTime *string           `json:"registration_start_time" binding:"omitempty,non_empty_string,time_format"` // 活动报名开始时间
+	RegistrationEndTime   *string           `json:"registration_end_time" binding:"omitempty,non_empty_string,time_format"`   // 活动报名截止时间
+	EventAddress          *string           `json:"event_address" binding:"omitempty,non_empty_string,max=255"`               // 活动地址
+	RegistrationFee       *float64          `json:"registration_fee" binding:"omitempty,gte=0"`                               // 报名费用，必须大于或等于 0
+	MaxParticipants       *int              `json:"max_participants" binding:"omitempty,gte=0"`                               // 报名人数上限，0表示不限制，调大后按候补顺序自动转为正式报名
+	RequireApproval       *string           `json:"require_approval" binding:"omitempty,oneof=Y N"`                           // 报名是否需要审核，关闭后已提交的报名仍需审核
+	RegistrationForm      *RegistrationForm `json:"registration_form" binding:"omitempty"`                                    // 报名表单，修改后不影响已报名用户的答案
+	CoverImageURL         *string           `json:"cover_image_url" binding:"omitempty,url"`                                  // 封面图片URL
+	ImageIDList           *[]int            `json:"image_id_list" binding:"omitempty,dive,min=1"`                             // 图片ID列表
+	Slug                  *string           `json:"slug" binding:"omitempty,slug"`                                            // 别名，传空字符串表示清除别名
+	MetaDescription       *string           `json:"meta_description" binding:"omitempty,max=300"`                             // SEO描述
+	OgImageURL            *string           `json:"og_image_url" binding:"omitempty,url"`                                     // Open Graph 分享图片
 }
 
 // EventListResponse 活动列表响应结构体
 type EventListResponse struct {
-	ID                    int       `json:"id"`                      // 活动ID
-	Title                 string    `json:"title"`                   // 活动标题
-	EventStartTime        time.Time `json:"event_start_time"`        // 活动开始时间
-	EventEndTime          time.Time `json:"event_end_time"`          // 活动结束时间
-	RegistrationStartTime time.Time `json:"registration_start_time"` // 活动报名开始时间
-	RegistrationEndTime   time.Time `json:"registration_end_time"`   // 活动报名截止时间
-	EventAddress          string    `json:"event_address"`           // 活动地址
-	RegistrationFee       float64   `json:"registration_fee"`        // 报名费用
-	Status                string    `json:"status"`                  // 活动状态
-	CoverImageURL         string    `json:"cover_image_url"`         // 封面图片URL
-	MemberCount           int       `json:"member_count"`            // 报名人数
+	ID                    int         `json:"id"`                                // 活动ID
+	Title                 string      `json:"title"`                             // 活动标题
+	EventStartTime        time.Time   `json:"event_start_time"`                  // 活动开始时间
+	EventEndTime          time.Time   `json:"event_end_time"`                    // 活动结束时间
+	RegistrationStartTime time.Time   `json:"registration_start_time"`           // 活动报名开始时间
+	RegistrationEndTime   time.Time   `json:"registration_end_time"`             // 活动报名截止时间
+	EventAddress          string      `json:"event_address"`                     // 活动地址
+	RegistrationFee       float64     `json:"registration_fee"`                  // 报名费用
+	EventStatus           StatusLabel `json:"event_status" gorm:"-"`             // 活动状态
+	RegistrationStatus    StatusLabel `json:"registration_status" gorm:"-"`      // 报名状态
+	IsCancelled           string      `json:"-"`                                 // 是否已取消，用于计算状态
+	OccupiedSeats         int         `json:"-"`                                 // 已占用的名额数，用于计算报名状态
+	CoverImageURL         string      `json:"cover_image_url"`                   // 封面图片URL
+	MemberCount           int         `json:"member_count"`                      // 报名人数，不包含候补用户
+	MaxParticipants       int         `json:"max_participants"`                  // 报名人数上限，0表示不限制
+	RequireApproval       string      `json:"require_approval"`                  // 报名是否需要审核
+	CheckInToken          string      `json:"check_in_token,omitempty" gorm:"-"` // 签到码内容，仅用户已报名活动列表返回
+	RegistrationID        int         `json:"-"`                                 // 报名记录ID，仅用户已报名活动列表查询，用于生成签到码
+	RegistrationTime      time.Time   `json:"-"`                                 // 报名时间，仅用户已报名活动列表查询，用于生成签到码
+	Slug                  string      `json:"slug"`                              // 别名
+	SeriesID              int         `json:"series_id"`                         // 所属活动系列ID，0表示不属于任何系列
+	Lang                  string      `json:"lang" gorm:"-"`                     // 标题实际使用的语言，缺少请求语言的译文时为默认语言
 }
 
 // Image 关联图片列表结构体
@@ -71,30 +198,48 @@ type Image struct {
 
 // EventDetailResponse 活动详情响应结构体
 type EventDetailResponse struct {
-	Title                 string    `json:"title"`                   // 活动标题
-	Detail                string    `json:"detail"`                  // 活动内容
-	EventStartTime        time.Time `json:"event_start_time"`        // 活动开始时间
-	EventEndTime          time.Time `json:"event_end_time"`          // 活动结束时间
-	RegistrationStartTime time.Time `json:"registration_start_time"` // 活动报名开始时间
-	RegistrationEndTime   time.Time `json:"registration_end_time"`   // 活动报名截止时间
-	EventAddress          string    `json:"event_address"`           // 活动地址
-	RegistrationFee       float64   `json:"registration_fee"`        // 报名费用
-	Status                string    `json:"status"`                  // 活动状态
-	CoverImageURL         string    `json:"cover_image_url"`         // 封面图片URL
-	Images                []Image   `json:"images"`                  // 图片列表
+	ID                    int               `json:"id"`                      // 活动ID
+	Title                 string            `json:"title"`                   // 活动标题
+	Detail                string            `json:"detail"`                  // 活动内容
+	EventStartTime        time.Time         `json:"event_start_time"`        // 活动开始时间
+	EventEndTime          time.Time         `json:"event_end_time"`          // 活动结束时间
+	RegistrationStartTime time.Time         `json:"registration_start_time"` // 活动报名开始时间
+	RegistrationEndTime   time.Time         `json:"registration_end_time"`   // 活动报名截止时间
+	EventAddress          string            `json:"event_address"`           // 活动地址
+	RegistrationFee       float64           `json:"registration_fee"`        // 报名费用
+	MaxParticipants       int               `json:"max_participants"`        // 报名人数上限，0表示不限制
+	RequireApproval       string            `json:"require_approval"`        // 报名是否需要审核
+	RegistrationForm      *RegistrationForm `json:"registration_form"`       // 报名表单，未设置时返回默认表单
+	EventStatus           StatusLabel       `json:"event_status"`            // 活动状态
+	RegistrationStatus    StatusLabel       `json:"registration_status"`     // 报名状态
+	CancelReason          string            `json:"cancel_reason,omitempty"` // 取消原因，仅已取消的活动返回
+	CoverImageURL         string            `json:"cover_image_url"`         // 封面图片URL
+	Images                []Image           `json:"images"`                  // 图片列表
+	Slug                  string            `json:"slug"`                    // 别名
+	MetaDescription       string            `json:"meta_description"`        // SEO描述，未设置时取活动详情的纯文本
+	OgImageURL            string            `json:"og_image_url"`            // Open Graph 分享图片，未设置时取封面图片
+	SeriesID              int               `json:"series_id"`               // 所属活动系列ID，0表示不属于任何系列
+	Lang                  string            `json:"lang"`                    // 标题和详情实际使用的语言，缺少请求语言的译文时为默认语言
 }
 
 // ListEventRegUserResponse 活动报名列表查询请求参数
 type ListEventRegUserResponse struct {
-	Nickname     string `json:"nickname"`
-	Name         string `json:"name"`
-	GenderCode   string `json:"gender_code"`
-	Gender       string `json:"gender"`
-	PhoneNumber  string `json:"phone_number"`
-	Email        string `json:"email"`
-	Unit         string `json:"unit"`
-	Department   string `json:"department"`
-	Position     string `json:"position"`
-	Industry     string `json:"industry"`
-	IndustryName string `json:"industry_name"`
+	RegistrationID int         `json:"registration_id"` // 报名记录ID
+	UserID         int         `json:"user_id"`
+	Nickname       string      `json:"nickname"`
+	Name           string      `json:"name"`
+	GenderCode     string      `json:"gender_code"`
+	Gender         string      `json:"gender"`
+	PhoneNumber    string      `json:"phone_number"`
+	Email          string      `json:"email"`
+	Unit           string      `json:"unit"`
+	Department     string      `json:"department"`
+	Position       string      `json:"position"`
+	Industry       string      `json:"industry"`
+	IndustryName   string      `json:"industry_name"`
+	Answers        FormAnswers `json:"answers"`       // 报名表单附加问题的答案
+	Status         string      `json:"status"`        // 报名状态
+	ReviewReason   string      `json:"review_reason"` // 审核意见
+	CreateTime     time.Time   `json:"create_time"`   // 报名时间
+	CheckInTime    *time.Time  `json:"check_in_time"` // 签到时间，未签到时为空
 }
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"news-release/internal/payment"
+	"time"
+)
+
+// OrderListRequest 订单列表查询请求参数
+type OrderListRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`                                                     // 页码，最小为1
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`                                        // 页大小，1-100
+	Status   string `form:"status" binding:"omitempty,oneof=PENDING PAID EXPIRED CANCELLED REFUNDING REFUNDED"` // 订单状态，默认查询全部
+}
+
+// OrderNoRequest 按订单号操作订单的请求参数
+type OrderNoRequest struct {
+	OrderNo string `uri:"orderNo" binding:"required,max=32"` // 订单号
+}
+
+// OrderResponse 订单信息，金额单位为元
+type OrderResponse struct {
+	OrderNo      string     `json:"order_no"`      // 订单号
+	EventID      int        `json:"event_id"`      // 活动ID
+	EventTitle   string     `json:"event_title"`   // 活动标题
+	Amount       float64    `json:"amount"`        // 订单金额
+	Status       string     `json:"status"`        // 订单状态
+	ExpireTime   time.Time  `json:"expire_time"`   // 支付截止时间
+	PaidTime     *time.Time `json:"paid_time"`     // 支付时间
+	RefundAmount float64    `json:"refund_amount"` // 退款金额
+	RefundReason string     `json:"refund_reason"` // 退款原因
+	RefundTime   *time.Time `json:"refund_time"`   // 退款完成时间
+	CreateTime   time.Time  `json:"create_time"`   // 下单时间
+}
+
+// PayOrderResponse 发起支付的结果
+type PayOrderResponse struct {
+	Order     *OrderResponse     `json:"order"`      // 订单信息
+	PayParams *payment.PayParams `json:"pay_params"` // 客户端拉起支付所需的参数
+}
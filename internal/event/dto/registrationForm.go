@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// 报名表单题目类型常量定义
+const (
+	QuestionTypeText         = "TEXT"          // 文本
+	QuestionTypeSingleChoice = "SINGLE_CHOICE" // 单选
+	QuestionTypeMultiChoice  = "MULTI_CHOICE"  // 多选
+	QuestionTypeFile         = "FILE"          // 文件上传，答案为通过文件上传接口获得的图片ID
+)
+
+// ProfileField 报名时可要求填写的用户资料字段
+type ProfileField struct {
+	Key   string `json:"key"`   // 字段标识，与用户资料的JSON字段名一致
+	Label string `json:"label"` // 字段名称
+}
+
+// ProfileFields 报名时可要求填写的用户资料字段，未设置报名表单的活动要求全部填写
+var ProfileFields = []ProfileField{
+	{Key: "name", Label: "姓名"},
+	{Key: "phone_number", Label: "手机号"},
+	{Key: "email", Label: "邮箱"},
+	{Key: "unit", Label: "单位"},
+	{Key: "department", Label: "部门"},
+	{Key: "position", Label: "职位"},
+	{Key: "industry", Label: "行业"},
+}
+
+// RegistrationForm 活动报名表单，以JSON格式保存在活动中
+type RegistrationForm struct {
+	RequiredFields []string       `json:"required_fields" binding:"omitempty,dive,oneof=name phone_number email unit department position industry"` // 报名时用户资料中必须填写的字段
+	Questions      []FormQuestion `json:"questions" binding:"omitempty,max=50,dive"`                                                                // 附加问题
+}
+
+// FormQuestion 报名表单的附加问题
+type FormQuestion struct {
+	Key       string   `json:"key" binding:"required,max=50"`                                      // 问题标识，同一表单内唯一
+	Label     string   `json:"label" binding:"required,max=200"`                                   // 问题内容
+	Type      string   `json:"type" binding:"required,oneof=TEXT SINGLE_CHOICE MULTI_CHOICE FILE"` // 问题类型
+	Required  bool     `json:"required"`                                                           // 是否必答
+	Options   []string `json:"options,omitempty" binding:"omitempty,max=50,dive,required,max=100"` // 选项，仅选择题使用
+	MaxLength int      `json:"max_length,omitempty" binding:"omitempty,min=1,max=5000"`            // 文本答案最大字数，仅文本题使用，默认500
+	MaxFiles  int      `json:"max_files,omitempty" binding:"omitempty,min=1,max=10"`               // 最多上传文件数，仅文件上传题使用，默认1
+}
+
+// FormAnswer 报名表单附加问题的答案，以JSON格式保存在活动-用户关联映射中
+type FormAnswer struct {
+	Key      string   `json:"key" binding:"required,max=50"`                             // 问题标识
+	Label    string   `json:"label"`                                                     // 报名时的问题内容，表单修改后仍可查看原问题
+	Values   []string `json:"values" binding:"omitempty,max=50,dive,max=5000"`           // 文本和选择题的答案，文件上传题为文件URL
+	ImageIDs []int    `json:"image_ids,omitempty" binding:"omitempty,max=10,dive,min=1"` // 文件上传题上传的图片ID
+}
+
+// FormAnswers 报名表单答案列表
+type FormAnswers []FormAnswer
+
+// Value 将报名表单保存为JSON，未设置表单时保存为NULL
+func (f *RegistrationForm) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan 从JSON读取报名表单
+func (f *RegistrationForm) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// Value 将答案列表保存为JSON，没有答案时保存为NULL
+func (a FormAnswers) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan 从JSON读取答案列表
+func (a *FormAnswers) Scan(value interface{}) error {
+	return scanJSON(value, a)
+}
+
+// scanJSON 将数据库中的JSON列解析到目标结构，NULL时保持零值
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("不支持的JSON数据类型: %T", value)
+	}
+}
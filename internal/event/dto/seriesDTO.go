@@ -0,0 +1,96 @@
+package dto
+
+import "time"
+
+// SeriesDetailRequest 活动系列查询请求参数
+type SeriesDetailRequest struct {
+	SeriesID int `uri:"id" binding:"required,numeric"` // 活动系列ID
+}
+
+// SeriesListRequest 活动系列列表查询请求参数
+type SeriesListRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+}
+
+// CreateSeriesRequest 创建活动系列请求参数，时间均为第一场的时间，后续场次保持相同的时长和报名时间间隔
+type CreateSeriesRequest struct {
+	Title                 string            `json:"title" binding:"required,max=255"`                       // 系列标题
+	Detail                string            `json:"detail" binding:"required"`                              // 活动内容
+	RecurrenceRule        string            `json:"recurrence_rule" binding:"required,max=255"`             // 重复规则，如 FREQ=MONTHLY;COUNT=12
+	EventStartTime        string            `json:"event_start_time" binding:"required,time_format"`        // 第一场活动开始时间
+	EventEndTime          string            `json:"event_end_time" binding:"required,time_format"`          // 第一场活动结束时间
+	RegistrationStartTime string            `json:"registration_start_time" binding:"required,time_format"` // 第一场报名开始时间
+	RegistrationEndTime   string            `json:"registration_end_time" binding:"required,time_format"`   // 第一场报名截止时间
+	EventAddress          string            `json:"event_address" binding:"required,max=255"`               // 活动地址
+	RegistrationFee       float64           `json:"registration_fee" binding:"gte=0"`                       // 每场的报名费用
+	MaxParticipants       int               `json:"max_participants" binding:"gte=0"`                       // 每场的报名人数上限，0表示不限制
+	RequireApproval       string            `json:"require_approval" binding:"omitempty,oneof=Y N"`         // 报名是否需要审核，默认不需要
+	RegistrationForm      *RegistrationForm `json:"registration_form" binding:"omitempty"`                  // 报名表单
+	CoverImageURL         string            `json:"cover_image_url" binding:"url"`                          // 封面图片URL
+	ImageIDList           []int             `json:"image_id_list" binding:"omitempty,dive,min=1"`           // 各场次共用的图片ID列表
+}
+
+// UpdateSeriesRequest 更新活动系列请求参数，修改同步到尚未开始且未单独修改过的场次，场次时间需单独修改
+type UpdateSeriesRequest struct {
+	Title            *string           `json:"title" binding:"omitempty,non_empty_string,max=255"`         // 系列标题
+	Detail           *string           `json:"detail" binding:"omitempty,non_empty_string"`                // 活动内容
+	EventAddress     *string           `json:"event_address" binding:"omitempty,non_empty_string,max=255"` // 活动地址
+	RegistrationFee  *float64          `json:"registration_fee" binding:"omitempty,gte=0"`                 // 每场的报名费用
+	MaxParticipants  *int              `json:"max_participants" binding:"omitempty,gte=0"`                 // 每场的报名人数上限
+	RequireApproval  *string           `json:"require_approval" binding:"omitempty,oneof=Y N"`             // 报名是否需要审核
+	RegistrationForm *RegistrationForm `json:"registration_form" binding:"omitempty"`                      // 报名表单
+	CoverImageURL    *string           `json:"cover_image_url" binding:"omitempty,url"`                    // 封面图片URL
+	ImageIDList      *[]int            `json:"image_id_list" binding:"omitempty,dive,min=1"`               // 各场次共用的图片ID列表
+}
+
+// UpdateSeriesResponse 更新活动系列的结果
+type UpdateSeriesResponse struct {
+	Updated    []int `json:"updated"`    // 已同步修改的场次ID
+	Overridden []int `json:"overridden"` // 单独修改过而未同步的场次ID
+	Failed     []int `json:"failed"`     // 同步失败的场次ID
+}
+
+// SeriesOccurrence 活动系列的场次
+type SeriesOccurrence struct {
+	EventID               int       `json:"event_id"`                // 活动ID
+	Title                 string    `json:"title"`                   // 活动标题
+	EventStartTime        time.Time `json:"event_start_time"`        // 活动开始时间
+	EventEndTime          time.Time `json:"event_end_time"`          // 活动结束时间
+	RegistrationStartTime time.Time `json:"registration_start_time"` // 报名开始时间
+	RegistrationEndTime   time.Time `json:"registration_end_time"`   // 报名截止时间
+	SeriesOverridden      string    `json:"series_overridden"`       // 是否单独修改过
+	IsCancelled           string    `json:"is_cancelled"`            // 是否已取消
+	IsDeleted             string    `json:"is_deleted"`              // 是否已删除
+}
+
+// SeriesDetailResponse 活动系列详情
+type SeriesDetailResponse struct {
+	ID               int                `json:"id"`                // 活动系列ID
+	Title            string             `json:"title"`             // 系列标题
+	Detail           string             `json:"detail"`            // 活动内容
+	RecurrenceRule   string             `json:"recurrence_rule"`   // 重复规则
+	EventAddress     string             `json:"event_address"`     // 活动地址
+	RegistrationFee  float64            `json:"registration_fee"`  // 每场的报名费用
+	MaxParticipants  int                `json:"max_participants"`  // 每场的报名人数上限
+	RequireApproval  string             `json:"require_approval"`  // 报名是否需要审核
+	RegistrationForm *RegistrationForm  `json:"registration_form"` // 报名表单，未设置时返回默认表单
+	CoverImageURL    string             `json:"cover_image_url"`   // 封面图片URL
+	Images           []Image            `json:"images"`            // 各场次共用的图片
+	Occurrences      []SeriesOccurrence `json:"occurrences"`       // 场次列表，不包含已删除的场次
+}
+
+// SeriesRegistrationRequest 报名整个活动系列的请求参数
+type SeriesRegistrationRequest struct {
+	SeriesID int          `json:"series_id" binding:"required,numeric"`    // 活动系列ID
+	Answers  []FormAnswer `json:"answers" binding:"omitempty,max=50,dive"` // 报名表单附加问题的答案，各场次共用
+}
+
+// SeriesRegistrationResult 单个场次的报名或取消报名结果
+type SeriesRegistrationResult struct {
+	EventID        int       `json:"event_id"`          // 活动ID
+	EventStartTime time.Time `json:"event_start_time"`  // 活动开始时间
+	Success        bool      `json:"success"`           // 是否成功
+	Status         string    `json:"status,omitempty"`  // 报名成功时的报名状态
+	Message        string    `json:"message,omitempty"` // 失败原因
+}
@@ -5,30 +5,51 @@ import (
 	"time"
 )
 
-// 活动状态常量定义
+// 活动状态常量定义，根据活动时间和取消标志计算，不存储在数据库中
 const (
-	EventStatusNotBegun   = "NotBegun"   // 未开始
-	EventStatusInProgress = "InProgress" // 进行中
-	EventStatusCompleted  = "Completed"  // 已结束
+	EventStatusUpcoming  = "UPCOMING"  // 未开始
+	EventStatusOngoing   = "ONGOING"   // 进行中
+	EventStatusEnded     = "ENDED"     // 已结束
+	EventStatusCancelled = "CANCELLED" // 已取消
+)
+
+// 报名状态常量定义，根据报名时间、已占用名额和取消标志计算，不存储在数据库中
+const (
+	RegistrationStateNotOpen = "NOT_OPEN" // 未开放报名
+	RegistrationStateOpen    = "OPEN"     // 报名中
+	RegistrationStateClosed  = "CLOSED"   // 报名已截止，已取消的活动也视为报名已截止
+	RegistrationStateFull    = "FULL"     // 名额已满，仍可加入候补名单
 )
 
 // Event 对应 events 表的数据模型
 type Event struct {
-	ID                    int       `json:"id" gorm:"primaryKey;column:id"`
-	Title                 string    `json:"title" gorm:"type:varchar(255);not null;column:title"`               // 活动标题
-	Detail                string    `json:"detail" gorm:"type:mediumtext;column:detail"`                        // 活动详情
-	EventStartTime        time.Time `json:"event_start_time" gorm:"column:event_start_time"`                    // 活动开始时间
-	EventEndTime          time.Time `json:"event_end_time" gorm:"column:event_end_time"`                        // 活动结束时间
-	RegistrationStartTime time.Time `json:"registration_start_time" gorm:"column:registration_start_time"`      // 活动报名开始时间
-	RegistrationEndTime   time.Time `json:"registration_end_time" gorm:"column:registration_end_time"`          // 活动报名截止时间
-	EventAddress          string    `json:"event_address" gorm:"type:varchar(255);column:event_address"`        // 活动地址
-	RegistrationFee       float64   `json:"registration_fee" gorm:"type:decimal(10,2);column:registration_fee"` // 报名费用
-	CoverImageURL         string    `json:"cover_image_url" gorm:"column:cover_image_url"`                      // 封面图片URL
-	IsDeleted             string    `json:"is_deleted" gorm:"column:is_deleted;default:N"`                      // 软删除标志
-	CreateTime            time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`               // 数据创建时间，自动生成
-	UpdateTime            time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`               // 数据最后更新时间，自动更新
-	CreateUser            int       `json:"create_user" gorm:"column:create_user"`                              // 创建人ID
-	UpdateUser            int       `json:"update_user" gorm:"column:update_user"`                              // 最后更新人ID
+	ID                    int                   `json:"id" gorm:"primaryKey;column:id"`
+	Title                 string                `json:"title" gorm:"type:varchar(255);not null;column:title;index:ft_event_search,class:FULLTEXT,option:WITH PARSER ngram"` // 活动标题
+	Detail                string                `json:"detail" gorm:"type:mediumtext;column:detail"`                                                                        // 活动详情
+	DetailText            string                `json:"-" gorm:"type:mediumtext;column:detail_text;index:ft_event_search,class:FULLTEXT,option:WITH PARSER ngram"`          // 活动详情纯文本，由详情HTML提取，用于全文检索
+	EventStartTime        time.Time             `json:"event_start_time" gorm:"column:event_start_time"`                                                                    // 活动开始时间
+	EventEndTime          time.Time             `json:"event_end_time" gorm:"column:event_end_time"`                                                                        // 活动结束时间
+	RegistrationStartTime time.Time             `json:"registration_start_time" gorm:"column:registration_start_time"`                                                      // 活动报名开始时间
+	RegistrationEndTime   time.Time             `json:"registration_end_time" gorm:"column:registration_end_time"`                                                          // 活动报名截止时间
+	EventAddress          string                `json:"event_address" gorm:"type:varchar(255);column:event_address"`                                                        // 活动地址
+	RegistrationFee       float64               `json:"registration_fee" gorm:"type:decimal(10,2);column:registration_fee"`                                                 // 报名费用
+	MaxParticipants       int                   `json:"max_participants" gorm:"column:max_participants;not null;default:0"`                                                 // 报名人数上限，0表示不限制
+	RequireApproval       string                `json:"require_approval" gorm:"type:varchar(5);not null;default:N;column:require_approval"`                                 // 报名是否需要审核，Y-需要，审核通过后才算正式报名
+	RegistrationForm      *dto.RegistrationForm `json:"registration_form" gorm:"type:json;column:registration_form"`                                                        // 报名表单，未设置时要求填写全部用户资料字段
+	CoverImageURL         string                `json:"cover_image_url" gorm:"column:cover_image_url"`                                                                      // 封面图片URL
+	Slug                  string                `json:"slug" gorm:"type:varchar(200);column:slug;default:NULL;uniqueIndex:uk_event_slug"`                                   // 别名，用于生成可读链接，未设置时为NULL
+	MetaDescription       string                `json:"meta_description" gorm:"type:varchar(300);column:meta_description"`                                                  // SEO描述，为空时取活动详情的纯文本
+	OgImageURL            string                `json:"og_image_url" gorm:"type:varchar(500);column:og_image_url"`                                                          // Open Graph 分享图片，为空时取封面图片
+	SeriesID              int                   `json:"series_id" gorm:"column:series_id;not null;default:0;index:idx_event_series"`                                        // 所属活动系列ID，0表示不属于任何系列
+	SeriesOverridden      string                `json:"series_overridden" gorm:"type:varchar(5);not null;default:N;column:series_overridden"`                               // 是否单独修改过，Y-修改系列时不再同步到该场次
+	Sequence              int                   `json:"sequence" gorm:"column:sequence;not null;default:0"`                                                                 // 日历修订序号，活动标题、时间、地址或详情变更以及删除、恢复、取消时递增
+	IsCancelled           string                `json:"is_cancelled" gorm:"type:varchar(5);not null;default:N;column:is_cancelled"`                                         // 是否已取消，取消后不能报名，已支付的报名费用全额退款
+	CancelReason          string                `json:"cancel_reason" gorm:"type:varchar(255);column:cancel_reason"`                                                        // 取消原因
+	IsDeleted             string                `json:"is_deleted" gorm:"column:is_deleted;default:N"`                                                                      // 软删除标志
+	CreateTime            time.Time             `json:"create_time" gorm:"column:create_time;autoCreateTime"`                                                               // 数据创建时间，自动生成
+	UpdateTime            time.Time             `json:"update_time" gorm:"column:update_time;autoUpdateTime"`                                                               // 数据最后更新时间，自动更新
+	CreateUser            int                   `json:"create_user" gorm:"column:create_user"`                                                                              // 创建人ID
+	UpdateUser            int                   `json:"update_user" gorm:"column:update_user"`                                                                              // 最后更新人ID
 	// 关联字段
 	Images []dto.Image `json:"images" gorm:"-"` // 图片列表，存储图片ID和URL
 }
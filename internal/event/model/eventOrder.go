@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// 订单状态常量定义
+const (
+	OrderStatusPending   = "PENDING"   // 待支付
+	OrderStatusPaid      = "PAID"      // 已支付
+	OrderStatusExpired   = "EXPIRED"   // 超时未支付，已关闭
+	OrderStatusCancelled = "CANCELLED" // 支付前取消报名，已关闭
+	OrderStatusRefunding = "REFUNDING" // 退款中，退款失败时由定时任务重试
+	OrderStatusRefunded  = "REFUNDED"  // 已退款
+)
+
+// EventOrder 对应 event_orders 表的数据模型，收费活动报名时生成
+type EventOrder struct {
+	ID            int        `json:"id" gorm:"primaryKey;column:id"`                                                      // 主键
+	OrderNo       string     `json:"order_no" gorm:"type:varchar(32);not null;uniqueIndex:uk_order_no;column:order_no"`   // 订单号
+	EventID       int        `json:"event_id" gorm:"not null;index:idx_order_event_user;column:event_id"`                 // 活动ID
+	UserID        int        `json:"user_id" gorm:"not null;index:idx_order_event_user;column:user_id"`                   // 用户ID
+	Amount        int        `json:"amount" gorm:"not null;column:amount"`                                                // 订单金额，单位分
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;index:idx_order_status_expire;column:status"` // 订单状态
+	ExpireTime    time.Time  `json:"expire_time" gorm:"not null;index:idx_order_status_expire;column:expire_time"`        // 支付截止时间
+	Provider      string     `json:"provider" gorm:"type:varchar(20);column:provider"`                                    // 支付渠道
+	TradeNo       string     `json:"trade_no" gorm:"type:varchar(64);column:trade_no"`                                    // 支付渠道的交易号
+	PaidTime      *time.Time `json:"paid_time" gorm:"column:paid_time"`                                                   // 支付时间
+	RefundNo      string     `json:"refund_no" gorm:"type:varchar(32);column:refund_no"`                                  // 退款单号
+	RefundTradeNo string     `json:"refund_trade_no" gorm:"type:varchar(64);column:refund_trade_no"`                      // 支付渠道的退款交易号
+	RefundAmount  int        `json:"refund_amount" gorm:"not null;default:0;column:refund_amount"`                        // 退款金额，单位分
+	RefundReason  string     `json:"refund_reason" gorm:"type:varchar(255);column:refund_reason"`                         // 退款原因
+	RefundTime    *time.Time `json:"refund_time" gorm:"column:refund_time"`                                               // 退款完成时间
+	CreateTime    time.Time  `json:"create_time" gorm:"column:create_time;autoCreateTime"`                                // 数据创建时间，自动生成
+	UpdateTime    time.Time  `json:"update_time" gorm:"column:update_time;autoUpdateTime"`                                // 数据最后更新时间，自动更新
+}
+
+// TableName 设置表名
+func (*EventOrder) TableName() string {
+	return "event_orders"
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// 活动提醒类型常量定义
+const (
+	ReminderTypeEventStart        = "EVENT_START"        // 活动即将开始
+	ReminderTypeRegistrationClose = "REGISTRATION_CLOSE" // 报名即将截止
+)
+
+// EventReminder 对应 event_reminders 表的数据模型，记录已发送的活动提醒，避免重启或多实例部署时重复发送
+// 活动时间修改后提醒时间点随之变化，按新的时间重新发送
+type EventReminder struct {
+	ID           int       `json:"id" gorm:"primaryKey;column:id"`                                                                    // 主键
+	EventID      int       `json:"event_id" gorm:"not null;uniqueIndex:uk_event_reminder;column:event_id"`                            // 活动ID
+	ReminderType string    `json:"reminder_type" gorm:"type:varchar(20);not null;uniqueIndex:uk_event_reminder;column:reminder_type"` // 提醒类型
+	Offset       int       `json:"offset" gorm:"not null;uniqueIndex:uk_event_reminder;column:offset_minutes"`                        // 提前提醒的分钟数
+	TargetTime   time.Time `json:"target_time" gorm:"not null;uniqueIndex:uk_event_reminder;column:target_time"`                      // 提醒对应的活动开始时间或报名截止时间
+	MessageID    int       `json:"message_id" gorm:"not null;default:0;column:message_id"`                                            // 发送的消息ID
+	CreateTime   time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`                                              // 数据创建时间，自动生成
+}
+
+// TableName 设置表名
+func (*EventReminder) TableName() string {
+	return "event_reminders"
+}
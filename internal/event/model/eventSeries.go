@@ -0,0 +1,31 @@
+package model
+
+import (
+	"news-release/internal/event/dto"
+	"time"
+)
+
+// EventSeries 对应 event_series 表的数据模型，按重复规则生成多个场次的活动
+// 系列保存各场次共用的活动内容，修改系列时同步到尚未开始且未单独修改过的场次
+type EventSeries struct {
+	ID               int                   `json:"id" gorm:"primaryKey;column:id"`                                                     // 主键
+	Title            string                `json:"title" gorm:"type:varchar(255);not null;column:title"`                               // 系列标题，各场次的活动标题与系列标题相同
+	Detail           string                `json:"detail" gorm:"type:mediumtext;column:detail"`                                        // 活动详情
+	RecurrenceRule   string                `json:"recurrence_rule" gorm:"type:varchar(255);not null;column:recurrence_rule"`           // 重复规则，RRULE格式的子集
+	EventAddress     string                `json:"event_address" gorm:"type:varchar(255);column:event_address"`                        // 活动地址
+	RegistrationFee  float64               `json:"registration_fee" gorm:"type:decimal(10,2);column:registration_fee"`                 // 每场的报名费用
+	MaxParticipants  int                   `json:"max_participants" gorm:"column:max_participants;not null;default:0"`                 // 每场的报名人数上限，0表示不限制
+	RequireApproval  string                `json:"require_approval" gorm:"type:varchar(5);not null;default:N;column:require_approval"` // 报名是否需要审核
+	RegistrationForm *dto.RegistrationForm `json:"registration_form" gorm:"type:json;column:registration_form"`                        // 报名表单
+	CoverImageURL    string                `json:"cover_image_url" gorm:"column:cover_image_url"`                                      // 封面图片URL
+	IsDeleted        string                `json:"is_deleted" gorm:"column:is_deleted;default:N"`                                      // 软删除标志
+	CreateTime       time.Time             `json:"create_time" gorm:"column:create_time;autoCreateTime"`                               // 数据创建时间，自动生成
+	UpdateTime       time.Time             `json:"update_time" gorm:"column:update_time;autoUpdateTime"`                               // 数据最后更新时间，自动更新
+	CreateUser       int                   `json:"create_user" gorm:"column:create_user"`                                              // 创建人ID
+	UpdateUser       int                   `json:"update_user" gorm:"column:update_user"`                                              // 最后更新人ID
+}
+
+// TableName 设置表名
+func (*EventSeries) TableName() string {
+	return "event_series"
+}
@@ -1,17 +1,42 @@
 package model
 
 import (
+	"news-release/internal/event/dto"
 	"time"
 )
 
+// 报名状态常量定义
+const (
+	RegistrationStatusRegistered = "REGISTERED" // 已报名
+	RegistrationStatusWaitlisted = "WAITLISTED" // 候补中
+	RegistrationStatusPending    = "PENDING"    // 待审核
+	RegistrationStatusRejected   = "REJECTED"   // 审核未通过
+	RegistrationStatusUnpaid     = "UNPAID"     // 待支付，收费活动占用名额直到支付完成或订单超时关闭
+	RegistrationStatusCancelled  = "CANCELLED"  // 活动取消时尚未完成报名，报名已关闭
+)
+
+// 报名审核操作常量定义
+const (
+	ReviewActionApprove = "APPROVE" // 通过
+	ReviewActionReject  = "REJECT"  // 驳回
+)
+
 // EventUserMapping 对应 event_user_mappings 表的数据模型
 type EventUserMapping struct {
-	ID         int       `json:"id" gorm:"primaryKey;column:id"`                       // 主键
-	UserID     int       `json:"user_id" gorm:"column:user_id"`                        // 用户id，关联users表
-	EventID    int       `json:"event_id" gorm:"column:event_id"`                      // 活动id，关联events表
-	IsDeleted  string    `json:"is_deleted" gorm:"column:is_deleted;default:N"`        // 软删除标志
-	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"` // 数据创建时间，自动生成
-	UpdateTime time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"` // 数据最后更新时间，自动更新
+	ID           int             `json:"id" gorm:"primaryKey;column:id"`                                           // 主键
+	UserID       int             `json:"user_id" gorm:"column:user_id"`                                            // 用户id，关联users表
+	EventID      int             `json:"event_id" gorm:"column:event_id"`                                          // 活动id，关联events表
+	Status       string          `json:"status" gorm:"type:varchar(20);not null;default:REGISTERED;column:status"` // 报名状态，活动人数已满时进入候补
+	WaitlistTime *time.Time      `json:"waitlist_time" gorm:"column:waitlist_time"`                                // 进入候补名单的时间，候补按此时间先后转为正式报名
+	ReviewReason string          `json:"review_reason" gorm:"type:varchar(255);column:review_reason"`              // 审核意见，驳回时必填
+	ReviewTime   *time.Time      `json:"review_time" gorm:"column:review_time"`                                    // 审核时间
+	ReviewUser   int             `json:"review_user" gorm:"column:review_user"`                                    // 审核人ID
+	Answers      dto.FormAnswers `json:"answers" gorm:"type:json;column:answers"`                                  // 报名表单附加问题的答案
+	CheckInTime  *time.Time      `json:"check_in_time" gorm:"column:check_in_time"`                                // 签到时间，未签到时为空
+	CheckInUser  int             `json:"check_in_user" gorm:"column:check_in_user"`                                // 扫码签到的工作人员ID
+	IsDeleted    string          `json:"is_deleted" gorm:"column:is_deleted;default:N"`                            // 软删除标志
+	CreateTime   time.Time       `json:"create_time" gorm:"column:create_time;autoCreateTime"`                     // 数据创建时间，自动生成
+	UpdateTime   time.Time       `json:"update_time" gorm:"column:update_time;autoUpdateTime"`                     // 数据最后更新时间，自动更新
 }
 
 // TableName 设置表名
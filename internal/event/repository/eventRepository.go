@@ -11,36 +11,79 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// occupiedSeatsSQL 统计活动已占用名额数的子查询，参数为占用名额的报名状态和未删除标志
+const occupiedSeatsSQL = "(SELECT COUNT(*) FROM event_user_mappings o WHERE o.event_id = e.id AND o.status IN ? AND o.is_deleted = ?)"
+
+// occupiedStatuses 占用名额的报名状态，包含正式报名和待支付的用户，不包含候补用户
+var occupiedStatuses = []string{model.RegistrationStatusRegistered, model.RegistrationStatusUnpaid}
+
 // EventRepository 数据访问接口，定义数据访问的方法集
 type EventRepository interface {
 	// ExecTransaction 执行事务
 	ExecTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
 	// List 分页查询
-	List(ctx context.Context, page, pageSize int, eventStatus string, queryScope string) ([]*dto.EventListResponse, int, error)
+	List(ctx context.Context, pg *utils.Pagination, eventStatus string, registrationStatus string, queryScope string) ([]*dto.EventListResponse, error)
 	// GetEventDetail 获取活动详情
 	GetEventDetail(ctx context.Context, eventID int) (*model.Event, error)
 	// ListEventImage 获取活动图片列表
 	ListEventImage(ctx context.Context, bizID int) []dto.Image
 	// GetEventUserMap 查询活动-用户关联映射
 	GetEventUserMap(ctx context.Context, eventID int, userID int) (*model.EventUserMapping, error)
+	// LockEventUserMap 在事务中查询并锁定活动-用户关联映射
+	LockEventUserMap(ctx context.Context, tx *gorm.DB, eventID int, userID int) (*model.EventUserMapping, error)
 	// CreatEventUserMap 创建活动-用户关联映射,将用户添加到活动中
-	CreatEventUserMap(ctx context.Context, eventUserMapping *model.EventUserMapping) error
-	// UpdateEUMapDeleteFlag 更新活动-用户关联删除标志
-	UpdateEUMapDeleteFlag(ctx context.Context, eventID int, userID int, isDeleted string) error
+	CreatEventUserMap(ctx context.Context, tx *gorm.DB, eventUserMapping *model.EventUserMapping) error
+	// UpdateEventUserMap 更新活动-用户关联映射
+	UpdateEventUserMap(ctx context.Context, tx *gorm.DB, eventID int, userID int, updateFields map[string]interface{}) error
+	// LockEvent 在事务中锁定活动，同一活动的报名人数变更串行执行
+	LockEvent(ctx context.Context, tx *gorm.DB, eventID int) (*model.Event, error)
+	// CountOccupiedSeats 在事务中统计活动已占用的名额数
+	CountOccupiedSeats(ctx context.Context, tx *gorm.DB, eventID int) (int64, error)
+	// GetOccupiedSeats 统计活动已占用的名额数，用于展示报名状态
+	GetOccupiedSeats(ctx context.Context, eventID int) (int64, error)
+	// ListWaitlistedUserIDs 在事务中按候补顺序查询候补用户ID，limit小于等于0时查询全部
+	ListWaitlistedUserIDs(ctx context.Context, tx *gorm.DB, eventID int, limit int) ([]int, error)
+	// PromoteWaitlistedUsers 将候补用户转为正式报名或待支付
+	PromoteWaitlistedUsers(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, status string) error
+	// GetWaitlistPosition 查询候补用户在候补名单中的位置，从1开始
+	GetWaitlistPosition(ctx context.Context, mapping *model.EventUserMapping) (int, error)
 	// IsUserRegistered 查询用户是否已报名活动
 	IsUserRegistered(ctx context.Context, eventID int, userID int) (bool, error)
 	// ListUserRegisteredEvents 获取用户已报名活动列表
-	ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string) ([]*model.Event, int, error)
+	ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string, registrationStatus string) ([]*dto.EventListResponse, int, error)
+	// ListUserCalendarEvents 查询用户已报名的未删除活动，用于生成订阅日历
+	ListUserCalendarEvents(ctx context.Context, userID int, limit int) ([]*model.Event, error)
+	// ListUserCancelledEvents 查询用户已报名但已被删除的活动
+	ListUserCancelledEvents(ctx context.Context, userID int, since time.Time) ([]*model.Event, error)
 	// CreateEvent 创建活动
 	CreateEvent(ctx context.Context, tx *gorm.DB, event *model.Event) error
 	// UpdateEvent 更新活动
 	UpdateEvent(ctx context.Context, tx *gorm.DB, eventID int, updateFields map[string]interface{}) error
-	// ListEventRegisteredUser 查询已报名活动的用户列表
-	ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error)
-	// GetEventByTitle 根据活动标题查询活动
+	// ListEventRegisteredUser 按报名状态查询活动的报名用户列表
+	ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int, status string) ([]*dto.ListEventRegUserResponse, int, error)
+	// ListNoShowUsers 查询活动正式报名但未签到的用户列表
+	ListNoShowUsers(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error)
+	// ListExportRegUsers 按报名记录ID升序分批查询待导出的活动报名用户
+	ListExportRegUsers(ctx context.Context, eventID int, status string, afterID int, limit int) ([]*dto.ListEventRegUserResponse, error)
+	// CountAttendance 统计活动的正式报名人数和已签到人数
+	CountAttendance(ctx context.Context, eventID int) (int64, int64, error)
+	// ListPendingUserIDs 在事务中查询并锁定指定用户中待审核的报名，按报名先后排列
+	ListPendingUserIDs(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int) ([]int, error)
+	// ListActiveUserIDs 在事务中查询并锁定指定状态的有效报名，返回报名用户ID
+	ListActiveUserIDs(ctx context.Context, tx *gorm.DB, eventID int, statuses []string) ([]int, error)
+	// UpdateEventUserMaps 批量更新活动-用户关联映射
+	UpdateEventUserMaps(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, updateFields map[string]interface{}) error
+	// GetEventByTitle 根据活动标题查询不属于活动系列的活动
 	GetEventByTitle(ctx context.Context, title string) (*model.Event, error)
+	// ListEventsWithoutText 查询尚未提取详情纯文本的活动
+	ListEventsWithoutText(ctx context.Context, limit int) ([]model.Event, error)
+	// UpdateEventDetailText 更新活动详情纯文本，不变更更新时间
+	UpdateEventDetailText(ctx context.Context, eventID int, detailText string) error
+	// GetEventBySlug 根据别名查询活动，包含已删除的活动
+	GetEventBySlug(ctx context.Context, slug string) (*model.Event, error)
 }
 
 // EventRepositoryImpl 实现接口的具体结构体
@@ -59,24 +102,15 @@ func (repo *EventRepositoryImpl) ExecTransaction(ctx context.Context, fn func(tx
 }
 
 // List 分页查询数据
-func (repo *EventRepositoryImpl) List(ctx context.Context, page, pageSize int, eventStatus string, queryScope string) ([]*dto.EventListResponse, int, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	offset := (page - 1) * pageSize
+func (repo *EventRepositoryImpl) List(ctx context.Context, pg *utils.Pagination, eventStatus string, registrationStatus string, queryScope string) ([]*dto.EventListResponse, error) {
 	var events []*dto.EventListResponse
-	var total int64
 
 	query := repo.db.WithContext(ctx)
 	// 构建基础查询
 	query = query.Table("events e").
 		Select(`e.*, 
-				COUNT(DISTINCT m.user_id) as member_count`).
-		Joins("LEFT JOIN event_user_mappings m ON e.id = m.event_id AND m.is_deleted = ?", utils.DeletedFlagNo).
+				COUNT(DISTINCT m.user_id) as member_count, `+occupiedSeatsSQL+` as occupied_seats`, occupiedStatuses, utils.DeletedFlagNo).
+		Joins("LEFT JOIN event_user_mappings m ON e.id = m.event_id AND m.status = ? AND m.is_deleted = ?", model.RegistrationStatusRegistered, utils.DeletedFlagNo).
 		Group("e.id")
 
 	if queryScope != "" {
@@ -93,36 +127,70 @@ func (repo *EventRepositoryImpl) List(ctx context.Context, page, pageSize int, e
 		query = query.Where("e.is_deleted = ?", utils.DeletedFlagNo)
 	}
 
-	// 根据活动状态拼接查询条件
-	if eventStatus == model.EventStatusInProgress {
-		// 进行中的活动：报名时间在当前时间范围内
-		query = query.Where("e.registration_start_time <= ? AND e.registration_end_time >= ?", time.Now(), time.Now())
-		// 按活动开始时间升序排列
-		query = query.Order("e.event_start_time ASC")
-	} else if eventStatus == model.EventStatusCompleted {
-		// 已结束的活动：报名截止时间在当前时间之前
-		query = query.Where("e.registration_end_time < ?", time.Now())
-		// 按活动开始时间降序排列
-		query = query.Order("e.event_start_time DESC")
-	} else if eventStatus == model.EventStatusNotBegun {
-		// 未开始的活动：报名开始时间在当前时间之后
-		query = query.Where("e.registration_start_time > ?", time.Now())
-		// 按活动开始时间升序排列
-		query = query.Order("e.event_start_time ASC")
-	}
+	// 根据活动状态和报名状态拼接查询条件
+	now := time.Now()
+	query, desc := whereEventStatus(query, eventStatus, now)
+	query = whereRegistrationStatus(query, registrationStatus, now)
 
 	// 计算总数
-	countQuery := query.Session(&gorm.Session{})
-	if err := countQuery.Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	if pg.WithTotal {
+		if err := query.Session(&gorm.Session{}).Count(&pg.Total).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+		}
+	}
+
+	// 游标分页：开始时间相同的活动按活动ID排序，保证翻页不重复不遗漏
+	if pg.Cursor != nil {
+		keyset, args := pg.Cursor.After("e.event_start_time", "e.id", desc)
+		query = query.Where(keyset, args...)
+	}
+	if desc {
+		query = query.Order("e.event_start_time DESC").Order("e.id DESC")
+	} else {
+		query = query.Order("e.event_start_time ASC").Order("e.id ASC")
 	}
 
 	// 分页查询数据
-	if err := query.Offset(offset).Limit(pageSize).Find(&events).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	if err := query.Offset(pg.Offset()).Limit(pg.Limit()).Find(&events).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
 	}
 
-	return events, int(total), nil
+	return utils.TrimPage(pg, events, func(e *dto.EventListResponse) utils.Cursor {
+		return utils.Cursor{Time: e.EventStartTime, ID: e.ID}
+	}), nil
+}
+
+// whereEventStatus 按活动状态拼接查询条件，与服务层计算活动状态的规则保持一致
+// 返回是否按活动开始时间降序排列：未开始和进行中的活动按开始时间升序排列，其余按开始时间降序排列
+func whereEventStatus(query *gorm.DB, eventStatus string, now time.Time) (*gorm.DB, bool) {
+	switch eventStatus {
+	case model.EventStatusUpcoming:
+		return query.Where("e.is_cancelled = ? AND e.event_start_time > ?", utils.FlagNo, now), false
+	case model.EventStatusOngoing:
+		return query.Where("e.is_cancelled = ? AND e.event_start_time <= ? AND e.event_end_time >= ?", utils.FlagNo, now, now), false
+	case model.EventStatusEnded:
+		return query.Where("e.is_cancelled = ? AND e.event_end_time < ?", utils.FlagNo, now), true
+	case model.EventStatusCancelled:
+		return query.Where("e.is_cancelled = ?", utils.FlagYes), true
+	}
+	return query, true
+}
+
+// whereRegistrationStatus 按报名状态拼接查询条件，与服务层计算报名状态的规则保持一致
+func whereRegistrationStatus(query *gorm.DB, registrationStatus string, now time.Time) *gorm.DB {
+	switch registrationStatus {
+	case model.RegistrationStateNotOpen:
+		return query.Where("e.is_cancelled = ? AND e.registration_start_time > ?", utils.FlagNo, now)
+	case model.RegistrationStateOpen:
+		return query.Where("e.is_cancelled = ? AND e.registration_start_time <= ? AND e.registration_end_time >= ?", utils.FlagNo, now, now).
+			Where("(e.max_participants = 0 OR "+occupiedSeatsSQL+" < e.max_participants)", occupiedStatuses, utils.DeletedFlagNo)
+	case model.RegistrationStateFull:
+		return query.Where("e.is_cancelled = ? AND e.registration_start_time <= ? AND e.registration_end_time >= ?", utils.FlagNo, now, now).
+			Where("e.max_participants > 0 AND "+occupiedSeatsSQL+" >= e.max_participants", occupiedStatuses, utils.DeletedFlagNo)
+	case model.RegistrationStateClosed:
+		return query.Where("(e.is_cancelled = ? OR e.registration_end_time < ?)", utils.FlagYes, now)
+	}
+	return query
 }
 
 // GetEventDetail 获取活动详情
@@ -180,9 +248,28 @@ func (repo *EventRepositoryImpl) GetEventUserMap(ctx context.Context, eventID in
 	return &mapping, nil
 }
 
+// LockEventUserMap 在事务中查询并锁定活动-用户关联映射，映射不存在时返回nil
+func (repo *EventRepositoryImpl) LockEventUserMap(ctx context.Context, tx *gorm.DB, eventID int, userID int) (*model.EventUserMapping, error) {
+	var mapping model.EventUserMapping
+
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		First(&mapping).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &mapping, nil
+}
+
 // CreatEventUserMap 创建活动-用户关联映射,将用户添加到活动中
-func (repo *EventRepositoryImpl) CreatEventUserMap(ctx context.Context, eventUserMapping *model.EventUserMapping) error {
-	err := repo.db.WithContext(ctx).Create(eventUserMapping).Error
+func (repo *EventRepositoryImpl) CreatEventUserMap(ctx context.Context, tx *gorm.DB, eventUserMapping *model.EventUserMapping) error {
+	err := tx.WithContext(ctx).Create(eventUserMapping).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			return utils.NewBusinessError(utils.ErrCodeResourceExists, "已报名该活动，请勿重复报名")
@@ -193,14 +280,12 @@ func (repo *EventRepositoryImpl) CreatEventUserMap(ctx context.Context, eventUse
 	return nil
 }
 
-// UpdateEUMapDeleteFlag 更新活动-用户关联删除标志
-func (repo *EventRepositoryImpl) UpdateEUMapDeleteFlag(ctx context.Context, eventID int, userID int, isDeleted string) error {
-	result := repo.db.WithContext(ctx).Model(&model.EventUserMapping{}).
+// UpdateEventUserMap 更新活动-用户关联映射
+func (repo *EventRepositoryImpl) UpdateEventUserMap(ctx context.Context, tx *gorm.DB, eventID int, userID int, updateFields map[string]interface{}) error {
+	result := tx.WithContext(ctx).Model(&model.EventUserMapping{}).
 		Where("event_id = ?", eventID).
 		Where("user_id = ?", userID).
-		Updates(map[string]interface{}{
-			"is_deleted": isDeleted,
-		})
+		Updates(updateFields)
 
 	if result.Error != nil {
 		return utils.NewSystemError(fmt.Errorf("数据更新异常: %w", result.Error))
@@ -212,12 +297,100 @@ func (repo *EventRepositoryImpl) UpdateEUMapDeleteFlag(ctx context.Context, even
 	return nil
 }
 
+// LockEvent 在事务中锁定活动并查询完整的活动数据
+// 报名、取消报名和修改人数上限前先锁定活动，避免并发报名时超出人数上限
+// 调用方依据锁定后的删除、取消状态等字段做判断，因此查询全部字段，不能只查询部分列
+func (repo *EventRepositoryImpl) LockEvent(ctx context.Context, tx *gorm.DB, eventID int) (*model.Event, error) {
+	var event model.Event
+
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&event, eventID).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动不存在或已被删除，请刷新页面后重试")
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("锁定活动失败: %v", err))
+	}
+
+	return &event, nil
+}
+
+// CountOccupiedSeats 在事务中统计活动已占用的名额数，包含正式报名和待支付的用户，不包含候补用户
+func (repo *EventRepositoryImpl) CountOccupiedSeats(ctx context.Context, tx *gorm.DB, eventID int) (int64, error) {
+	var count int64
+	err := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Where("event_id = ? AND status IN ? AND is_deleted = ?", eventID, occupiedStatuses, utils.DeletedFlagNo).
+		Count(&count).Error
+
+	if err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("统计活动报名人数失败: %w", err))
+	}
+
+	return count, nil
+}
+
+// ListWaitlistedUserIDs 在事务中按进入候补的先后顺序查询候补用户ID
+func (repo *EventRepositoryImpl) ListWaitlistedUserIDs(ctx context.Context, tx *gorm.DB, eventID int, limit int) ([]int, error) {
+	var userIDs []int
+
+	query := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND status = ? AND is_deleted = ?", eventID, model.RegistrationStatusWaitlisted, utils.DeletedFlagNo).
+		Order("waitlist_time ASC").Order("id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询活动候补用户失败: %w", err))
+	}
+
+	return userIDs, nil
+}
+
+// PromoteWaitlistedUsers 将候补用户转为正式报名，收费活动转为待支付
+func (repo *EventRepositoryImpl) PromoteWaitlistedUsers(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, status string) error {
+	err := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Where("event_id = ? AND user_id IN ? AND status = ?", eventID, userIDs, model.RegistrationStatusWaitlisted).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"waitlist_time": nil,
+		}).Error
+
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("候补用户转为正式报名失败: %w", err))
+	}
+
+	return nil
+}
+
+// GetWaitlistPosition 查询候补用户在候补名单中的位置，从1开始
+func (repo *EventRepositoryImpl) GetWaitlistPosition(ctx context.Context, mapping *model.EventUserMapping) (int, error) {
+	var ahead int64
+	err := repo.db.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Where("event_id = ? AND status = ? AND is_deleted = ?", mapping.EventID, model.RegistrationStatusWaitlisted, utils.DeletedFlagNo).
+		Where("(waitlist_time < ? OR (waitlist_time = ? AND id < ?))", mapping.WaitlistTime, mapping.WaitlistTime, mapping.ID).
+		Count(&ahead).Error
+
+	if err != nil {
+		return 0, utils.NewSystemError(fmt.Errorf("查询候补位置失败: %w", err))
+	}
+
+	return int(ahead) + 1, nil
+}
+
 // IsUserRegistered 查询用户是否已报名活动
 func (repo *EventRepositoryImpl) IsUserRegistered(ctx context.Context, eventID int, userID int) (bool, error) {
 	var count int64
 	err := repo.db.WithContext(ctx).
 		Model(&model.EventUserMapping{}).
-		Where("event_id = ? AND user_id = ? AND is_deleted = ?", eventID, userID, utils.DeletedFlagNo).
+		Where("event_id = ? AND user_id = ? AND status = ? AND is_deleted = ?", eventID, userID, model.RegistrationStatusRegistered, utils.DeletedFlagNo).
 		Count(&count).Error
 
 	if err != nil {
@@ -227,8 +400,13 @@ func (repo *EventRepositoryImpl) IsUserRegistered(ctx context.Context, eventID i
 	return count > 0, nil
 }
 
-// ListUserRegisteredEvents 获取用户已报名活动列表
-func (repo *EventRepositoryImpl) ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string) ([]*model.Event, int, error) {
+// GetOccupiedSeats 统计活动已占用的名额数，不加锁，仅用于展示报名状态
+func (repo *EventRepositoryImpl) GetOccupiedSeats(ctx context.Context, eventID int) (int64, error) {
+	return repo.CountOccupiedSeats(ctx, repo.db, eventID)
+}
+
+// ListUserRegisteredEvents 获取用户已报名活动列表，同时统计各活动已占用的名额数
+func (repo *EventRepositoryImpl) ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string, registrationStatus string) ([]*dto.EventListResponse, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -237,27 +415,25 @@ func (repo *EventRepositoryImpl) ListUserRegisteredEvents(ctx context.Context, p
 	}
 
 	offset := (page - 1) * pageSize
-	var events []*model.Event
+	var events []*dto.EventListResponse
 	var total int64
 
 	query := repo.db.WithContext(ctx)
 
+	// 关联表只查询报名记录ID和报名时间，避免同名字段覆盖活动ID等字段
 	query = query.Table("events e").
+		Select("e.*, eum.id AS registration_id, eum.create_time AS registration_time, "+occupiedSeatsSQL+" AS occupied_seats", occupiedStatuses, utils.DeletedFlagNo).
 		Joins("JOIN event_user_mappings eum ON e.id = eum.event_id").
-		Where("eum.user_id = ? AND e.is_deleted = ? AND eum.is_deleted = ?", userID, utils.DeletedFlagNo, utils.DeletedFlagNo).
-		Find(&events)
-
-	// 根据活动状态拼接查询条件
-	if eventStatus == model.EventStatusInProgress {
-		// 进行中的活动：报名时间在当前时间范围内
-		query = query.Where("e.registration_start_time <= ? AND e.registration_end_time >= ?", time.Now(), time.Now())
-		// 按活动开始时间升序排列
-		query = query.Order("e.event_start_time ASC")
-	} else if eventStatus == model.EventStatusCompleted {
-		// 已结束的活动：报名截止时间在当前时间之前
-		query = query.Where("e.registration_end_time < ?", time.Now())
-		// 按活动开始时间降序排列
+		Where("eum.user_id = ? AND eum.status = ? AND e.is_deleted = ? AND eum.is_deleted = ?", userID, model.RegistrationStatusRegistered, utils.DeletedFlagNo, utils.DeletedFlagNo)
+
+	// 根据活动状态和报名状态拼接查询条件
+	now := time.Now()
+	query, desc := whereEventStatus(query, eventStatus, now)
+	query = whereRegistrationStatus(query, registrationStatus, now)
+	if desc {
 		query = query.Order("e.event_start_time DESC")
+	} else {
+		query = query.Order("e.event_start_time ASC")
 	}
 
 	// 计算总数
@@ -274,6 +450,45 @@ func (repo *EventRepositoryImpl) ListUserRegisteredEvents(ctx context.Context, p
 	return events, int(total), nil
 }
 
+// ListUserCalendarEvents 查询用户已报名的未删除活动，按开始时间降序排列
+func (repo *EventRepositoryImpl) ListUserCalendarEvents(ctx context.Context, userID int, limit int) ([]*model.Event, error) {
+	var events []*model.Event
+
+	err := repo.db.WithContext(ctx).
+		Table("events e").
+		Select("e.*").
+		Joins("JOIN event_user_mappings eum ON e.id = eum.event_id").
+		Where("eum.user_id = ? AND eum.status = ? AND e.is_deleted = ? AND eum.is_deleted = ?", userID, model.RegistrationStatusRegistered, utils.DeletedFlagNo, utils.DeletedFlagNo).
+		Order("e.event_start_time DESC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询用户已报名的活动失败: %w", err))
+	}
+
+	return events, nil
+}
+
+// ListUserCancelledEvents 查询用户已报名但已被删除的活动，只查询活动结束时间在since之后的活动
+func (repo *EventRepositoryImpl) ListUserCancelledEvents(ctx context.Context, userID int, since time.Time) ([]*model.Event, error) {
+	var events []*model.Event
+
+	err := repo.db.WithContext(ctx).
+		Table("events e").
+		Select("e.*").
+		Joins("JOIN event_user_mappings eum ON e.id = eum.event_id").
+		Where("eum.user_id = ? AND eum.status = ? AND e.is_deleted = ? AND eum.is_deleted = ?", userID, model.RegistrationStatusRegistered, utils.DeletedFlagYes, utils.DeletedFlagNo).
+		Where("e.event_end_time >= ?", since).
+		Order("e.event_start_time DESC").
+		Find(&events).Error
+
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询已取消的活动失败: %w", err))
+	}
+
+	return events, nil
+}
+
 // CreateEvent 创建活动
 func (repo *EventRepositoryImpl) CreateEvent(ctx context.Context, tx *gorm.DB, event *model.Event) error {
 	// 插入新活动
@@ -300,8 +515,40 @@ func (repo *EventRepositoryImpl) UpdateEvent(ctx context.Context, tx *gorm.DB, e
 	return nil
 }
 
-// ListEventRegisteredUser 查询已报名活动的用户列表
-func (repo *EventRepositoryImpl) ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error) {
+// ListEventRegisteredUser 按报名状态查询活动的报名用户列表
+func (repo *EventRepositoryImpl) ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int, status string) ([]*dto.ListEventRegUserResponse, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var users []*dto.ListEventRegUserResponse
+	var total int64
+
+	query := repo.regUserQuery(ctx, eventID).
+		Where("eum.status = ?", status)
+
+	// 计算总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 分页查询数据，候补用户按候补顺序排列，其余按报名先后排列
+	if status == model.RegistrationStatusWaitlisted {
+		query = query.Order("eum.waitlist_time ASC")
+	}
+	if err := query.Order("eum.id ASC").Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return users, int(total), nil
+}
+
+// ListNoShowUsers 查询活动正式报名但未签到的用户列表
+func (repo *EventRepositoryImpl) ListNoShowUsers(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -313,9 +560,44 @@ func (repo *EventRepositoryImpl) ListEventRegisteredUser(ctx context.Context, pa
 	var users []*dto.ListEventRegUserResponse
 	var total int64
 
-	query := repo.db.WithContext(ctx).
+	query := repo.regUserQuery(ctx, eventID).
+		Where("eum.status = ? AND eum.check_in_time IS NULL", model.RegistrationStatusRegistered)
+
+	// 计算总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 分页查询数据，按报名先后排列
+	if err := query.Order("eum.id ASC").Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return users, int(total), nil
+}
+
+// ListExportRegUsers 查询报名记录ID大于afterID的活动报名用户，按报名记录ID升序排列，status为空时查询全部报名用户
+func (repo *EventRepositoryImpl) ListExportRegUsers(ctx context.Context, eventID int, status string, afterID int, limit int) ([]*dto.ListEventRegUserResponse, error) {
+	var users []*dto.ListEventRegUserResponse
+
+	query := repo.regUserQuery(ctx, eventID).
+		Where("eum.id > ?", afterID)
+	if status != "" {
+		query = query.Where("eum.status = ?", status)
+	}
+
+	if err := query.Order("eum.id").Limit(limit).Find(&users).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询导出报名用户失败: %v", err))
+	}
+
+	return users, nil
+}
+
+// regUserQuery 构造活动报名用户的查询，包含用户资料和报名信息
+func (repo *EventRepositoryImpl) regUserQuery(ctx context.Context, eventID int) *gorm.DB {
+	return repo.db.WithContext(ctx).
 		Table("users u").
-		Select(`u.nickname, u.name, u.gender AS gender_code,
+		Select(`u.user_id, u.nickname, u.name, u.gender AS gender_code,
 				CASE
 					WHEN gender = 'M' THEN
 					'男'
@@ -323,29 +605,85 @@ func (repo *EventRepositoryImpl) ListEventRegisteredUser(ctx context.Context, pa
 					'女'
 					ELSE
 					'未知'
-				END AS gender, u.phone_number, u.email, u.unit, u.department, u.position, u.industry, i.industry_name`).
+				END AS gender, u.phone_number, u.email, u.unit, u.department, u.position, u.industry, i.industry_name, eum.answers,
+				eum.id AS registration_id, eum.status, eum.review_reason, eum.create_time, eum.check_in_time`).
 		Joins("JOIN event_user_mappings eum ON u.user_id = eum.user_id").
 		Joins("LEFT JOIN industries i ON u.industry = i.industry_code").
 		Where("eum.event_id = ? AND eum.is_deleted = ?", eventID, utils.DeletedFlagNo)
+}
 
-	// 计算总数
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+// CountAttendance 统计活动的正式报名人数和已签到人数
+func (repo *EventRepositoryImpl) CountAttendance(ctx context.Context, eventID int) (int64, int64, error) {
+	var result struct {
+		Registered int64
+		CheckedIn  int64
 	}
 
-	// 分页查询数据
-	if err := query.Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	if err := repo.db.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Select("COUNT(*) AS registered, COUNT(check_in_time) AS checked_in").
+		Where("event_id = ? AND status = ? AND is_deleted = ?", eventID, model.RegistrationStatusRegistered, utils.DeletedFlagNo).
+		Scan(&result).Error; err != nil {
+		return 0, 0, utils.NewSystemError(fmt.Errorf("统计活动签到人数失败: %w", err))
 	}
 
-	return users, int(total), nil
+	return result.Registered, result.CheckedIn, nil
+}
+
+// ListPendingUserIDs 在事务中查询并锁定指定用户中待审核的报名，按报名先后排列
+func (repo *EventRepositoryImpl) ListPendingUserIDs(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int) ([]int, error) {
+	var pending []int
+
+	if err := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND user_id IN ? AND status = ? AND is_deleted = ?", eventID, userIDs, model.RegistrationStatusPending, utils.DeletedFlagNo).
+		Order("id ASC").
+		Pluck("user_id", &pending).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询待审核报名失败: %w", err))
+	}
+
+	return pending, nil
+}
+
+// ListActiveUserIDs 在事务中查询并锁定指定状态的有效报名，返回报名用户ID
+func (repo *EventRepositoryImpl) ListActiveUserIDs(ctx context.Context, tx *gorm.DB, eventID int, statuses []string) ([]int, error) {
+	var userIDs []int
+
+	if err := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND status IN ? AND is_deleted = ?", eventID, statuses, utils.DeletedFlagNo).
+		Order("id ASC").
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询活动报名用户失败: %w", err))
+	}
+
+	return userIDs, nil
+}
+
+// UpdateEventUserMaps 批量更新活动-用户关联映射
+func (repo *EventRepositoryImpl) UpdateEventUserMaps(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, updateFields map[string]interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.WithContext(ctx).
+		Model(&model.EventUserMapping{}).
+		Where("event_id = ? AND user_id IN ?", eventID, userIDs).
+		Updates(updateFields).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("批量更新报名状态失败: %w", err))
+	}
+
+	return nil
 }
 
-// GetEventByTitle 根据活动标题查询活动
+// GetEventByTitle 根据活动标题查询未删除且不属于活动系列的活动
 func (repo *EventRepositoryImpl) GetEventByTitle(ctx context.Context, title string) (*model.Event, error) {
 	var event model.Event
 
-	result := repo.db.WithContext(ctx).Where("title = ? AND is_deleted = ?", title, utils.DeletedFlagNo).First(&event)
+	// 活动系列的各场次标题相同，不参与标题查重
+	result := repo.db.WithContext(ctx).Where("title = ? AND is_deleted = ? AND series_id = 0", title, utils.DeletedFlagNo).First(&event)
 	err := result.Error
 
 	if err != nil {
@@ -357,3 +695,46 @@ func (repo *EventRepositoryImpl) GetEventByTitle(ctx context.Context, title stri
 
 	return &event, nil
 }
+
+// GetEventBySlug 根据别名查询活动
+// 别名的唯一索引包含已删除的活动，因此查询时不过滤删除标志
+func (repo *EventRepositoryImpl) GetEventBySlug(ctx context.Context, slug string) (*model.Event, error) {
+	var event model.Event
+
+	if err := repo.db.WithContext(ctx).Select("id, slug, is_deleted").Where("slug = ?", slug).First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &event, nil
+}
+
+// ListEventsWithoutText 查询 detail_text 为空值的活动，包含已删除的活动
+func (repo *EventRepositoryImpl) ListEventsWithoutText(ctx context.Context, limit int) ([]model.Event, error) {
+	var events []model.Event
+
+	if err := repo.db.WithContext(ctx).
+		Select("id, detail").
+		Where("detail_text IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询待提取纯文本的活动失败: %v", err))
+	}
+
+	return events, nil
+}
+
+// UpdateEventDetailText 更新活动详情纯文本
+// 纯文本由详情派生，使用 UpdateColumn 避免变更 update_time 影响站点地图
+func (repo *EventRepositoryImpl) UpdateEventDetailText(ctx context.Context, eventID int, detailText string) error {
+	if err := repo.db.WithContext(ctx).
+		Model(&model.Event{}).
+		Where("id = ?", eventID).
+		UpdateColumn("detail_text", detailText).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("更新活动纯文本失败: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder 记录执行的SQL语句
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// newDryRunDB 创建只生成SQL、不连接数据库的 MySQL 会话
+func newDryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:pass@tcp(127.0.0.1:3306)/test?parseTime=true",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: recorder})
+	if err != nil {
+		t.Fatalf("创建数据库会话失败: %v", err)
+	}
+	return db, recorder
+}
+
+func TestLockEventLoadsFullRow(t *testing.T) {
+	db, recorder := newDryRunDB(t)
+	repo := &EventRepositoryImpl{db: db}
+
+	if _, err := repo.LockEvent(context.Background(), db, 1); err != nil {
+		t.Fatalf("锁定活动失败: %v", err)
+	}
+	if len(recorder.statements) != 1 {
+		t.Fatalf("执行了 %d 条SQL，期望1条: %v", len(recorder.statements), recorder.statements)
+	}
+	sql := recorder.statements[0]
+	// 调用方依据取消状态等字段做判断，只查询部分列会使未查询的字段为零值
+	if !strings.HasPrefix(sql, "SELECT * FROM `events`") {
+		t.Errorf("锁定活动应查询全部字段，实际SQL: %s", sql)
+	}
+	if !strings.HasSuffix(sql, "FOR UPDATE") {
+		t.Errorf("锁定活动应加行锁，实际SQL: %s", sql)
+	}
+}
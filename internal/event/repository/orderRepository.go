@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrderRepository 活动订单数据访问接口
+type OrderRepository interface {
+	// CreateOrder 创建订单
+	CreateOrder(ctx context.Context, tx *gorm.DB, order *model.EventOrder) error
+	// GetOrderByNo 根据订单号查询订单
+	GetOrderByNo(ctx context.Context, orderNo string) (*model.EventOrder, error)
+	// LockOrder 在事务中根据订单号查询并锁定订单
+	LockOrder(ctx context.Context, tx *gorm.DB, orderNo string) (*model.EventOrder, error)
+	// GetUserOrder 查询用户在活动中指定状态的最新订单
+	GetUserOrder(ctx context.Context, eventID int, userID int, status string) (*model.EventOrder, error)
+	// LockUserOrder 在事务中查询并锁定用户在活动中指定状态的最新订单
+	LockUserOrder(ctx context.Context, tx *gorm.DB, eventID int, userID int, status string) (*model.EventOrder, error)
+	// LockEventOrders 在事务中查询并锁定活动中指定状态的全部订单
+	LockEventOrders(ctx context.Context, tx *gorm.DB, eventID int, status string) ([]model.EventOrder, error)
+	// UpdateOrder 在事务中更新处于指定状态的订单
+	UpdateOrder(ctx context.Context, tx *gorm.DB, orderID int, status string, updateFields map[string]interface{}) error
+	// CompleteRefund 将退款中的订单标记为已退款
+	CompleteRefund(ctx context.Context, orderID int, refundTradeNo string) error
+	// ListExpiredOrders 按ID升序分批查询超过支付截止时间的待支付订单
+	ListExpiredOrders(ctx context.Context, before time.Time, afterID int, limit int) ([]model.EventOrder, error)
+	// ListRefundingOrders 按ID升序分批查询退款中的订单
+	ListRefundingOrders(ctx context.Context, afterID int, limit int) ([]model.EventOrder, error)
+	// ListUserOrders 分页查询用户的订单
+	ListUserOrders(ctx context.Context, page, pageSize int, userID int, status string) ([]*dto.OrderResponse, int, error)
+}
+
+// OrderRepositoryImpl 实现接口的具体结构体
+type OrderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOrderRepository 创建数据访问实例
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &OrderRepositoryImpl{db: db}
+}
+
+// CreateOrder 创建订单
+func (repo *OrderRepositoryImpl) CreateOrder(ctx context.Context, tx *gorm.DB, order *model.EventOrder) error {
+	if err := tx.WithContext(ctx).Create(order).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建订单失败: %w", err))
+	}
+	return nil
+}
+
+// GetOrderByNo 根据订单号查询订单，订单不存在时返回nil
+func (repo *OrderRepositoryImpl) GetOrderByNo(ctx context.Context, orderNo string) (*model.EventOrder, error) {
+	return findOrder(repo.db.WithContext(ctx).Where("order_no = ?", orderNo))
+}
+
+// LockOrder 在事务中根据订单号查询并锁定订单，订单不存在时返回nil
+func (repo *OrderRepositoryImpl) LockOrder(ctx context.Context, tx *gorm.DB, orderNo string) (*model.EventOrder, error) {
+	return findOrder(tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_no = ?", orderNo))
+}
+
+// GetUserOrder 查询用户在活动中指定状态的最新订单，订单不存在时返回nil
+func (repo *OrderRepositoryImpl) GetUserOrder(ctx context.Context, eventID int, userID int, status string) (*model.EventOrder, error) {
+	return findOrder(repo.db.WithContext(ctx).
+		Where("event_id = ? AND user_id = ? AND status = ?", eventID, userID, status).
+		Order("id DESC"))
+}
+
+// LockUserOrder 在事务中查询并锁定用户在活动中指定状态的最新订单，订单不存在时返回nil
+func (repo *OrderRepositoryImpl) LockUserOrder(ctx context.Context, tx *gorm.DB, eventID int, userID int, status string) (*model.EventOrder, error) {
+	return findOrder(tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND user_id = ? AND status = ?", eventID, userID, status).
+		Order("id DESC"))
+}
+
+// LockEventOrders 在事务中查询并锁定活动中指定状态的全部订单，按ID升序排列
+func (repo *OrderRepositoryImpl) LockEventOrders(ctx context.Context, tx *gorm.DB, eventID int, status string) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND status = ?", eventID, status).
+		Order("id").
+		Find(&orders).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询活动订单失败: %v", err))
+	}
+
+	return orders, nil
+}
+
+// findOrder 按查询条件查询一个订单，订单不存在时返回nil
+func findOrder(query *gorm.DB) (*model.EventOrder, error) {
+	var order model.EventOrder
+	if err := query.First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询订单失败: %v", err))
+	}
+	return &order, nil
+}
+
+// UpdateOrder 在事务中更新处于指定状态的订单，订单状态已变化时返回错误
+func (repo *OrderRepositoryImpl) UpdateOrder(ctx context.Context, tx *gorm.DB, orderID int, status string, updateFields map[string]interface{}) error {
+	result := tx.WithContext(ctx).Model(&model.EventOrder{}).
+		Where("id = ? AND status = ?", orderID, status).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新订单失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict, "订单状态已变化，请刷新页面后重试")
+	}
+	return nil
+}
+
+// CompleteRefund 将退款中的订单标记为已退款，订单已不是退款中时不做处理
+func (repo *OrderRepositoryImpl) CompleteRefund(ctx context.Context, orderID int, refundTradeNo string) error {
+	err := repo.db.WithContext(ctx).Model(&model.EventOrder{}).
+		Where("id = ? AND status = ?", orderID, model.OrderStatusRefunding).
+		Updates(map[string]interface{}{
+			"status":          model.OrderStatusRefunded,
+			"refund_trade_no": refundTradeNo,
+			"refund_time":     time.Now(),
+		}).Error
+
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("更新订单退款结果失败: %w", err))
+	}
+	return nil
+}
+
+// ListExpiredOrders 查询ID大于afterID且超过支付截止时间的待支付订单，按ID升序排列
+func (repo *OrderRepositoryImpl) ListExpiredOrders(ctx context.Context, before time.Time, afterID int, limit int) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+
+	if err := repo.db.WithContext(ctx).
+		Where("id > ? AND status = ? AND expire_time < ?", afterID, model.OrderStatusPending, before).
+		Order("id").Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询超时订单失败: %v", err))
+	}
+
+	return orders, nil
+}
+
+// ListRefundingOrders 查询ID大于afterID的退款中订单，按ID升序排列
+func (repo *OrderRepositoryImpl) ListRefundingOrders(ctx context.Context, afterID int, limit int) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+
+	if err := repo.db.WithContext(ctx).
+		Where("id > ? AND status = ?", afterID, model.OrderStatusRefunding).
+		Order("id").Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询退款中订单失败: %v", err))
+	}
+
+	return orders, nil
+}
+
+// ListUserOrders 分页查询用户的订单，按下单时间倒序排列
+func (repo *OrderRepositoryImpl) ListUserOrders(ctx context.Context, page, pageSize int, userID int, status string) ([]*dto.OrderResponse, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var orders []*dto.OrderResponse
+	var total int64
+
+	query := repo.db.WithContext(ctx).
+		Table("event_orders o").
+		Select(`o.order_no, o.event_id, e.title AS event_title, o.amount / 100 AS amount, o.status, o.expire_time,
+				o.paid_time, o.refund_amount / 100 AS refund_amount, o.refund_reason, o.refund_time, o.create_time`).
+		Joins("LEFT JOIN events e ON o.event_id = e.id").
+		Where("o.user_id = ?", userID)
+	if status != "" {
+		query = query.Where("o.status = ?", status)
+	}
+
+	// 计算总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 分页查询数据
+	if err := query.Order("o.id DESC").Offset(offset).Limit(pageSize).Find(&orders).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return orders, int(total), nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reminderEventColumns 发送提醒时需要的活动字段
+const reminderEventColumns = "id, title, event_start_time, event_end_time, registration_start_time, registration_end_time, event_address, create_user"
+
+// ReminderRepository 活动提醒数据访问接口
+type ReminderRepository interface {
+	// ListStartingEvents 查询开始时间在指定区间内的未删除且未取消的活动
+	ListStartingEvents(ctx context.Context, from, to time.Time) ([]*model.Event, error)
+	// ListClosingEvents 查询报名截止时间在指定区间内且已开放报名的未删除且未取消的活动
+	ListClosingEvents(ctx context.Context, from, to time.Time) ([]*model.Event, error)
+	// CreateReminder 记录待发送的提醒，已记录过相同提醒时返回false
+	CreateReminder(ctx context.Context, reminder *model.EventReminder) (bool, error)
+	// UpdateReminderMessage 记录提醒发送的消息ID
+	UpdateReminderMessage(ctx context.Context, reminderID int, messageID int) error
+	// DeleteReminder 删除提醒记录，发送失败时删除以便下次重试
+	DeleteReminder(ctx context.Context, reminderID int) error
+}
+
+// ReminderRepositoryImpl 实现接口的具体结构体
+type ReminderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository 创建数据访问实例
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &ReminderRepositoryImpl{db: db}
+}
+
+// ListStartingEvents 查询开始时间在(from, to]区间内的未删除且未取消的活动
+func (repo *ReminderRepositoryImpl) ListStartingEvents(ctx context.Context, from, to time.Time) ([]*model.Event, error) {
+	var events []*model.Event
+
+	err := repo.db.WithContext(ctx).
+		Select(reminderEventColumns).
+		Where("is_deleted = ? AND is_cancelled = ? AND event_start_time > ? AND event_start_time <= ?", utils.DeletedFlagNo, utils.FlagNo, from, to).
+		Find(&events).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询即将开始的活动失败: %w", err))
+	}
+
+	return events, nil
+}
+
+// ListClosingEvents 查询报名截止时间在(from, to]区间内且已开放报名的未删除且未取消的活动
+func (repo *ReminderRepositoryImpl) ListClosingEvents(ctx context.Context, from, to time.Time) ([]*model.Event, error) {
+	var events []*model.Event
+
+	err := repo.db.WithContext(ctx).
+		Select(reminderEventColumns).
+		Where("is_deleted = ? AND is_cancelled = ? AND registration_start_time <= ? AND registration_end_time > ? AND registration_end_time <= ?",
+			utils.DeletedFlagNo, utils.FlagNo, from, from, to).
+		Find(&events).Error
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询报名即将截止的活动失败: %w", err))
+	}
+
+	return events, nil
+}
+
+// CreateReminder 记录待发送的提醒，依赖唯一索引保证同一提醒只记录一次
+func (repo *ReminderRepositoryImpl) CreateReminder(ctx context.Context, reminder *model.EventReminder) (bool, error) {
+	result := repo.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		return false, utils.NewSystemError(fmt.Errorf("记录活动提醒失败: %w", result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateReminderMessage 记录提醒发送的消息ID
+func (repo *ReminderRepositoryImpl) UpdateReminderMessage(ctx context.Context, reminderID int, messageID int) error {
+	err := repo.db.WithContext(ctx).Model(&model.EventReminder{}).
+		Where("id = ?", reminderID).
+		Update("message_id", messageID).Error
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("更新活动提醒失败: %w", err))
+	}
+	return nil
+}
+
+// DeleteReminder 删除提醒记录
+func (repo *ReminderRepositoryImpl) DeleteReminder(ctx context.Context, reminderID int) error {
+	if err := repo.db.WithContext(ctx).Delete(&model.EventReminder{}, reminderID).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("删除活动提醒记录失败: %w", err))
+	}
+	return nil
+}
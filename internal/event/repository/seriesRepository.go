@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// SeriesRepository 活动系列数据访问接口
+type SeriesRepository interface {
+	// CreateSeries 创建活动系列
+	CreateSeries(ctx context.Context, tx *gorm.DB, series *model.EventSeries) error
+	// GetSeries 根据ID查询活动系列
+	GetSeries(ctx context.Context, seriesID int) (*model.EventSeries, error)
+	// GetSeriesByTitle 根据标题查询未删除的活动系列
+	GetSeriesByTitle(ctx context.Context, title string) (*model.EventSeries, error)
+	// UpdateSeries 更新活动系列
+	UpdateSeries(ctx context.Context, tx *gorm.DB, seriesID int, updateFields map[string]interface{}) error
+	// ListSeries 分页查询未删除的活动系列
+	ListSeries(ctx context.Context, page, pageSize int) ([]*model.EventSeries, int, error)
+	// ListOccurrences 按开始时间查询系列的全部场次，包含已删除的场次
+	ListOccurrences(ctx context.Context, seriesID int) ([]*model.Event, error)
+	// ListSeriesImage 获取系列各场次共用的图片列表
+	ListSeriesImage(ctx context.Context, seriesID int) []dto.Image
+}
+
+// SeriesRepositoryImpl 实现接口的具体结构体
+type SeriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository 创建数据访问实例
+func NewSeriesRepository(db *gorm.DB) SeriesRepository {
+	return &SeriesRepositoryImpl{db: db}
+}
+
+// CreateSeries 创建活动系列
+func (repo *SeriesRepositoryImpl) CreateSeries(ctx context.Context, tx *gorm.DB, series *model.EventSeries) error {
+	if err := tx.WithContext(ctx).Create(series).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("创建活动系列失败: %w", err))
+	}
+	return nil
+}
+
+// GetSeries 根据ID查询活动系列，包含已删除的系列
+func (repo *SeriesRepositoryImpl) GetSeries(ctx context.Context, seriesID int) (*model.EventSeries, error) {
+	var series model.EventSeries
+
+	if err := repo.db.WithContext(ctx).First(&series, seriesID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动系列不存在或已被删除，请刷新页面后重试")
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &series, nil
+}
+
+// GetSeriesByTitle 根据标题查询未删除的活动系列，不存在时返回nil
+func (repo *SeriesRepositoryImpl) GetSeriesByTitle(ctx context.Context, title string) (*model.EventSeries, error) {
+	var series model.EventSeries
+
+	err := repo.db.WithContext(ctx).Where("title = ? AND is_deleted = ?", title, utils.DeletedFlagNo).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return &series, nil
+}
+
+// UpdateSeries 更新活动系列
+func (repo *SeriesRepositoryImpl) UpdateSeries(ctx context.Context, tx *gorm.DB, seriesID int, updateFields map[string]interface{}) error {
+	result := tx.WithContext(ctx).Model(&model.EventSeries{}).
+		Where("id = ?", seriesID).
+		Updates(updateFields)
+
+	if result.Error != nil {
+		return utils.NewSystemError(fmt.Errorf("更新活动系列失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动系列不存在或已被删除，请刷新页面后重试")
+	}
+
+	return nil
+}
+
+// ListSeries 分页查询未删除的活动系列，按创建时间倒序排列
+func (repo *SeriesRepositoryImpl) ListSeries(ctx context.Context, page, pageSize int) ([]*model.EventSeries, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	var series []*model.EventSeries
+	var total int64
+
+	query := repo.db.WithContext(ctx).Model(&model.EventSeries{}).Where("is_deleted = ?", utils.DeletedFlagNo)
+
+	// 计算总数
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 分页查询数据，列表不返回详情
+	if err := query.Omit("detail").Order("id DESC").Offset(offset).Limit(pageSize).Find(&series).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	return series, int(total), nil
+}
+
+// ListOccurrences 按开始时间查询系列的全部场次，包含已删除的场次，不查询活动详情
+func (repo *SeriesRepositoryImpl) ListOccurrences(ctx context.Context, seriesID int) ([]*model.Event, error) {
+	var events []*model.Event
+
+	err := repo.db.WithContext(ctx).
+		Select("id, series_id, title, event_start_time, event_end_time, registration_start_time, registration_end_time, series_overridden, is_cancelled, is_deleted").
+		Where("series_id = ?", seriesID).
+		Order("event_start_time ASC").
+		Find(&events).Error
+
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询活动系列场次失败: %v", err))
+	}
+
+	return events, nil
+}
+
+// ListSeriesImage 获取系列各场次共用的图片列表
+func (repo *SeriesRepositoryImpl) ListSeriesImage(ctx context.Context, seriesID int) []dto.Image {
+	var images []dto.Image
+
+	err := repo.db.WithContext(ctx).
+		Table("images").
+		Select("id AS image_id, url").
+		Where("biz_type = ? AND biz_id = ?", utils.TypeEventSeries, seriesID).
+		Find(&images).Error
+
+	if err != nil {
+		logrus.Errorf("获取活动系列图片失败: %v", err) // 只记录异常，不影响活动信息的返回
+		return nil
+	}
+
+	return images
+}
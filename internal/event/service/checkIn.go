@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+)
+
+// checkInQRCodeSize 签到二维码图片的边长，单位像素
+const checkInQRCodeSize = 256
+
+// signCheckIn 计算签到码签名
+func (svc *EventServiceImpl) signCheckIn(payload string) string {
+	mac := hmac.New(sha256.New, svc.checkInSecret)
+	mac.Write([]byte("check_in:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// checkInClaims 签到码中携带的报名信息
+type checkInClaims struct {
+	EventID          int   // 活动ID
+	UserID           int   // 用户ID
+	RegistrationID   int   // 报名记录ID
+	RegistrationTime int64 // 报名时间，Unix秒
+}
+
+// checkInToken 生成签到码，格式为 活动ID.用户ID.报名记录ID.报名时间.签名
+// 签到码与具体的报名记录绑定，取消后重新报名会更新报名时间，之前的签到码随之失效
+func (svc *EventServiceImpl) checkInToken(eventID int, userID int, registrationID int, registrationTime time.Time) string {
+	payload := fmt.Sprintf("%d.%d.%d.%d", eventID, userID, registrationID, registrationTime.Unix())
+	return payload + "." + svc.signCheckIn(payload)
+}
+
+// parseCheckInToken 校验签到码签名，返回签到码中携带的报名信息
+func (svc *EventServiceImpl) parseCheckInToken(token string) (*checkInClaims, error) {
+	invalid := utils.NewBusinessError(utils.ErrCodeParamInvalid, "签到码无效")
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 5 {
+		return nil, invalid
+	}
+	payload := strings.Join(parts[:4], ".")
+	if !hmac.Equal([]byte(parts[4]), []byte(svc.signCheckIn(payload))) {
+		return nil, invalid
+	}
+
+	values := make([]int64, 4)
+	for i, part := range parts[:4] {
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		values[i] = value
+	}
+	return &checkInClaims{
+		EventID:          int(values[0]),
+		UserID:           int(values[1]),
+		RegistrationID:   int(values[2]),
+		RegistrationTime: values[3],
+	}, nil
+}
+
+// GetCheckInQRCode 生成用户的活动签到二维码图片，只有报名已生效的用户可以获取
+func (svc *EventServiceImpl) GetCheckInQRCode(ctx context.Context, eventID int, userID int) ([]byte, error) {
+	mapping, err := svc.eventRepo.GetEventUserMap(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes || mapping.Status != model.RegistrationStatusRegistered {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "未报名该活动或报名未生效，无法获取签到码")
+	}
+
+	token := svc.checkInToken(eventID, userID, mapping.ID, mapping.CreateTime)
+	png, err := qrcode.Encode(token, qrcode.Medium, checkInQRCodeSize)
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("生成签到二维码失败: %w", err))
+	}
+	return png, nil
+}
+
+// CheckIn 工作人员扫码签到，校验签到码、签到时间和报名状态，同一用户只能签到一次
+func (svc *EventServiceImpl) CheckIn(ctx context.Context, token string, operateUser int) (*dto.CheckInResponse, error) {
+	claims, err := svc.parseCheckInToken(token)
+	if err != nil {
+		return nil, err
+	}
+	eventID, userID := claims.EventID, claims.UserID
+
+	now := time.Now()
+	var event *model.Event
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		event, err = svc.eventRepo.LockEvent(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+		if event.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
+		}
+		if event.IsCancelled == utils.FlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消，无法签到")
+		}
+
+		// 活动开始前一段时间内至活动结束前可以签到
+		if now.Before(event.EventStartTime.Add(-svc.checkInOpenBefore)) {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError,
+				fmt.Sprintf("签到尚未开始，请于%s后签到", event.EventStartTime.Add(-svc.checkInOpenBefore).Format(time.DateTime)))
+		}
+		if now.After(event.EventEndTime) {
+			return utils.NewBusinessError(utils.ErrCodeResourceExpired, "活动已结束，无法签到")
+		}
+
+		mapping, err := svc.eventRepo.LockEventUserMap(ctx, tx, eventID, userID)
+		if err != nil {
+			return err
+		}
+		if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes || mapping.Status != model.RegistrationStatusRegistered {
+			return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "该用户未报名此活动或报名未生效")
+		}
+		// 取消后重新报名时报名时间会更新，旧的签到码不能再使用
+		if mapping.ID != claims.RegistrationID || mapping.CreateTime.Unix() != claims.RegistrationTime {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "签到码已失效，请重新获取")
+		}
+		if mapping.CheckInTime != nil {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists,
+				fmt.Sprintf("该用户已于%s签到，请勿重复签到", mapping.CheckInTime.Format(time.DateTime)))
+		}
+
+		return svc.eventRepo.UpdateEventUserMap(ctx, tx, eventID, userID, map[string]interface{}{
+			"check_in_time": now,
+			"check_in_user": operateUser,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.CheckInResponse{
+		EventID:     eventID,
+		EventTitle:  event.Title,
+		UserID:      userID,
+		CheckInTime: now,
+	}
+	// 返回用户信息供工作人员核对身份，查询失败不影响签到结果
+	if user, err := svc.userRepo.GetUserByID(ctx, userID); err == nil && user != nil {
+		result.Name = user.Name
+		result.Nickname = user.Nickname
+	}
+	return result, nil
+}
+
+// GetAttendance 统计活动的报名和签到人数
+func (svc *EventServiceImpl) GetAttendance(ctx context.Context, eventID int) (*dto.AttendanceResponse, error) {
+	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, checkedIn, err := svc.eventRepo.CountAttendance(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.AttendanceResponse{
+		EventID:         event.ID,
+		Title:           event.Title,
+		RegisteredCount: int(registered),
+		CheckedInCount:  int(checkedIn),
+		NoShowCount:     int(registered - checkedIn),
+	}
+	if registered > 0 {
+		result.AttendanceRate = math.Round(float64(checkedIn)*10000/float64(registered)) / 100
+	}
+	return result, nil
+}
+
+// ListNoShowUsers 获取活动正式报名但未签到的用户列表
+func (svc *EventServiceImpl) ListNoShowUsers(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error) {
+	return svc.eventRepo.ListNoShowUsers(ctx, page, pageSize, eventID)
+}
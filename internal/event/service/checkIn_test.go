@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// registeredToken 报名免费活动并返回签到码，活动在一小时后开始，已可以签到
+func registeredToken(t *testing.T, env *testEnv, userID int) (int, string) {
+	t.Helper()
+	event := env.addEvent(0, 0)
+	env.register(t, event.ID, userID)
+	event.EventStartTime = time.Now().Add(time.Hour)
+	event.EventEndTime = time.Now().Add(3 * time.Hour)
+
+	m := env.events.mapping(event.ID, userID)
+	return event.ID, env.svc.checkInToken(event.ID, userID, m.ID, m.CreateTime)
+}
+
+func TestCheckInCancelledEvent(t *testing.T) {
+	env := newTestEnv(t)
+	eventID, token := registeredToken(t, env, 101)
+	if err := env.svc.CancelEvent(context.Background(), eventID, "天气原因", 1); err != nil {
+		t.Fatalf("取消活动失败: %v", err)
+	}
+
+	_, err := env.svc.CheckIn(context.Background(), token, 1)
+	assertBusinessError(t, err, "活动已取消")
+	if m := env.events.mapping(eventID, 101); m.CheckInTime != nil {
+		t.Error("已取消的活动记录了签到时间")
+	}
+}
+
+func TestCheckInTokenRoundTrip(t *testing.T) {
+	svc := &EventServiceImpl{checkInSecret: []byte("test-check-in-secret")}
+	registered := time.Date(2026, time.April, 1, 9, 30, 0, 0, time.Local)
+	token := svc.checkInToken(12, 34, 56, registered)
+
+	claims, err := svc.parseCheckInToken(" " + token + "\n")
+	if err != nil {
+		t.Fatalf("解析签到码失败: %v", err)
+	}
+	want := checkInClaims{EventID: 12, UserID: 34, RegistrationID: 56, RegistrationTime: registered.Unix()}
+	if *claims != want {
+		t.Errorf("签到码信息为 %+v，期望 %+v", *claims, want)
+	}
+
+	other := &EventServiceImpl{checkInSecret: []byte("other-secret")}
+	parts := strings.Split(token, ".")
+	tests := map[string]string{
+		"修改用户ID":   strings.Join(append([]string{parts[0], "35"}, parts[2:]...), "."),
+		"缺少签名":     strings.Join(parts[:4], "."),
+		"其他密钥签名":   other.checkInToken(12, 34, 56, registered),
+		"报名信息不是数字": "a.34.56.1.x",
+	}
+	for name, tampered := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.parseCheckInToken(tampered)
+			assertBusinessError(t, err, "签到码无效")
+		})
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	env := newTestEnv(t)
+	eventID, token := registeredToken(t, env, 101)
+	ctx := context.Background()
+
+	res, err := env.svc.CheckIn(ctx, token, 1)
+	if err != nil {
+		t.Fatalf("签到失败: %v", err)
+	}
+	if res.EventID != eventID || res.UserID != 101 || res.Name == "" {
+		t.Errorf("签到结果为 %+v", res)
+	}
+	if m := env.events.mapping(eventID, 101); m.CheckInTime == nil || m.CheckInUser != 1 {
+		t.Errorf("未记录签到时间和签到人: %+v", m)
+	}
+
+	_, err = env.svc.CheckIn(ctx, token, 1)
+	assertBusinessError(t, err, "请勿重复签到")
+}
+
+func TestCheckInAfterReRegistration(t *testing.T) {
+	env := newTestEnv(t)
+	eventID, token := registeredToken(t, env, 101)
+	ctx := context.Background()
+
+	// 取消后重新报名，报名时间更新，旧签到码失效
+	if err := env.svc.CancelRegistrationEvent(ctx, eventID, 101); err != nil {
+		t.Fatalf("取消报名失败: %v", err)
+	}
+	env.register(t, eventID, 101)
+	m := env.events.mapping(eventID, 101)
+	m.CreateTime = m.CreateTime.Add(time.Second) // 签到码精确到秒，模拟重新报名发生在之后的时间
+
+	_, err := env.svc.CheckIn(ctx, token, 1)
+	assertBusinessError(t, err, "签到码已失效")
+
+	fresh := env.svc.checkInToken(eventID, 101, m.ID, m.CreateTime)
+	if _, err := env.svc.CheckIn(ctx, fresh, 1); err != nil {
+		t.Errorf("使用重新获取的签到码签到失败: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"news-release/internal/content"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	calendarProdID           = "-//news-release//Event Calendar//ZH"
+	calendarName             = "已报名的活动"
+	calendarMaxEvents        = 500                 // 订阅日历最多包含的活动数，超出时只保留开始时间最晚的活动
+	calendarCancelledWithin  = 30 * 24 * time.Hour // 已删除的活动结束后仍以已取消状态保留在订阅日历中的时长
+	calendarRefreshInterval  = "PT1H"              // 建议客户端刷新订阅日历的间隔
+	calendarDescMaxLength    = 1000                // 日历中活动描述的最大字数
+	calendarLineMaxOctets    = 75                  // iCalendar 内容行折行前的最大字节数
+	calendarTimeFormat       = "20060102T150405Z"
+	calendarSubscriptionPath = "/api/event/calendar/"
+)
+
+// calendarFields 日历中展示的活动字段，变更时递增活动的修订序号
+var calendarFields = []string{"title", "detail", "event_start_time", "event_end_time", "event_address"}
+
+// calendarEscaper 转义 iCalendar 文本值中的特殊字符
+var calendarEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// signCalendar 计算日历订阅令牌签名
+func (svc *EventServiceImpl) signCalendar(payload string) string {
+	mac := hmac.New(sha256.New, svc.calendarSecret)
+	mac.Write([]byte("calendar:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// calendarToken 生成日历订阅令牌，格式为 用户ID.签名
+func (svc *EventServiceImpl) calendarToken(userID int) string {
+	payload := strconv.Itoa(userID)
+	return payload + "." + svc.signCalendar(payload)
+}
+
+// parseCalendarToken 校验日历订阅令牌签名，返回用户ID，兼容带 .ics 后缀的令牌
+func (svc *EventServiceImpl) parseCalendarToken(token string) (int, error) {
+	invalid := utils.NewBusinessError(utils.ErrCodeResourceNotFound, "日历订阅链接无效")
+
+	parts := strings.Split(strings.TrimSuffix(token, ".ics"), ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(svc.signCalendar(parts[0]))) {
+		return 0, invalid
+	}
+	userID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, invalid
+	}
+	return userID, nil
+}
+
+// GetCalendarSubscription 生成用户的日历订阅链接，链接长期有效，无需登录即可访问
+func (svc *EventServiceImpl) GetCalendarSubscription(userID int, siteURL string) *dto.CalendarSubscription {
+	subscriptionURL := svc.siteCfg.BaseURL(siteURL) + calendarSubscriptionPath + svc.calendarToken(userID) + ".ics"
+
+	webcalURL := subscriptionURL
+	if i := strings.Index(webcalURL, "://"); i >= 0 {
+		webcalURL = "webcal" + webcalURL[i:]
+	}
+	return &dto.CalendarSubscription{URL: subscriptionURL, WebcalURL: webcalURL}
+}
+
+// GetEventCalendar 生成包含单个活动的iCalendar文件，已删除或已取消的活动标记为已取消
+func (svc *EventServiceImpl) GetEventCalendar(ctx context.Context, eventID int, siteURL string) ([]byte, error) {
+	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return svc.renderCalendar(svc.siteCfg.BaseURL(siteURL), "", []*model.Event{event}), nil
+}
+
+// GetUserCalendar 根据订阅令牌生成用户已报名活动的日历
+// 近期被删除的活动以已取消状态保留，客户端刷新后将其从日历中标记为取消
+func (svc *EventServiceImpl) GetUserCalendar(ctx context.Context, token string, siteURL string) ([]byte, error) {
+	userID, err := svc.parseCalendarToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := svc.eventRepo.ListUserCalendarEvents(ctx, userID, calendarMaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := svc.eventRepo.ListUserCancelledEvents(ctx, userID, time.Now().Add(-calendarCancelledWithin))
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.renderCalendar(svc.siteCfg.BaseURL(siteURL), calendarName, append(events, cancelled...)), nil
+}
+
+// renderCalendar 将活动渲染为iCalendar格式，name不为空时作为订阅日历的名称
+func (svc *EventServiceImpl) renderCalendar(baseURL string, name string, events []*model.Event) []byte {
+	host := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	stamp := time.Now().UTC().Format(calendarTimeFormat)
+
+	var buf bytes.Buffer
+	line := func(name string, value string) {
+		writeCalendarLine(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", calendarProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if name != "" {
+		line("X-WR-CALNAME", calendarEscaper.Replace(name))
+		line("REFRESH-INTERVAL;VALUE=DURATION", calendarRefreshInterval)
+		line("X-PUBLISHED-TTL", calendarRefreshInterval)
+	}
+
+	for _, event := range events {
+		status := "CONFIRMED"
+		if event.IsDeleted == utils.DeletedFlagYes || event.IsCancelled == utils.FlagYes {
+			status = "CANCELLED"
+		}
+		detailText := event.DetailText
+		if detailText == "" {
+			detailText = utils.PlainText(event.Detail) // 历史数据尚未补全纯文本
+		}
+		link := svc.siteCfg.EventLink(baseURL, event.ID, event.Slug)
+
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("event-%d@%s", event.ID, host))
+		line("DTSTAMP", stamp)
+		line("DTSTART", event.EventStartTime.UTC().Format(calendarTimeFormat))
+		line("DTEND", event.EventEndTime.UTC().Format(calendarTimeFormat))
+		line("SEQUENCE", strconv.Itoa(event.Sequence))
+		line("STATUS", status)
+		line("SUMMARY", calendarEscaper.Replace(event.Title))
+		line("LOCATION", calendarEscaper.Replace(event.EventAddress))
+		line("DESCRIPTION", calendarEscaper.Replace(content.Summary(detailText, calendarDescMaxLength)))
+		line("URL", link)
+		line("LAST-MODIFIED", event.UpdateTime.UTC().Format(calendarTimeFormat))
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeCalendarLine 写入一个内容行，超过75字节时折行，续行以空格开头，不拆分多字节字符
+func writeCalendarLine(buf *bytes.Buffer, contentLine string) {
+	limit := calendarLineMaxOctets
+	for len(contentLine) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(contentLine[cut]) {
+			cut--
+		}
+		buf.WriteString(contentLine[:cut])
+		buf.WriteString("\r\n ")
+		contentLine = contentLine[cut:]
+		limit = calendarLineMaxOctets - 1 // 续行开头的空格占一个字节
+	}
+	buf.WriteString(contentLine)
+	buf.WriteString("\r\n")
+}
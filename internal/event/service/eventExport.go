@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// regExportBatchSize 导出时每批查询的报名用户数
+const regExportBatchSize = 500
+
+// utf8BOM 导出CSV时写入的字节顺序标记，便于 Excel 正确识别中文
+const utf8BOM = "\ufeff"
+
+// regExportSheet 导出Excel文件的工作表名称
+const regExportSheet = "报名用户"
+
+// regExportColumns 报名用户导出文件的固定列，报名表单附加问题的列追加在后面
+var regExportColumns = []string{
+	"用户ID", "昵称", "姓名", "性别", "手机号", "邮箱", "单位", "部门", "职位", "行业",
+	"报名状态", "审核意见", "报名时间", "签到时间",
+}
+
+// registrationStatusNames 报名状态的中文名称
+var registrationStatusNames = map[string]string{
+	model.RegistrationStatusRegistered: "已报名",
+	model.RegistrationStatusWaitlisted: "候补中",
+	model.RegistrationStatusPending:    "待审核",
+	model.RegistrationStatusRejected:   "审核未通过",
+	model.RegistrationStatusUnpaid:     "待支付",
+	model.RegistrationStatusCancelled:  "活动已取消",
+}
+
+// rowWriter 逐行写入导出文件
+type rowWriter interface {
+	// WriteRow 写入一行
+	WriteRow(values []string) error
+	// Flush 写入剩余内容
+	Flush() error
+	// Close 释放占用的资源
+	Close() error
+}
+
+// csvRowWriter 写入CSV文件
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+// newCSVRowWriter 创建CSV写入器，先写入字节顺序标记
+func newCSVRowWriter(w io.Writer) (*csvRowWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+	return &csvRowWriter{writer: csv.NewWriter(w)}, nil
+}
+
+// WriteRow 写入一行
+func (c *csvRowWriter) WriteRow(values []string) error {
+	return c.writer.Write(values)
+}
+
+// Flush 写入缓冲区中的内容
+func (c *csvRowWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// Close CSV写入器不占用额外资源
+func (c *csvRowWriter) Close() error {
+	return nil
+}
+
+// xlsxRowWriter 以流式方式写入Excel文件，超出内存缓冲的行暂存在临时文件中
+type xlsxRowWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// newXLSXRowWriter 创建Excel写入器
+func newXLSXRowWriter(w io.Writer) (*xlsxRowWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), regExportSheet); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(regExportSheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &xlsxRowWriter{w: w, file: file, stream: stream}, nil
+}
+
+// WriteRow 写入一行，所有单元格按文本写入，避免手机号等被识别为数字
+func (x *xlsxRowWriter) WriteRow(values []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	cells := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		cells = append(cells, value)
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+// Flush 生成Excel文件并写入输出
+func (x *xlsxRowWriter) Flush() error {
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.w)
+	return err
+}
+
+// Close 清理流式写入时生成的临时文件
+func (x *xlsxRowWriter) Close() error {
+	return x.file.Close()
+}
+
+// ExportEventRegisteredUsers 分批查询活动报名用户并写入导出文件，附带报名表单附加问题的答案
+func (svc *EventServiceImpl) ExportEventRegisteredUsers(ctx context.Context, w io.Writer, eventID int, req dto.ExportRegUserRequest) error {
+	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	questions := effectiveRegistrationForm(event.RegistrationForm).Questions
+
+	var writer rowWriter
+	if req.Format == dto.RegExportFormatCSV {
+		writer, err = newCSVRowWriter(w)
+	} else {
+		writer, err = newXLSXRowWriter(w)
+	}
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+	}
+	defer writer.Close()
+
+	header := append([]string{}, regExportColumns...)
+	for _, question := range questions {
+		header = append(header, escapeCell(question.Label))
+	}
+	if err := writer.WriteRow(header); err != nil {
+		return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+	}
+
+	afterID := 0
+	for {
+		users, err := svc.eventRepo.ListExportRegUsers(ctx, eventID, req.Status, afterID, regExportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if err := writer.WriteRow(regUserToRow(user, questions)); err != nil {
+				return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+			}
+		}
+		if len(users) < regExportBatchSize {
+			break
+		}
+		afterID = users[len(users)-1].RegistrationID
+	}
+
+	if err := writer.Flush(); err != nil {
+		return utils.NewSystemError(fmt.Errorf("写入导出文件失败: %w", err))
+	}
+	return nil
+}
+
+// regUserToRow 将报名用户转换为导出文件的一行，附加问题的多个答案以分号分隔
+func regUserToRow(user *dto.ListEventRegUserResponse, questions []dto.FormQuestion) []string {
+	industry := user.IndustryName
+	if industry == "" {
+		industry = user.Industry
+	}
+	status := registrationStatusNames[user.Status]
+	if status == "" {
+		status = user.Status
+	}
+	checkInTime := ""
+	if user.CheckInTime != nil {
+		checkInTime = user.CheckInTime.Format(time.DateTime)
+	}
+
+	row := []string{
+		fmt.Sprint(user.UserID), user.Nickname, user.Name, user.Gender, user.PhoneNumber, user.Email,
+		user.Unit, user.Department, user.Position, industry,
+		status, user.ReviewReason, user.CreateTime.Format(time.DateTime), checkInTime,
+	}
+
+	answers := make(map[string][]string, len(user.Answers))
+	for _, answer := range user.Answers {
+		answers[answer.Key] = answer.Values
+	}
+	for _, question := range questions {
+		row = append(row, strings.Join(answers[question.Key], "; "))
+	}
+	for i := range row {
+		row[i] = escapeCell(row[i])
+	}
+	return row
+}
+
+// escapeCell 以公式字符开头的单元格内容前加单引号，避免用户填写的内容在电子表格中被当作公式执行
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
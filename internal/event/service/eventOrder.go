@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/payment"
+	"news-release/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// orderBatchSize 关闭超时订单和重试退款时每批处理的订单数
+const orderBatchSize = 100
+
+// requiresPayment 活动报名是否需要支付，未配置支付渠道时不收取报名费用
+func (svc *EventServiceImpl) requiresPayment(event *model.Event) bool {
+	return svc.payProvider != nil && event.RegistrationFee > 0
+}
+
+// seatStatus 用户占用名额后的报名状态，收费活动支付完成后才算正式报名
+func (svc *EventServiceImpl) seatStatus(event *model.Event) string {
+	if svc.requiresPayment(event) {
+		return model.RegistrationStatusUnpaid
+	}
+	return model.RegistrationStatusRegistered
+}
+
+// payDeadline 新订单的支付截止时间
+func (svc *EventServiceImpl) payDeadline() time.Time {
+	return time.Now().Add(svc.payTimeout)
+}
+
+// feeToCents 将以元为单位的报名费用转换为以分为单位的订单金额
+func feeToCents(fee float64) int {
+	return int(math.Round(fee * 100))
+}
+
+// newOrderNo 生成订单号，格式为 E+下单时间+12位随机字符
+func newOrderNo() string {
+	random := strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", ""))
+	return "E" + time.Now().Format("20060102150405") + random[:12]
+}
+
+// refundNoOf 根据订单号生成退款单号，同一订单重试退款时使用相同的退款单号，避免重复退款
+func refundNoOf(orderNo string) string {
+	return "R" + strings.TrimPrefix(orderNo, "E")
+}
+
+// refundFields 订单进入退款中状态时更新的字段，均为全额退款
+func refundFields(order *model.EventOrder, reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"status":        model.OrderStatusRefunding,
+		"refund_no":     refundNoOf(order.OrderNo),
+		"refund_amount": order.Amount,
+		"refund_reason": reason,
+	}
+}
+
+// toOrderResponse 转换为订单响应结构体
+func toOrderResponse(order *model.EventOrder, eventTitle string) *dto.OrderResponse {
+	return &dto.OrderResponse{
+		OrderNo:      order.OrderNo,
+		EventID:      order.EventID,
+		EventTitle:   eventTitle,
+		Amount:       float64(order.Amount) / 100,
+		Status:       order.Status,
+		ExpireTime:   order.ExpireTime,
+		PaidTime:     order.PaidTime,
+		RefundAmount: float64(order.RefundAmount) / 100,
+		RefundReason: order.RefundReason,
+		RefundTime:   order.RefundTime,
+		CreateTime:   order.CreateTime,
+	}
+}
+
+// createOrders 在事务中为占用名额的用户创建待支付订单，订单金额为当前的报名费用
+func (svc *EventServiceImpl) createOrders(ctx context.Context, tx *gorm.DB, event *model.Event, userIDs []int) error {
+	expireTime := svc.payDeadline()
+	for _, userID := range userIDs {
+		order := &model.EventOrder{
+			OrderNo:    newOrderNo(),
+			EventID:    event.ID,
+			UserID:     userID,
+			Amount:     feeToCents(event.RegistrationFee),
+			Status:     model.OrderStatusPending,
+			ExpireTime: expireTime,
+			Provider:   svc.payProvider.Name(),
+		}
+		if err := svc.orderRepo.CreateOrder(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeRegistrationOrder 在事务中处理取消报名的订单：待支付的订单关闭，已支付的订单在退款截止时间前申请退款
+// 返回需要退款的订单，调用前需在同一事务中锁定活动和活动-用户关联映射
+func (svc *EventServiceImpl) closeRegistrationOrder(ctx context.Context, tx *gorm.DB, event *model.Event, mapping *model.EventUserMapping) (*model.EventOrder, error) {
+	switch mapping.Status {
+	case model.RegistrationStatusUnpaid:
+		order, err := svc.orderRepo.LockUserOrder(ctx, tx, event.ID, mapping.UserID, model.OrderStatusPending)
+		if err != nil || order == nil {
+			return nil, err
+		}
+		return nil, svc.orderRepo.UpdateOrder(ctx, tx, order.ID, model.OrderStatusPending, map[string]interface{}{
+			"status": model.OrderStatusCancelled,
+		})
+
+	case model.RegistrationStatusRegistered:
+		order, err := svc.orderRepo.LockUserOrder(ctx, tx, event.ID, mapping.UserID, model.OrderStatusPaid)
+		if err != nil || order == nil {
+			return nil, err
+		}
+		// 超过退款截止时间取消报名不退款
+		if time.Now().After(event.EventStartTime.Add(-svc.refundBefore)) {
+			return nil, nil
+		}
+		if err := svc.orderRepo.UpdateOrder(ctx, tx, order.ID, model.OrderStatusPaid, refundFields(order, "用户取消报名")); err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+	return nil, nil
+}
+
+// refundOrder 调用支付渠道全额退款，失败时订单保持退款中状态，由定时任务重试
+func (svc *EventServiceImpl) refundOrder(ctx context.Context, order *model.EventOrder) error {
+	if svc.payProvider == nil {
+		return fmt.Errorf("未配置支付渠道，订单[%s]无法退款", order.OrderNo)
+	}
+
+	refundTradeNo, err := svc.payProvider.Refund(ctx, payment.Order{
+		OrderNo: order.OrderNo,
+		Amount:  order.Amount,
+	}, refundNoOf(order.OrderNo), order.Amount)
+	if err != nil {
+		return fmt.Errorf("订单[%s]退款失败: %w", order.OrderNo, err)
+	}
+	return svc.orderRepo.CompleteRefund(ctx, order.ID, refundTradeNo)
+}
+
+// ListUserOrders 分页查询用户的订单
+func (svc *EventServiceImpl) ListUserOrders(ctx context.Context, page, pageSize int, userID int, status string) ([]*dto.OrderResponse, int, error) {
+	return svc.orderRepo.ListUserOrders(ctx, page, pageSize, userID, status)
+}
+
+// GetUserOrder 查询用户自己的订单
+func (svc *EventServiceImpl) GetUserOrder(ctx context.Context, orderNo string, userID int) (*model.EventOrder, error) {
+	order, err := svc.orderRepo.GetOrderByNo(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || order.UserID != userID {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "订单不存在")
+	}
+	return order, nil
+}
+
+// PayOrder 为待支付的订单发起支付，返回客户端拉起支付所需的参数
+func (svc *EventServiceImpl) PayOrder(ctx context.Context, orderNo string, userID int) (*dto.PayOrderResponse, error) {
+	order, err := svc.GetUserOrder(ctx, orderNo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.OrderStatusPending {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "订单已支付或已关闭，请刷新页面后重试")
+	}
+	if time.Now().After(order.ExpireTime) {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceExpired, "订单已超过支付时限，名额已释放")
+	}
+	if svc.payProvider == nil || svc.payProvider.Name() != order.Provider {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "支付渠道暂不可用，请联系活动主办方")
+	}
+
+	event, err := svc.eventRepo.GetEventDetail(ctx, order.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsDeleted == utils.DeletedFlagYes || event.IsCancelled == utils.FlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消或已失效，无法支付")
+	}
+	params, err := svc.payProvider.CreatePayment(ctx, payment.Order{
+		OrderNo:    order.OrderNo,
+		Amount:     order.Amount,
+		Subject:    "活动报名: " + event.Title,
+		ExpireTime: order.ExpireTime,
+	})
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("订单[%s]发起支付失败: %w", order.OrderNo, err))
+	}
+
+	return &dto.PayOrderResponse{Order: toOrderResponse(order, event.Title), PayParams: params}, nil
+}
+
+// HandlePaymentCallback 处理支付渠道的支付结果通知，支付成功的订单对应的报名转为正式报名
+// 订单已关闭或报名已失效时支付款项全额退回，重复通知直接返回成功
+func (svc *EventServiceImpl) HandlePaymentCallback(ctx context.Context, provider string, header http.Header, body []byte) error {
+	if svc.payProvider == nil || svc.payProvider.Name() != provider {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "支付渠道不存在")
+	}
+	result, err := svc.payProvider.ParseCallback(header, body)
+	if err != nil {
+		return utils.NewBusinessError(utils.ErrCodeAuthFailed, "支付回调校验失败: "+err.Error())
+	}
+
+	order, err := svc.orderRepo.GetOrderByNo(ctx, result.OrderNo)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "订单不存在")
+	}
+	if result.Amount != order.Amount {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "支付金额与订单金额不一致")
+	}
+
+	var event *model.Event
+	confirmed, refunding := false, false
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		event, err = svc.eventRepo.LockEvent(ctx, tx, order.EventID)
+		if err != nil {
+			return err
+		}
+		locked, err := svc.orderRepo.LockOrder(ctx, tx, order.OrderNo)
+		if err != nil {
+			return err
+		}
+
+		paidFields := map[string]interface{}{
+			"trade_no":  result.TradeNo,
+			"paid_time": result.PaidTime,
+		}
+		withRefund := func(reason string) map[string]interface{} {
+			fields := refundFields(locked, reason)
+			for key, value := range paidFields {
+				fields[key] = value
+			}
+			return fields
+		}
+
+		switch locked.Status {
+		case model.OrderStatusPending:
+			mapping, err := svc.eventRepo.LockEventUserMap(ctx, tx, locked.EventID, locked.UserID)
+			if err != nil {
+				return err
+			}
+			valid := event.IsDeleted == utils.DeletedFlagNo && event.IsCancelled == utils.FlagNo && mapping != nil &&
+				mapping.IsDeleted == utils.DeletedFlagNo && mapping.Status == model.RegistrationStatusUnpaid
+			if !valid {
+				refunding = true
+				return svc.orderRepo.UpdateOrder(ctx, tx, locked.ID, locked.Status, withRefund("报名已失效，支付款项原路退回"))
+			}
+
+			paidFields["status"] = model.OrderStatusPaid
+			if err := svc.orderRepo.UpdateOrder(ctx, tx, locked.ID, locked.Status, paidFields); err != nil {
+				return err
+			}
+			confirmed = true
+			return svc.eventRepo.UpdateEventUserMap(ctx, tx, locked.EventID, locked.UserID, map[string]interface{}{
+				"status": model.RegistrationStatusRegistered,
+			})
+
+		case model.OrderStatusExpired, model.OrderStatusCancelled:
+			// 订单关闭后才完成支付，名额可能已被占用，支付款项直接退回
+			refunding = true
+			return svc.orderRepo.UpdateOrder(ctx, tx, locked.ID, locked.Status, withRefund("订单已关闭，支付款项原路退回"))
+
+		default:
+			// 重复通知，交易号不同说明同一订单被重复支付，需人工处理
+			if locked.TradeNo != result.TradeNo {
+				logrus.Errorf("订单[%s]收到不同交易号的支付通知，已记录交易号%s，本次交易号%s，请人工核实", locked.OrderNo, locked.TradeNo, result.TradeNo)
+			}
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if refunding {
+		if err := svc.refundOrder(ctx, order); err != nil {
+			logrus.Errorf("%v，将由定时任务重试", err)
+		}
+	}
+
+	// 支付完成的用户进入活动消息群组，失败不影响报名结果
+	if confirmed {
+		group, count, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", event.ID, "")
+		if err != nil || count == 0 {
+			logrus.Errorf("活动[%d]支付完成的用户进入消息群组失败，未找到活动消息群组: %v", event.ID, err)
+		} else if err := svc.msgSvc.AddUserToGroup(ctx, group[0].ID, []int{order.UserID}, order.UserID); err != nil {
+			logrus.Errorf("活动[%d]支付完成的用户进入消息群组失败: %v", event.ID, err)
+		}
+	}
+	return nil
+}
+
+// ProcessOrders 关闭超时未支付的订单并释放名额，重试失败的退款，供定时任务调用
+func (svc *EventServiceImpl) ProcessOrders(ctx context.Context) error {
+	if err := svc.closeExpiredOrders(ctx); err != nil {
+		return err
+	}
+	return svc.retryRefunds(ctx)
+}
+
+// closeExpiredOrders 分批关闭超时未支付的订单，单个订单处理失败只记录日志
+func (svc *EventServiceImpl) closeExpiredOrders(ctx context.Context) error {
+	now := time.Now()
+	afterID := 0
+	for {
+		orders, err := svc.orderRepo.ListExpiredOrders(ctx, now, afterID, orderBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			if err := svc.closeExpiredOrder(ctx, &orders[i]); err != nil {
+				logrus.Errorf("关闭超时订单[%s]失败: %v", orders[i].OrderNo, err)
+			}
+		}
+		if len(orders) < orderBatchSize {
+			return nil
+		}
+		afterID = orders[len(orders)-1].ID
+	}
+}
+
+// closeExpiredOrder 关闭超时订单，取消对应的待支付报名，空出的名额由候补用户依次补位
+func (svc *EventServiceImpl) closeExpiredOrder(ctx context.Context, order *model.EventOrder) error {
+	var event *model.Event
+	var promoted []int
+	released := false
+	err := svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		event, err = svc.eventRepo.LockEvent(ctx, tx, order.EventID)
+		if err != nil {
+			return err
+		}
+		locked, err := svc.orderRepo.LockOrder(ctx, tx, order.OrderNo)
+		if err != nil {
+			return err
+		}
+		// 加锁前已支付或已取消
+		if locked == nil || locked.Status != model.OrderStatusPending {
+			return nil
+		}
+		if err := svc.orderRepo.UpdateOrder(ctx, tx, locked.ID, model.OrderStatusPending, map[string]interface{}{
+			"status": model.OrderStatusExpired,
+		}); err != nil {
+			return err
+		}
+
+		mapping, err := svc.eventRepo.LockEventUserMap(ctx, tx, locked.EventID, locked.UserID)
+		if err != nil {
+			return err
+		}
+		if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes || mapping.Status != model.RegistrationStatusUnpaid {
+			return nil
+		}
+		if err := svc.eventRepo.UpdateEventUserMap(ctx, tx, locked.EventID, locked.UserID, map[string]interface{}{
+			"is_deleted":    utils.DeletedFlagYes,
+			"waitlist_time": nil,
+		}); err != nil {
+			return err
+		}
+		released = true
+		promoted, err = svc.promoteWaitlist(ctx, tx, event)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if released {
+		svc.notifyUsers(ctx, event, []int{order.UserID}, "报名订单已关闭",
+			fmt.Sprintf("您报名的活动「%s」未在支付时限内完成支付，订单已关闭，名额已释放。", event.Title), order.UserID)
+	}
+	svc.notifyPromoted(ctx, event, promoted, order.UserID)
+	return nil
+}
+
+// retryRefunds 分批重试退款中的订单，单个订单退款失败只记录日志
+func (svc *EventServiceImpl) retryRefunds(ctx context.Context) error {
+	afterID := 0
+	for {
+		orders, err := svc.orderRepo.ListRefundingOrders(ctx, afterID, orderBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			if err := svc.refundOrder(ctx, &orders[i]); err != nil {
+				logrus.Errorf("%v", err)
+			}
+		}
+		if len(orders) < orderBatchSize {
+			return nil
+		}
+		afterID = orders[len(orders)-1].ID
+	}
+}
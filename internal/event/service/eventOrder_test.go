@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"testing"
+	"time"
+)
+
+func TestHandlePaymentCallbackConfirmsRegistration(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+
+	env.register(t, event.ID, 101)
+	if got := env.status(event.ID, 101); got != model.RegistrationStatusUnpaid {
+		t.Fatalf("支付前报名状态为 %s，期望 %s", got, model.RegistrationStatusUnpaid)
+	}
+
+	env.pay(t, event.ID, 101)
+
+	order := env.orders.userOrder(event.ID, 101)
+	if order.Status != model.OrderStatusPaid {
+		t.Errorf("订单状态为 %s，期望 %s", order.Status, model.OrderStatusPaid)
+	}
+	if order.TradeNo == "" || order.PaidTime == nil {
+		t.Errorf("订单未记录交易号和支付时间: %+v", order)
+	}
+	if got := env.status(event.ID, 101); got != model.RegistrationStatusRegistered {
+		t.Errorf("报名状态为 %s，期望 %s", got, model.RegistrationStatusRegistered)
+	}
+	if !env.groups.members[101] {
+		t.Error("支付完成的用户未进入活动消息群组")
+	}
+}
+
+func TestProcessOrdersDoesNotPromoteOnCancelledEvent(t *testing.T) {
+	for _, cancelled := range []bool{false, true} {
+		name := "活动未取消时候补转正"
+		if cancelled {
+			name = "活动已取消时不再补位"
+		}
+		t.Run(name, func(t *testing.T) {
+			env := newTestEnv(t)
+			event := env.addEvent(50, 1)
+			env.register(t, event.ID, 101)
+			env.register(t, event.ID, 102)
+			if got := env.status(event.ID, 102); got != model.RegistrationStatusWaitlisted {
+				t.Fatalf("名额已满时报名状态为 %s，期望 %s", got, model.RegistrationStatusWaitlisted)
+			}
+
+			// 订单超时，活动在关闭订单前已被取消
+			env.orders.userOrder(event.ID, 101).ExpireTime = time.Now().Add(-time.Minute)
+			if cancelled {
+				env.events.events[event.ID].IsCancelled = utils.FlagYes
+			}
+			if err := env.svc.ProcessOrders(context.Background()); err != nil {
+				t.Fatalf("处理订单失败: %v", err)
+			}
+
+			if got := env.orders.userOrder(event.ID, 101).Status; got != model.OrderStatusExpired {
+				t.Errorf("超时订单状态为 %s，期望 %s", got, model.OrderStatusExpired)
+			}
+			want, wantOrder := model.RegistrationStatusUnpaid, true
+			if cancelled {
+				want, wantOrder = model.RegistrationStatusWaitlisted, false
+			}
+			if got := env.status(event.ID, 102); got != want {
+				t.Errorf("候补用户报名状态为 %s，期望 %s", got, want)
+			}
+			if got := env.orders.userOrder(event.ID, 102) != nil; got != wantOrder {
+				t.Errorf("候补用户是否生成订单为 %v，期望 %v", got, wantOrder)
+			}
+		})
+	}
+}
+
+func TestRegistrationCreatesOrder(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(49.9, 10)
+
+	res, err := env.svc.RegistrationEvent(context.Background(), event.ID, 101, nil)
+	if err != nil {
+		t.Fatalf("报名失败: %v", err)
+	}
+	if res.Status != model.RegistrationStatusUnpaid || res.Order == nil {
+		t.Fatalf("报名结果为 %+v，期望待支付并返回订单", res)
+	}
+
+	order := env.orders.userOrder(event.ID, 101)
+	if order.Status != model.OrderStatusPending {
+		t.Errorf("订单状态为 %s，期望 %s", order.Status, model.OrderStatusPending)
+	}
+	if order.Amount != 4990 {
+		t.Errorf("订单金额为 %d 分，期望 4990 分", order.Amount)
+	}
+	if res.Order.OrderNo != order.OrderNo {
+		t.Errorf("返回的订单号为 %s，期望 %s", res.Order.OrderNo, order.OrderNo)
+	}
+	if env.groups.members[101] {
+		t.Error("未支付的用户进入了活动消息群组")
+	}
+}
+
+func TestHandlePaymentCallbackAfterOrderClosed(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+	env.register(t, event.ID, 101)
+	order := *env.orders.userOrder(event.ID, 101)
+
+	// 用户在支付完成前取消报名，订单关闭后才收到支付通知
+	if err := env.svc.CancelRegistrationEvent(context.Background(), event.ID, 101); err != nil {
+		t.Fatalf("取消报名失败: %v", err)
+	}
+	if got := env.orders.userOrder(event.ID, 101).Status; got != model.OrderStatusCancelled {
+		t.Fatalf("取消报名后订单状态为 %s，期望 %s", got, model.OrderStatusCancelled)
+	}
+	header, body, err := env.provider.BuildCallback(order.OrderNo, order.Amount)
+	if err != nil {
+		t.Fatalf("构造支付回调失败: %v", err)
+	}
+	if err := env.svc.HandlePaymentCallback(context.Background(), env.provider.Name(), header, body); err != nil {
+		t.Fatalf("处理支付回调失败: %v", err)
+	}
+
+	refunded := env.orders.userOrder(event.ID, 101)
+	if refunded.Status != model.OrderStatusRefunded || refunded.RefundAmount != order.Amount {
+		t.Errorf("订单状态为 %s，退款金额 %d，期望全额退款", refunded.Status, refunded.RefundAmount)
+	}
+	if got := env.status(event.ID, 101); got != "" {
+		t.Errorf("报名状态为 %s，期望报名保持取消", got)
+	}
+}
+
+func TestHandlePaymentCallbackRejectsWrongAmount(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+	env.register(t, event.ID, 101)
+	order := env.orders.userOrder(event.ID, 101)
+
+	header, body, err := env.provider.BuildCallback(order.OrderNo, order.Amount-1)
+	if err != nil {
+		t.Fatalf("构造支付回调失败: %v", err)
+	}
+	err = env.svc.HandlePaymentCallback(context.Background(), env.provider.Name(), header, body)
+	assertBusinessError(t, err, "金额")
+	if order.Status != model.OrderStatusPending {
+		t.Errorf("订单状态为 %s，期望保持 %s", order.Status, model.OrderStatusPending)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/event/model"
+	msgmodel "news-release/internal/message/model"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// reminderTimeFormat 提醒消息中的时间格式
+const reminderTimeFormat = "2006-01-02 15:04"
+
+// SendReminders 发送活动即将开始提醒和报名即将截止通知，供定时任务调用
+// 提醒发送到活动消息群组，已发送的提醒记录在库中，重启或多实例部署时不会重复发送
+func (svc *EventServiceImpl) SendReminders(ctx context.Context) error {
+	now := time.Now()
+	if err := svc.sendEventReminders(ctx, now); err != nil {
+		return err
+	}
+	return svc.sendRegistrationReminders(ctx, now)
+}
+
+// sendEventReminders 为即将开始的活动发送提醒
+// 每个活动只发送最近一个已到达的提醒时间点，活动创建较晚或任务停止期间错过的较早提醒不再补发
+func (svc *EventServiceImpl) sendEventReminders(ctx context.Context, now time.Time) error {
+	if len(svc.eventReminders) == 0 {
+		return nil
+	}
+	events, err := svc.reminderRepo.ListStartingEvents(ctx, now, now.Add(svc.eventReminders[0]))
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		remaining := event.EventStartTime.Sub(now)
+		offset := svc.eventReminders[0]
+		for _, candidate := range svc.eventReminders {
+			if candidate >= remaining {
+				offset = candidate
+			}
+		}
+
+		content := fmt.Sprintf("您报名的活动《%s》将于%s开始，活动地址：%s，请合理安排时间，准时参加。",
+			event.Title, event.EventStartTime.Format(reminderTimeFormat), event.EventAddress)
+		reminder := &model.EventReminder{
+			EventID:      event.ID,
+			ReminderType: model.ReminderTypeEventStart,
+			Offset:       int(offset / time.Minute),
+			TargetTime:   event.EventStartTime,
+		}
+		if err := svc.sendReminder(ctx, event, reminder, "活动即将开始提醒", content); err != nil {
+			logrus.Errorf("活动[%d]发送开始提醒失败: %v", event.ID, err)
+		}
+	}
+	return nil
+}
+
+// sendRegistrationReminders 为报名即将截止的活动发送通知，提醒已报名的用户邀请同行人员尽快报名
+func (svc *EventServiceImpl) sendRegistrationReminders(ctx context.Context, now time.Time) error {
+	if svc.registrationReminder <= 0 {
+		return nil
+	}
+	events, err := svc.reminderRepo.ListClosingEvents(ctx, now, now.Add(svc.registrationReminder))
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		content := fmt.Sprintf("活动《%s》的报名将于%s截止，如有同行人员需要参加，请提醒其尽快报名。",
+			event.Title, event.RegistrationEndTime.Format(reminderTimeFormat))
+		reminder := &model.EventReminder{
+			EventID:      event.ID,
+			ReminderType: model.ReminderTypeRegistrationClose,
+			Offset:       int(svc.registrationReminder / time.Minute),
+			TargetTime:   event.RegistrationEndTime,
+		}
+		if err := svc.sendReminder(ctx, event, reminder, "活动报名即将截止", content); err != nil {
+			logrus.Errorf("活动[%d]发送报名截止通知失败: %v", event.ID, err)
+		}
+	}
+	return nil
+}
+
+// sendReminder 先记录提醒再发送到活动消息群组，已记录过的提醒直接跳过
+// 发送失败时删除记录，由下一次执行重试
+func (svc *EventServiceImpl) sendReminder(ctx context.Context, event *model.Event, reminder *model.EventReminder, title string, content string) error {
+	created, err := svc.reminderRepo.CreateReminder(ctx, reminder)
+	if err != nil || !created {
+		return err
+	}
+
+	if err := svc.deliverReminder(ctx, event, reminder, title, content); err != nil {
+		if deleteErr := svc.reminderRepo.DeleteReminder(ctx, reminder.ID); deleteErr != nil {
+			logrus.Errorf("活动[%d]删除发送失败的提醒记录失败: %v", event.ID, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// deliverReminder 将提醒发送到活动消息群组，没有消息群组的活动不发送
+func (svc *EventServiceImpl) deliverReminder(ctx context.Context, event *model.Event, reminder *model.EventReminder, title string, content string) error {
+	group, _, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", event.ID, "")
+	if err != nil {
+		return err
+	}
+	if len(group) == 0 {
+		return nil
+	}
+
+	msg := &msgmodel.Message{
+		Title:      title,
+		Content:    content,
+		SendTime:   time.Now(),
+		CreateUser: event.CreateUser,
+		UpdateUser: event.CreateUser,
+	}
+	if err := svc.sendSvc.SendMessage(ctx, group[0].ID, msg); err != nil {
+		return err
+	}
+	if err := svc.reminderRepo.UpdateReminderMessage(ctx, reminder.ID, msg.ID); err != nil {
+		logrus.Errorf("活动[%d]记录提醒消息ID失败: %v", event.ID, err) // 消息已发送，不能删除提醒记录
+	}
+	return nil
+}
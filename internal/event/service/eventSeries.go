@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CreateSeries 创建活动系列，按重复规则生成各场次活动
+// 各场次与第一场保持相同的时长和报名时间间隔，共用活动详情和图片，并各自创建活动消息群组
+func (svc *EventServiceImpl) CreateSeries(ctx context.Context, req dto.CreateSeriesRequest, userID int) (*model.EventSeries, error) {
+	rule, err := parseRecurrenceRule(req.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换第一场的时间并检查是否合理
+	eventStartTime, err := utils.StringToTime(req.EventStartTime)
+	if err != nil {
+		return nil, err
+	}
+	eventEndTime, err := utils.StringToTime(req.EventEndTime)
+	if err != nil {
+		return nil, err
+	}
+	registrationStartTime, err := utils.StringToTime(req.RegistrationStartTime)
+	if err != nil {
+		return nil, err
+	}
+	registrationEndTime, err := utils.StringToTime(req.RegistrationEndTime)
+	if err != nil {
+		return nil, err
+	}
+	if eventStartTime.After(eventEndTime) {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动开始时间不能晚于结束时间")
+	}
+	if registrationStartTime.After(registrationEndTime) {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "报名开始时间不能晚于结束时间")
+	}
+
+	starts, err := rule.occurrences(eventStartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查是否有重复的系列标题
+	existing, err := svc.seriesRepo.GetSeriesByTitle(ctx, req.Title)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名活动系列，请修改标题后重试")
+	}
+
+	// 检查报名表单设置
+	if err := validateRegistrationForm(req.RegistrationForm); err != nil {
+		return nil, err
+	}
+
+	// 过滤活动详情中的不安全内容并提取纯文本
+	processed := svc.processor.Process(req.Detail)
+	if strings.TrimSpace(processed.HTML) == "" {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "活动详情不能为空")
+	}
+
+	series := &model.EventSeries{
+		Title:            req.Title,
+		Detail:           processed.HTML,
+		RecurrenceRule:   req.RecurrenceRule,
+		EventAddress:     req.EventAddress,
+		RegistrationFee:  req.RegistrationFee,
+		MaxParticipants:  req.MaxParticipants,
+		RequireApproval:  req.RequireApproval,
+		RegistrationForm: req.RegistrationForm,
+		CoverImageURL:    req.CoverImageURL,
+		CreateUser:       userID,
+		UpdateUser:       userID,
+	}
+
+	// 按第一场的时间间隔生成各场次，别名为系列别名加场次日期
+	duration := eventEndTime.Sub(eventStartTime)
+	registrationOpen := eventStartTime.Sub(registrationStartTime)
+	registrationClose := eventStartTime.Sub(registrationEndTime)
+	slugBase := utils.GenerateSlug(req.Title)
+	events := make([]*model.Event, 0, len(starts))
+	for _, start := range starts {
+		slug := ""
+		if slugBase != "" {
+			if slug, err = svc.resolveSlug(ctx, "", slugBase+" "+start.Format(time.DateOnly), 0); err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, &model.Event{
+			Title:                 req.Title,
+			Detail:                processed.HTML,
+			DetailText:            processed.Text,
+			EventStartTime:        start,
+			EventEndTime:          start.Add(duration),
+			RegistrationStartTime: start.Add(-registrationOpen),
+			RegistrationEndTime:   start.Add(-registrationClose),
+			EventAddress:          req.EventAddress,
+			RegistrationFee:       req.RegistrationFee,
+			MaxParticipants:       req.MaxParticipants,
+			RequireApproval:       req.RequireApproval,
+			RegistrationForm:      req.RegistrationForm,
+			CoverImageURL:         req.CoverImageURL,
+			Slug:                  slug,
+			CreateUser:            userID,
+			UpdateUser:            userID,
+		})
+	}
+
+	// 使用 GORM 函数式事务
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if err := svc.seriesRepo.CreateSeries(ctx, tx, series); err != nil {
+			return err
+		}
+		for _, event := range events {
+			event.SeriesID = series.ID
+			if err := svc.eventRepo.CreateEvent(ctx, tx, event); err != nil {
+				return err
+			}
+		}
+
+		// 图片关联到系列，由各场次共用
+		if len(req.ImageIDList) > 0 {
+			return svc.fileRepo.BatchUpdateImageBizID(ctx, tx, req.ImageIDList, series.ID, utils.TypeEventSeries)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+
+	// 为各场次创建活动消息群组，创建失败不影响活动系列创建成功
+	for _, event := range events {
+		if err := svc.createEventMsgGroup(ctx, event); err != nil {
+			logrus.Errorf("活动系列[%d]的场次[%d]创建消息群组失败: %v", series.ID, event.ID, err)
+		}
+	}
+
+	return series, nil
+}
+
+// GetSeriesDetail 获取活动系列详情和未删除的场次列表
+func (svc *EventServiceImpl) GetSeriesDetail(ctx context.Context, seriesID int) (*dto.SeriesDetailResponse, error) {
+	series, err := svc.activeSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	occurrences, err := svc.seriesRepo.ListOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.SeriesDetailResponse{
+		ID:               series.ID,
+		Title:            series.Title,
+		Detail:           series.Detail,
+		RecurrenceRule:   series.RecurrenceRule,
+		EventAddress:     series.EventAddress,
+		RegistrationFee:  series.RegistrationFee,
+		MaxParticipants:  series.MaxParticipants,
+		RequireApproval:  series.RequireApproval,
+		RegistrationForm: effectiveRegistrationForm(series.RegistrationForm),
+		CoverImageURL:    series.CoverImageURL,
+		Images:           svc.seriesRepo.ListSeriesImage(ctx, seriesID),
+		Occurrences:      make([]dto.SeriesOccurrence, 0, len(occurrences)),
+	}
+	for _, occurrence := range occurrences {
+		if occurrence.IsDeleted == utils.DeletedFlagYes {
+			continue
+		}
+		res.Occurrences = append(res.Occurrences, dto.SeriesOccurrence{
+			EventID:               occurrence.ID,
+			Title:                 occurrence.Title,
+			EventStartTime:        occurrence.EventStartTime,
+			EventEndTime:          occurrence.EventEndTime,
+			RegistrationStartTime: occurrence.RegistrationStartTime,
+			RegistrationEndTime:   occurrence.RegistrationEndTime,
+			SeriesOverridden:      occurrence.SeriesOverridden,
+			IsCancelled:           occurrence.IsCancelled,
+			IsDeleted:             occurrence.IsDeleted,
+		})
+	}
+	return res, nil
+}
+
+// ListSeries 分页查询活动系列
+func (svc *EventServiceImpl) ListSeries(ctx context.Context, page, pageSize int) ([]*model.EventSeries, int, error) {
+	return svc.seriesRepo.ListSeries(ctx, page, pageSize)
+}
+
+// activeSeries 查询未删除的活动系列
+func (svc *EventServiceImpl) activeSeries(ctx context.Context, seriesID int) (*model.EventSeries, error) {
+	series, err := svc.seriesRepo.GetSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series.IsDeleted == utils.DeletedFlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动系列不存在或已被删除，请刷新页面后重试")
+	}
+	return series, nil
+}
+
+// UpdateSeries 更新活动系列，修改同步到尚未开始且未单独修改过的场次
+// 各场次按单独修改活动的逻辑更新，调大人数上限时候补用户自动转正
+func (svc *EventServiceImpl) UpdateSeries(ctx context.Context, seriesID int, req dto.UpdateSeriesRequest, userID int) (*dto.UpdateSeriesResponse, error) {
+	series, err := svc.activeSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 当标题修改时，检查是否有重复的系列标题
+	if req.Title != nil && *req.Title != series.Title {
+		existing, err := svc.seriesRepo.GetSeriesByTitle(ctx, *req.Title)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名活动系列，请修改标题后重试")
+		}
+	}
+
+	// 构建更新字段映射
+	updateFields := make(map[string]interface{})
+	if req.Title != nil {
+		updateFields["title"] = *req.Title
+	}
+	if req.Detail != nil {
+		processed := svc.processor.Process(*req.Detail)
+		if strings.TrimSpace(processed.HTML) == "" {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "活动详情不能为空")
+		}
+		updateFields["detail"] = processed.HTML
+	}
+	if req.EventAddress != nil {
+		updateFields["event_address"] = *req.EventAddress
+	}
+	if req.RegistrationFee != nil {
+		updateFields["registration_fee"] = *req.RegistrationFee
+	}
+	if req.MaxParticipants != nil {
+		updateFields["max_participants"] = *req.MaxParticipants
+	}
+	if req.RequireApproval != nil {
+		updateFields["require_approval"] = *req.RequireApproval
+	}
+	if req.RegistrationForm != nil {
+		if err := validateRegistrationForm(req.RegistrationForm); err != nil {
+			return nil, err
+		}
+		updateFields["registration_form"] = req.RegistrationForm
+	}
+	if req.CoverImageURL != nil {
+		updateFields["cover_image_url"] = *req.CoverImageURL
+	}
+
+	var imageIDList []int
+	if req.ImageIDList != nil {
+		imageIDList = *req.ImageIDList
+	}
+
+	res := &dto.UpdateSeriesResponse{Updated: []int{}, Overridden: []int{}, Failed: []int{}}
+	if len(updateFields) == 0 && len(imageIDList) == 0 {
+		return res, nil // 无更新内容
+	}
+	updateFields["update_user"] = userID
+
+	// 使用 GORM 函数式事务
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		if err := svc.seriesRepo.UpdateSeries(ctx, tx, seriesID, updateFields); err != nil {
+			return err
+		}
+		if len(imageIDList) > 0 {
+			return svc.fileRepo.BatchUpdateImageBizID(ctx, tx, imageIDList, seriesID, utils.TypeEventSeries)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+
+	// 同步到尚未开始的场次，已开始的场次保持原样，单个场次同步失败只记录日志
+	eventReq := dto.UpdateEventRequest{
+		Title:            req.Title,
+		Detail:           req.Detail,
+		EventAddress:     req.EventAddress,
+		RegistrationFee:  req.RegistrationFee,
+		MaxParticipants:  req.MaxParticipants,
+		RequireApproval:  req.RequireApproval,
+		RegistrationForm: req.RegistrationForm,
+		CoverImageURL:    req.CoverImageURL,
+	}
+	occurrences, err := svc.seriesRepo.ListOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, occurrence := range occurrences {
+		if occurrence.IsDeleted == utils.DeletedFlagYes || !occurrence.EventStartTime.After(now) {
+			continue
+		}
+		if occurrence.SeriesOverridden == utils.FlagYes {
+			res.Overridden = append(res.Overridden, occurrence.ID)
+			continue
+		}
+		if err := svc.updateEvent(ctx, occurrence.ID, eventReq, userID, false); err != nil {
+			logrus.Errorf("活动系列[%d]的修改同步到场次[%d]失败: %v", seriesID, occurrence.ID, err)
+			res.Failed = append(res.Failed, occurrence.ID)
+			continue
+		}
+		res.Updated = append(res.Updated, occurrence.ID)
+	}
+
+	return res, nil
+}
+
+// DeleteSeries 删除活动系列及尚未开始的场次，已开始或已结束的场次保留
+func (svc *EventServiceImpl) DeleteSeries(ctx context.Context, seriesID int, userID int) error {
+	if _, err := svc.activeSeries(ctx, seriesID); err != nil {
+		return err
+	}
+
+	// 使用 GORM 函数式事务
+	err := svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		return svc.seriesRepo.UpdateSeries(ctx, tx, seriesID, map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagYes,
+			"update_user": userID,
+		})
+	})
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+
+	// 逐个删除场次及其消息群组，单个场次删除失败只记录日志
+	occurrences, err := svc.seriesRepo.ListOccurrences(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, occurrence := range occurrences {
+		if occurrence.IsDeleted == utils.DeletedFlagYes || !occurrence.EventStartTime.After(now) {
+			continue
+		}
+		if err := svc.DeleteEvent(ctx, occurrence.ID, userID); err != nil {
+			logrus.Errorf("删除活动系列[%d]的场次[%d]失败: %v", seriesID, occurrence.ID, err)
+		}
+	}
+	return nil
+}
+
+// RegisterSeries 报名活动系列中尚未截止报名的全部场次，尚未开放报名的场次不报名，在结果中返回开放报名的时间
+// 各场次分别按人数上限、审核和支付规则处理，单个场次报名失败不影响其他场次
+func (svc *EventServiceImpl) RegisterSeries(ctx context.Context, seriesID int, userID int, answers []dto.FormAnswer) ([]dto.SeriesRegistrationResult, error) {
+	if _, err := svc.activeSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+	// 上传的文件只能关联到一个报名，文件上传题需按场次分别报名
+	for _, answer := range answers {
+		if len(answer.ImageIDs) > 0 {
+			return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "报名整个系列时不支持文件上传题，请按场次分别报名")
+		}
+	}
+
+	occurrences, err := svc.seriesRepo.ListOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	results := make([]dto.SeriesRegistrationResult, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		if occurrence.IsDeleted == utils.DeletedFlagYes || occurrence.IsCancelled == utils.FlagYes || occurrence.RegistrationEndTime.Before(now) {
+			continue
+		}
+
+		result := dto.SeriesRegistrationResult{EventID: occurrence.ID, EventStartTime: occurrence.EventStartTime}
+		if occurrence.RegistrationStartTime.After(now) {
+			result.Message = fmt.Sprintf("报名尚未开始，请于%s后报名", occurrence.RegistrationStartTime.Format(time.DateTime))
+			results = append(results, result)
+			continue
+		}
+		event, err := svc.eventRepo.GetEventDetail(ctx, occurrence.ID)
+		if err == nil {
+			var res *dto.EventRegistrationResponse
+			if res, err = svc.registerEvent(ctx, event, userID, answers); err == nil {
+				result.Success = true
+				result.Status = res.Status
+			}
+		}
+		if err != nil {
+			result.Message = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动系列中没有可报名的场次")
+	}
+	return results, nil
+}
+
+// CancelSeriesRegistration 取消活动系列中尚未开始的全部场次的报名，已支付的订单按取消单场报名的规则退款
+func (svc *EventServiceImpl) CancelSeriesRegistration(ctx context.Context, seriesID int, userID int) ([]dto.SeriesRegistrationResult, error) {
+	if _, err := svc.activeSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := svc.seriesRepo.ListOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var results []dto.SeriesRegistrationResult
+	for _, occurrence := range occurrences {
+		// 已取消的场次报名已失效，无需取消
+		if occurrence.IsDeleted == utils.DeletedFlagYes || occurrence.IsCancelled == utils.FlagYes || !occurrence.EventStartTime.After(now) {
+			continue
+		}
+		mapping, err := svc.eventRepo.GetEventUserMap(ctx, occurrence.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes {
+			continue
+		}
+
+		result := dto.SeriesRegistrationResult{EventID: occurrence.ID, EventStartTime: occurrence.EventStartTime, Success: true}
+		if err := svc.CancelRegistrationEvent(ctx, occurrence.ID, userID); err != nil {
+			result.Success = false
+			result.Message = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "未报名该活动系列中尚未开始的场次")
+	}
+	return results, nil
+}
@@ -3,85 +3,186 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"news-release/internal/config"
+	"news-release/internal/content"
 	"news-release/internal/event/dto"
 	"news-release/internal/event/model"
 	"news-release/internal/event/repository"
 	filerepo "news-release/internal/file/repository"
 	msgmodel "news-release/internal/message/model"
 	msgsvc "news-release/internal/message/service"
+	"news-release/internal/payment"
 	userrepo "news-release/internal/user/repository"
 	"news-release/internal/utils"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+// metaDescriptionMaxLength SEO描述的最大字数
+const metaDescriptionMaxLength = 300
+
+// detailTextBatchSize 补全活动详情纯文本时每批处理的数量
+const detailTextBatchSize = 100
+
 // EventService 定义事件服务接口，提供事件相关的业务逻辑方法
 type EventService interface {
-	// GetEventStatus 根据开始时间和结束时间计算活动状态
-	GetEventStatus(registrationStartTime time.Time, registrationEndTime time.Time) string
+	// GetEventStatus 计算活动状态和报名状态
+	GetEventStatus(ctx context.Context, event *model.Event) (eventStatus dto.StatusLabel, registrationStatus dto.StatusLabel, err error)
 	// ListEvent 分页查询活动列表
-	ListEvent(ctx context.Context, page, pageSize int, eventStatus string, queryScope string) ([]*dto.EventListResponse, int, error)
+	ListEvent(ctx context.Context, pg *utils.Pagination, eventStatus string, registrationStatus string, queryScope string) ([]*dto.EventListResponse, error)
 	// GetEventDetail 获取活动详情
 	GetEventDetail(ctx context.Context, eventID int) (*model.Event, error)
-	// RegistrationEvent 活动报名
-	RegistrationEvent(ctx context.Context, eventID int, userID int) error
-	// CancelRegistrationEvent 取消活动报名
+	// GetEventDetailBySlug 根据别名获取活动详情
+	GetEventDetailBySlug(ctx context.Context, slug string) (*model.Event, error)
+	// RegistrationEvent 活动报名，活动人数已满时进入候补名单，收费活动生成待支付订单
+	RegistrationEvent(ctx context.Context, eventID int, userID int, answers []dto.FormAnswer) (*dto.EventRegistrationResponse, error)
+	// CancelRegistrationEvent 取消活动报名，空出名额时候补用户自动转为正式报名，已支付的订单按规则退款
 	CancelRegistrationEvent(ctx context.Context, eventID int, userID int) error
-	// IsUserRegistered 查询用户是否已报名活动
-	IsUserRegistered(ctx context.Context, eventID int, userID int) (bool, error)
+	// GetRegistrationStatus 查询用户的活动报名状态
+	GetRegistrationStatus(ctx context.Context, eventID int, userID int) (*dto.EventRegistrationResponse, error)
 	// ListUserRegisteredEvents 获取用户已报名的活动列表
-	ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string) ([]*model.Event, int, error)
+	ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string, registrationStatus string) ([]*dto.EventListResponse, int, error)
 	// CreateEvent 创建活动
 	CreateEvent(ctx context.Context, event *model.Event, imageIDList []int) error
 	// UpdateEvent 更新活动
 	UpdateEvent(ctx context.Context, eventID int, req dto.UpdateEventRequest, userID int) error
 	// DeleteEvent 删除活动
 	DeleteEvent(ctx context.Context, eventID int, userID int) error
-	// ListEventRegisteredUser 获取活动报名用户列表
-	ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error)
+	// RestoreEvent 恢复已删除的活动
+	RestoreEvent(ctx context.Context, eventID int, userID int) error
+	// ListEventRegisteredUser 按报名状态获取活动报名用户列表
+	ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int, status string) ([]*dto.ListEventRegUserResponse, int, error)
+	// ExportEventRegisteredUsers 将活动报名用户导出为Excel或CSV文件
+	ExportEventRegisteredUsers(ctx context.Context, w io.Writer, eventID int, req dto.ExportRegUserRequest) error
+	// ReviewRegistrations 批量审核待审核的报名
+	ReviewRegistrations(ctx context.Context, eventID int, req dto.ReviewRegistrationRequest, userID int) (*dto.ReviewRegistrationResponse, error)
+	// GetCheckInQRCode 生成用户的活动签到二维码图片
+	GetCheckInQRCode(ctx context.Context, eventID int, userID int) ([]byte, error)
+	// CheckIn 工作人员扫码签到
+	CheckIn(ctx context.Context, token string, operateUser int) (*dto.CheckInResponse, error)
+	// GetAttendance 统计活动的报名和签到人数
+	GetAttendance(ctx context.Context, eventID int) (*dto.AttendanceResponse, error)
+	// ListNoShowUsers 获取活动正式报名但未签到的用户列表
+	ListNoShowUsers(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error)
+	// ListUserOrders 分页查询用户的订单
+	ListUserOrders(ctx context.Context, page, pageSize int, userID int, status string) ([]*dto.OrderResponse, int, error)
+	// GetUserOrder 查询用户自己的订单
+	GetUserOrder(ctx context.Context, orderNo string, userID int) (*model.EventOrder, error)
+	// PayOrder 为待支付的订单发起支付
+	PayOrder(ctx context.Context, orderNo string, userID int) (*dto.PayOrderResponse, error)
+	// HandlePaymentCallback 处理支付渠道的支付结果通知
+	HandlePaymentCallback(ctx context.Context, provider string, header http.Header, body []byte) error
+	// ProcessOrders 关闭超时未支付的订单并重试失败的退款，供定时任务调用
+	ProcessOrders(ctx context.Context) error
+	// GetEventCalendar 生成活动的iCalendar文件
+	GetEventCalendar(ctx context.Context, eventID int, siteURL string) ([]byte, error)
+	// GetCalendarSubscription 生成用户的日历订阅链接
+	GetCalendarSubscription(userID int, siteURL string) *dto.CalendarSubscription
+	// GetUserCalendar 根据订阅令牌生成用户已报名活动的日历
+	GetUserCalendar(ctx context.Context, token string, siteURL string) ([]byte, error)
+	// CreateSeries 创建活动系列，按重复规则生成各场次活动
+	CreateSeries(ctx context.Context, req dto.CreateSeriesRequest, userID int) (*model.EventSeries, error)
+	// GetSeriesDetail 获取活动系列详情和场次列表
+	GetSeriesDetail(ctx context.Context, seriesID int) (*dto.SeriesDetailResponse, error)
+	// ListSeries 分页查询活动系列
+	ListSeries(ctx context.Context, page, pageSize int) ([]*model.EventSeries, int, error)
+	// UpdateSeries 更新活动系列并同步到尚未开始且未单独修改过的场次
+	UpdateSeries(ctx context.Context, seriesID int, req dto.UpdateSeriesRequest, userID int) (*dto.UpdateSeriesResponse, error)
+	// DeleteSeries 删除活动系列及尚未开始的场次
+	DeleteSeries(ctx context.Context, seriesID int, userID int) error
+	// RegisterSeries 报名活动系列中已开放且尚未截止报名的全部场次
+	RegisterSeries(ctx context.Context, seriesID int, userID int, answers []dto.FormAnswer) ([]dto.SeriesRegistrationResult, error)
+	// CancelSeriesRegistration 取消活动系列中尚未开始的全部场次的报名
+	CancelSeriesRegistration(ctx context.Context, seriesID int, userID int) ([]dto.SeriesRegistrationResult, error)
+	// CancelEvent 取消活动，已支付的报名费用全额退款并通知已报名的用户
+	CancelEvent(ctx context.Context, eventID int, reason string, userID int) error
+	// FillDetailText 为历史活动补全详情纯文本，供定时任务调用
+	FillDetailText(ctx context.Context) error
+	// SendReminders 发送活动即将开始提醒和报名即将截止通知，供定时任务调用
+	SendReminders(ctx context.Context) error
 }
 
 // EventServiceImpl 实现 EventService 接口，提供事件相关的业务逻辑
 type EventServiceImpl struct {
-	eventRepo repository.EventRepository // 事件数据访问接口
-	userRepo  userrepo.UserRepository    // 用户数据访问接口
-	fileRepo  filerepo.FileRepository    // 文件数据访问接口
-	msgSvc    msgsvc.MsgGroupService     // 消息群组服务接口
+	eventRepo    repository.EventRepository    // 事件数据访问接口
+	orderRepo    repository.OrderRepository    // 活动订单数据访问接口
+	seriesRepo   repository.SeriesRepository   // 活动系列数据访问接口
+	reminderRepo repository.ReminderRepository // 活动提醒数据访问接口
+	userRepo     userrepo.UserRepository       // 用户数据访问接口
+	fileRepo     filerepo.FileRepository       // 文件数据访问接口
+	msgSvc       msgsvc.MsgGroupService        // 消息群组服务接口
+	sendSvc      msgsvc.MessageService         // 消息服务接口
+	processor    *content.Processor            // 富文本处理器
+
+	checkInSecret     []byte        // 签到码签名密钥
+	checkInOpenBefore time.Duration // 活动开始前可提前签到的时长
+
+	payProvider  payment.Provider // 支付渠道，未配置时不收取报名费用
+	payTimeout   time.Duration    // 订单支付时限
+	refundBefore time.Duration    // 活动开始前多久之前取消报名可以退款
+
+	calendarSecret []byte            // 日历订阅令牌签名密钥
+	siteCfg        config.SiteConfig // 站点配置，用于生成日历中的活动链接
+
+	eventReminders       []time.Duration // 活动开始前发送提醒的时间点，从远到近排序
+	registrationReminder time.Duration   // 报名截止前发送通知的时长，为0时不发送
 }
 
 // NewEventService 创建服务实例
 func NewEventService(
 	eventRepo repository.EventRepository,
+	orderRepo repository.OrderRepository,
+	seriesRepo repository.SeriesRepository,
+	reminderRepo repository.ReminderRepository,
 	userRepo userrepo.UserRepository,
 	fileRepo filerepo.FileRepository,
 	msgSvc msgsvc.MsgGroupService,
+	sendSvc msgsvc.MessageService,
+	processor *content.Processor,
+	payProvider payment.Provider,
+	cfg *config.Config,
 ) EventService {
 	return &EventServiceImpl{
-		eventRepo: eventRepo,
-		userRepo:  userRepo,
-		fileRepo:  fileRepo,
-		msgSvc:    msgSvc,
+		eventRepo:    eventRepo,
+		orderRepo:    orderRepo,
+		seriesRepo:   seriesRepo,
+		reminderRepo: reminderRepo,
+		userRepo:     userRepo,
+		fileRepo:     fileRepo,
+		msgSvc:       msgSvc,
+		sendSvc:      sendSvc,
+		processor:    processor,
+
+		checkInSecret:     config.SigningSecret(cfg.CheckIn.Secret, cfg.JWT, config.SigningPurposeCheckIn),
+		checkInOpenBefore: cfg.CheckIn.OpenBefore(),
+
+		payProvider:  payProvider,
+		payTimeout:   cfg.Payment.PayTimeout(),
+		refundBefore: cfg.Payment.RefundDeadline(),
+
+		calendarSecret: config.SigningSecret(cfg.Calendar.Secret, cfg.JWT, config.SigningPurposeCalendar),
+		siteCfg:        cfg.Site,
+
+		eventReminders:       cfg.Reminder.EventOffsets(),
+		registrationReminder: cfg.Reminder.RegistrationOffset(),
 	}
 }
 
-// GetEventStatus 根据开始时间和结束时间计算活动状态
-func (svc *EventServiceImpl) GetEventStatus(registrationStartTime time.Time, registrationEndTime time.Time) string {
-	if registrationStartTime.After(time.Now()) {
-		return "未开始"
-	}
-	if registrationStartTime.Before(time.Now()) && registrationEndTime.After(time.Now()) {
-		return "正在进行"
-	}
-	if registrationEndTime.Before(time.Now()) {
-		return "已结束"
+// ListEvent 分页查询活动列表，并计算各活动的活动状态和报名状态
+func (svc *EventServiceImpl) ListEvent(ctx context.Context, pg *utils.Pagination, eventStatus string, registrationStatus string, queryScope string) ([]*dto.EventListResponse, error) {
+	events, err := svc.eventRepo.List(ctx, pg, eventStatus, registrationStatus, queryScope)
+	if err != nil {
+		return nil, err
 	}
-	return ""
-}
 
-// ListEvent 分页查询活动列表
-func (svc *EventServiceImpl) ListEvent(ctx context.Context, page, pageSize int, eventStatus string, queryScope string) ([]*dto.EventListResponse, int, error) {
-	return svc.eventRepo.List(ctx, page, pageSize, eventStatus, queryScope)
+	fillListStatus(events)
+	return events, nil
 }
 
 // GetEventDetail 获取活动详情
@@ -91,8 +192,11 @@ func (svc *EventServiceImpl) GetEventDetail(ctx context.Context, eventID int) (*
 		return nil, err
 	}
 
-	// 获取关联图片列表
+	// 获取关联图片列表，系列场次未单独上传图片时使用系列共用的图片
 	images := svc.eventRepo.ListEventImage(ctx, eventID)
+	if len(images) == 0 && event.SeriesID > 0 {
+		images = svc.seriesRepo.ListSeriesImage(ctx, event.SeriesID)
+	}
 
 	// 添加图片到活动详情
 	event.Images = make([]dto.Image, 0, len(images)) // 预分配空间，提高性能
@@ -103,58 +207,205 @@ func (svc *EventServiceImpl) GetEventDetail(ctx context.Context, eventID int) (*
 		})
 	}
 
+	// SEO描述和分享图片未设置时分别取活动详情的纯文本和封面图片
+	if event.MetaDescription == "" {
+		detailText := event.DetailText
+		if detailText == "" {
+			detailText = utils.PlainText(event.Detail) // 历史数据尚未补全纯文本
+		}
+		event.MetaDescription = content.Summary(detailText, metaDescriptionMaxLength)
+	}
+	if event.OgImageURL == "" {
+		event.OgImageURL = event.CoverImageURL
+	}
+	event.RegistrationForm = effectiveRegistrationForm(event.RegistrationForm)
+
 	return event, nil
 }
 
+// GetEventDetailBySlug 根据别名获取活动详情
+func (svc *EventServiceImpl) GetEventDetailBySlug(ctx context.Context, slug string) (*model.Event, error) {
+	event, err := svc.eventRepo.GetEventBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil || event.IsDeleted == utils.DeletedFlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动不存在或已被删除，请刷新页面后重试")
+	}
+
+	return svc.GetEventDetail(ctx, event.ID)
+}
+
+// resolveSlug 校验指定的别名是否可用；未指定别名时根据标题生成
+// 标题无法生成别名（如纯中文标题）时返回空字符串，活动链接使用数字ID
+func (svc *EventServiceImpl) resolveSlug(ctx context.Context, slug string, title string, eventID int) (string, error) {
+	taken := func(candidate string) (bool, error) {
+		existing, err := svc.eventRepo.GetEventBySlug(ctx, candidate)
+		if err != nil {
+			return false, err
+		}
+		return existing != nil && existing.ID != eventID, nil
+	}
+
+	if slug == "" {
+		return utils.UniqueSlug(title, taken)
+	}
+	occupied, err := taken(slug)
+	if err != nil {
+		return "", err
+	}
+	if occupied {
+		return "", utils.NewBusinessError(utils.ErrCodeResourceExists, "别名已被使用，请修改后重试")
+	}
+	return slug, nil
+}
+
 // RegistrationEvent 活动报名实现
-func (svc *EventServiceImpl) RegistrationEvent(ctx context.Context, eventID int, userID int) error {
-	var mapping *model.EventUserMapping
+func (svc *EventServiceImpl) RegistrationEvent(ctx context.Context, eventID int, userID int, answers []dto.FormAnswer) (*dto.EventRegistrationResponse, error) {
 	// 检查活动是否存在
 	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// 检查活动是否已删除
 	if event.IsDeleted == utils.DeletedFlagYes {
-		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
 	}
 	// 检查活动是否在报名时间内
 	if event.RegistrationStartTime.After(time.Now()) || event.RegistrationEndTime.Before(time.Now()) {
-		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "未在活动报名时间内")
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "未在活动报名时间内")
 	}
-	// 检查用户信息是否完整
+
+	return svc.registerEvent(ctx, event, userID, answers)
+}
+
+// registerEvent 为用户报名活动，调用前需检查活动是否有效以及是否在报名时间内
+func (svc *EventServiceImpl) registerEvent(ctx context.Context, event *model.Event, userID int, answers []dto.FormAnswer) (*dto.EventRegistrationResponse, error) {
+	eventID := event.ID
+	if event.IsCancelled == utils.FlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消，无法报名")
+	}
+	// 检查用户信息是否已填写报名表单要求的字段
 	user, err := svc.userRepo.GetUserByID(ctx, userID)
 	if err != nil || user == nil {
-		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "加载用户信息失败")
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "加载用户信息失败")
 	}
-	if user.Name == "" || user.PhoneNumber == "" || user.Email == "" || user.Unit == "" || user.Department == "" || user.Position == "" || user.Industry == "" {
-		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "用户信息不完整，请完善个人信息")
+	form := effectiveRegistrationForm(event.RegistrationForm)
+	if err := checkRequiredProfile(user, form); err != nil {
+		return nil, err
 	}
 
-	// 执行活动报名逻辑
-	mapping, err = svc.eventRepo.GetEventUserMap(ctx, eventID, userID)
-	if err != nil {
-		return err
-	}
-	// 如果关联关系不存在，则创建新的关联关系
-	if mapping == nil {
-		mapping = &model.EventUserMapping{
-			UserID:  userID,
-			EventID: eventID,
-		}
-		err = svc.eventRepo.CreatEventUserMap(ctx, mapping)
+	// 执行活动报名逻辑，锁定活动后统计报名人数，并发报名时不会超出人数上限
+	var mapping *model.EventUserMapping
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		locked, err := svc.eventRepo.LockEvent(ctx, tx, eventID)
 		if err != nil {
 			return err
 		}
-	} else if mapping.IsDeleted == utils.DeletedFlagYes {
-		// 如果关联关系软删除了，则恢复
-		err = svc.eventRepo.UpdateEUMapDeleteFlag(ctx, eventID, userID, utils.DeletedFlagNo)
+		if locked.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
+		}
+
+		mapping, err = svc.eventRepo.LockEventUserMap(ctx, tx, eventID, userID)
 		if err != nil {
 			return err
 		}
-	} else if mapping.IsDeleted == utils.DeletedFlagNo {
 		// 如果关联关系存在且有效，则返回错误提示
-		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已报名该活动，请勿重复报名")
+		if mapping != nil && mapping.IsDeleted == utils.DeletedFlagNo {
+			switch mapping.Status {
+			case model.RegistrationStatusWaitlisted:
+				return utils.NewBusinessError(utils.ErrCodeResourceExists, "已在活动候补名单中，请勿重复报名")
+			case model.RegistrationStatusPending:
+				return utils.NewBusinessError(utils.ErrCodeResourceExists, "报名正在审核中，请耐心等待")
+			case model.RegistrationStatusRejected:
+				return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "报名未通过审核: "+mapping.ReviewReason)
+			case model.RegistrationStatusUnpaid:
+				return utils.NewBusinessError(utils.ErrCodeResourceExists, "已报名该活动，请在支付时限内完成支付")
+			}
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "已报名该活动，请勿重复报名")
+		}
+
+		// 校验报名表单附加问题的答案
+		mappingID := 0
+		if mapping != nil {
+			mappingID = mapping.ID
+		}
+		formAnswers, imageIDs, err := svc.buildAnswers(ctx, form, answers, userID, mappingID)
+		if err != nil {
+			return err
+		}
+
+		// 需要审核的活动先进入待审核状态，审核通过时再检查人数上限；否则活动人数已满时进入候补名单
+		// 收费活动占用名额后进入待支付状态，支付完成后才算正式报名
+		status := svc.seatStatus(locked)
+		var waitlistTime *time.Time
+		if locked.RequireApproval == utils.FlagYes {
+			status = model.RegistrationStatusPending
+		} else if locked.MaxParticipants > 0 {
+			count, err := svc.eventRepo.CountOccupiedSeats(ctx, tx, eventID)
+			if err != nil {
+				return err
+			}
+			if count >= int64(locked.MaxParticipants) {
+				now := time.Now()
+				status = model.RegistrationStatusWaitlisted
+				waitlistTime = &now
+			}
+		}
+
+		if mapping == nil {
+			// 如果关联关系不存在，则创建新的关联关系
+			mapping = &model.EventUserMapping{
+				UserID:       userID,
+				EventID:      eventID,
+				Status:       status,
+				WaitlistTime: waitlistTime,
+				Answers:      formAnswers,
+			}
+			if err := svc.eventRepo.CreatEventUserMap(ctx, tx, mapping); err != nil {
+				return err
+			}
+		} else {
+			// 如果关联关系软删除了，则恢复，重新报名时按新的报名时间排队并覆盖上次的答案和签到记录
+			// 报名时间更新后，取消报名前的签到码随之失效
+			mapping.Status = status
+			mapping.WaitlistTime = waitlistTime
+			if err := svc.eventRepo.UpdateEventUserMap(ctx, tx, eventID, userID, map[string]interface{}{
+				"is_deleted":    utils.DeletedFlagNo,
+				"status":        status,
+				"waitlist_time": waitlistTime,
+				"answers":       formAnswers,
+				"review_reason": "",
+				"review_time":   nil,
+				"review_user":   0,
+				"check_in_time": nil,
+				"check_in_user": 0,
+				"create_time":   time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+
+		// 文件上传题的图片关联到本次报名
+		if err := svc.fileRepo.BatchUpdateImageBizID(ctx, tx, imageIDs, mapping.ID, utils.TypeRegistration); err != nil {
+			return err
+		}
+
+		if status != model.RegistrationStatusUnpaid {
+			return nil
+		}
+		return svc.createOrders(ctx, tx, locked, []int{userID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 待审核、候补中和待支付的用户审核通过、转为正式报名或支付完成后再进入消息群组
+	if mapping.Status == model.RegistrationStatusPending {
+		return &dto.EventRegistrationResponse{Status: model.RegistrationStatusPending}, nil
+	}
+	if mapping.Status == model.RegistrationStatusWaitlisted || mapping.Status == model.RegistrationStatusUnpaid {
+		return svc.GetRegistrationStatus(ctx, eventID, userID)
 	}
 
 	// 报名成功后，添加用户到活动对应的消息群组，消息群组添加失败不影响报名成功
@@ -162,15 +413,15 @@ func (svc *EventServiceImpl) RegistrationEvent(ctx context.Context, eventID int,
 	group, count, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", eventID, "")
 	if err != nil || count == 0 {
 		// 不存在对应的消息群组，返回错误
-		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "进入活动消息群组失败，请联系管理员")
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "进入活动消息群组失败，请联系管理员")
 	}
 	// 将用户添加到消息群组
 	err = svc.msgSvc.AddUserToGroup(ctx, group[0].ID, []int{userID}, userID)
 	if err != nil {
-		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "进入活动消息群组失败，请联系管理员")
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "进入活动消息群组失败，请联系管理员")
 	}
 
-	return nil
+	return &dto.EventRegistrationResponse{Status: model.RegistrationStatusRegistered}, nil
 }
 
 // CancelRegistrationEvent 取消活动报名
@@ -185,20 +436,74 @@ func (svc *EventServiceImpl) CancelRegistrationEvent(ctx context.Context, eventI
 		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
 	}
 
+	// 已取消的活动报名已失效，已支付的报名费用在取消活动时退款
+	if event.IsCancelled == utils.FlagYes {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消，无需取消报名")
+	}
+
 	// 检查活动是否已开始
 	if event.EventStartTime.Before(time.Now()) {
 		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已开始，无法取消报名")
 	}
 
-	// 执行取消报名逻辑
-	err = svc.eventRepo.UpdateEUMapDeleteFlag(ctx, eventID, userID, utils.DeletedFlagYes)
+	// 执行取消报名逻辑，正式报名和待支付的用户取消后由候补用户依次补位
+	var mapping *model.EventUserMapping
+	var promoted []int
+	var refunding *model.EventOrder
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		locked, err := svc.eventRepo.LockEvent(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		mapping, err = svc.eventRepo.LockEventUserMap(ctx, tx, eventID, userID)
+		if err != nil {
+			return err
+		}
+		if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes || mapping.Status == model.RegistrationStatusRejected {
+			return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "未报名该活动，请刷新页面后重试")
+		}
+
+		if err := svc.eventRepo.UpdateEventUserMap(ctx, tx, eventID, userID, map[string]interface{}{
+			"is_deleted":    utils.DeletedFlagYes,
+			"waitlist_time": nil,
+		}); err != nil {
+			return err
+		}
+
+		refunding, err = svc.closeRegistrationOrder(ctx, tx, event, mapping)
+		if err != nil {
+			return err
+		}
+
+		if mapping.Status != model.RegistrationStatusRegistered && mapping.Status != model.RegistrationStatusUnpaid {
+			return nil
+		}
+		promoted, err = svc.promoteWaitlist(ctx, tx, locked)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	// 退款失败不影响取消报名成功，由定时任务重试
+	if refunding != nil {
+		if err := svc.refundOrder(ctx, refunding); err != nil {
+			logrus.Errorf("%v，将由定时任务重试", err)
+		}
+	}
+
+	// 候补转正的用户进入消息群组并收到通知，通知失败不影响取消报名成功
+	svc.notifyPromoted(ctx, event, promoted, userID)
+
+	// 待审核、候补中和待支付的用户未进入消息群组，无需移除
+	if mapping.Status != model.RegistrationStatusRegistered {
+		return nil
+	}
+
 	// 取消报名成功后，将用户从活动对应的消息群组移除
 	group, _, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", eventID, "")
-	if err != nil {
+	if err != nil || len(group) == 0 {
 		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "退出活动消息群组失败，请联系管理员处理")
 	}
 	// 将用户从消息群组移除
@@ -210,14 +515,270 @@ func (svc *EventServiceImpl) CancelRegistrationEvent(ctx context.Context, eventI
 	return nil
 }
 
-// IsUserRegistered 查询用户是否已报名活动
-func (svc *EventServiceImpl) IsUserRegistered(ctx context.Context, eventID int, userID int) (bool, error) {
-	return svc.eventRepo.IsUserRegistered(ctx, eventID, userID)
+// promoteWaitlist 按候补顺序将候补用户转为正式报名，直到达到人数上限，返回转正的用户ID
+// 收费活动的候补用户转为待支付并生成订单，调用前需在同一事务中锁定活动
+func (svc *EventServiceImpl) promoteWaitlist(ctx context.Context, tx *gorm.DB, event *model.Event) ([]int, error) {
+	if event.IsCancelled == utils.FlagYes {
+		return nil, nil // 已取消的活动不再补位
+	}
+	limit := 0 // 不限制人数时全部转正
+	if event.MaxParticipants > 0 {
+		count, err := svc.eventRepo.CountOccupiedSeats(ctx, tx, event.ID)
+		if err != nil {
+			return nil, err
+		}
+		limit = event.MaxParticipants - int(count)
+		if limit <= 0 {
+			return nil, nil
+		}
+	}
+
+	userIDs, err := svc.eventRepo.ListWaitlistedUserIDs(ctx, tx, event.ID, limit)
+	if err != nil || len(userIDs) == 0 {
+		return nil, err
+	}
+	status := svc.seatStatus(event)
+	if err := svc.eventRepo.PromoteWaitlistedUsers(ctx, tx, event.ID, userIDs, status); err != nil {
+		return nil, err
+	}
+	if status == model.RegistrationStatusUnpaid {
+		if err := svc.createOrders(ctx, tx, event, userIDs); err != nil {
+			return nil, err
+		}
+	}
+	return userIDs, nil
+}
+
+// notifyPromoted 将候补转正的用户添加到活动消息群组，并在群组中发送转正通知
+// 收费活动的用户支付完成后才进入活动消息群组，单独通知其完成支付；报名已生效，失败时只记录日志
+func (svc *EventServiceImpl) notifyPromoted(ctx context.Context, event *model.Event, userIDs []int, operateUser int) {
+	if len(userIDs) == 0 {
+		return
+	}
+	if svc.requiresPayment(event) {
+		svc.notifyUsers(ctx, event, userIDs, "候补转正通知",
+			fmt.Sprintf("活动「%s」已有名额空出，已为您保留名额，请在%s前完成支付，逾期名额将自动释放。",
+				event.Title, svc.payDeadline().Format(time.DateTime)), operateUser)
+		return
+	}
+
+	group, count, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", event.ID, "")
+	if err != nil || count == 0 {
+		logrus.Errorf("活动[%d]候补转正通知失败，未找到活动消息群组: %v", event.ID, err)
+		return
+	}
+	if err := svc.msgSvc.AddUserToGroup(ctx, group[0].ID, userIDs, operateUser); err != nil {
+		logrus.Errorf("活动[%d]候补转正用户进入消息群组失败: %v", event.ID, err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		name := strconv.Itoa(userID)
+		if user, err := svc.userRepo.GetUserByID(ctx, userID); err == nil && user != nil && user.Nickname != "" {
+			name = user.Nickname
+		}
+		msg := &msgmodel.Message{
+			Title:      "候补转正通知",
+			Content:    fmt.Sprintf("%s，活动「%s」已有名额空出，您已由候补转为正式报名，请按时参加。", name, event.Title),
+			SendTime:   time.Now(),
+			CreateUser: operateUser,
+			UpdateUser: operateUser,
+		}
+		if err := svc.sendSvc.SendMessage(ctx, group[0].ID, msg); err != nil {
+			logrus.Errorf("活动[%d]向用户[%d]发送候补转正通知失败: %v", event.ID, userID, err)
+		}
+	}
 }
 
-// ListUserRegisteredEvents 获取用户已报名的活动列表
-func (svc *EventServiceImpl) ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string) ([]*model.Event, int, error) {
-	return svc.eventRepo.ListUserRegisteredEvents(ctx, page, pageSize, userID, eventStatus)
+// GetRegistrationStatus 查询用户的活动报名状态，候补中时返回候补位置
+func (svc *EventServiceImpl) GetRegistrationStatus(ctx context.Context, eventID int, userID int) (*dto.EventRegistrationResponse, error) {
+	mapping, err := svc.eventRepo.GetEventUserMap(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil || mapping.IsDeleted == utils.DeletedFlagYes {
+		return &dto.EventRegistrationResponse{}, nil
+	}
+
+	res := &dto.EventRegistrationResponse{Status: mapping.Status, ReviewReason: mapping.ReviewReason}
+	switch mapping.Status {
+	case model.RegistrationStatusWaitlisted:
+		res.WaitlistPosition, err = svc.eventRepo.GetWaitlistPosition(ctx, mapping)
+		if err != nil {
+			return nil, err
+		}
+	case model.RegistrationStatusUnpaid:
+		order, err := svc.orderRepo.GetUserOrder(ctx, eventID, userID, model.OrderStatusPending)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+			if err != nil {
+				return nil, err
+			}
+			res.Order = toOrderResponse(order, event.Title)
+		}
+	}
+	return res, nil
+}
+
+// ReviewRegistrations 批量审核待审核的报名，已处理过的报名跳过
+// 通过的报名按报名先后占用名额，活动人数已满时进入候补名单，收费活动占用名额后生成待支付订单
+func (svc *EventServiceImpl) ReviewRegistrations(ctx context.Context, eventID int, req dto.ReviewRegistrationRequest, userID int) (*dto.ReviewRegistrationResponse, error) {
+	// 检查活动是否存在
+	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsDeleted == utils.DeletedFlagYes {
+		return nil, utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if req.Action == model.ReviewActionReject && reason == "" {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "驳回报名时请填写原因")
+	}
+
+	res := &dto.ReviewRegistrationResponse{Approved: []int{}, Waitlisted: []int{}, Rejected: []int{}, Skipped: []int{}}
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		locked, err := svc.eventRepo.LockEvent(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+		// 在锁定活动后检查，避免与取消活动并发时审核通过的用户生成订单
+		if locked.IsCancelled == utils.FlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消，无法审核报名")
+		}
+		pending, err := svc.eventRepo.ListPendingUserIDs(ctx, tx, eventID, req.UserIDs)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		reviewed := map[string]interface{}{
+			"review_reason": reason,
+			"review_time":   now,
+			"review_user":   userID,
+		}
+		withStatus := func(fields map[string]interface{}) map[string]interface{} {
+			for key, value := range reviewed {
+				fields[key] = value
+			}
+			return fields
+		}
+
+		if req.Action == model.ReviewActionReject {
+			res.Rejected = pending
+			return svc.eventRepo.UpdateEventUserMaps(ctx, tx, eventID, pending, withStatus(map[string]interface{}{
+				"status": model.RegistrationStatusRejected,
+			}))
+		}
+
+		// 计算剩余名额，超出名额的报名进入候补名单
+		free := len(pending)
+		if locked.MaxParticipants > 0 {
+			count, err := svc.eventRepo.CountOccupiedSeats(ctx, tx, eventID)
+			if err != nil {
+				return err
+			}
+			free = min(max(locked.MaxParticipants-int(count), 0), len(pending))
+		}
+		res.Approved = pending[:free]
+		res.Waitlisted = pending[free:]
+		status := svc.seatStatus(locked)
+		if err := svc.eventRepo.UpdateEventUserMaps(ctx, tx, eventID, res.Approved, withStatus(map[string]interface{}{
+			"status": status,
+		})); err != nil {
+			return err
+		}
+		if err := svc.eventRepo.UpdateEventUserMaps(ctx, tx, eventID, res.Waitlisted, withStatus(map[string]interface{}{
+			"status":        model.RegistrationStatusWaitlisted,
+			"waitlist_time": now,
+		})); err != nil {
+			return err
+		}
+		if status != model.RegistrationStatusUnpaid {
+			return nil
+		}
+		return svc.createOrders(ctx, tx, locked, res.Approved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	processed := make(map[int]bool)
+	for _, ids := range [][]int{res.Approved, res.Waitlisted, res.Rejected} {
+		for _, id := range ids {
+			processed[id] = true
+		}
+	}
+	for _, id := range req.UserIDs {
+		if !processed[id] {
+			res.Skipped = append(res.Skipped, id)
+		}
+	}
+
+	// 通过审核的用户进入活动消息群组，收费活动支付完成后再进入，审核结果通知失败不影响审核结果
+	approvedContent := fmt.Sprintf("您报名的活动「%s」已通过审核，请按时参加。", event.Title)
+	if svc.requiresPayment(event) {
+		approvedContent = fmt.Sprintf("您报名的活动「%s」已通过审核，请在%s前完成支付，逾期名额将自动释放。",
+			event.Title, svc.payDeadline().Format(time.DateTime))
+	} else if len(res.Approved) > 0 {
+		group, count, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", eventID, "")
+		if err != nil || count == 0 {
+			logrus.Errorf("活动[%d]审核通过的用户进入消息群组失败，未找到活动消息群组: %v", eventID, err)
+		} else if err := svc.msgSvc.AddUserToGroup(ctx, group[0].ID, res.Approved, userID); err != nil {
+			logrus.Errorf("活动[%d]审核通过的用户进入消息群组失败: %v", eventID, err)
+		}
+	}
+	svc.notifyReviewResult(ctx, event, res.Approved, approvedContent, reason, userID)
+	svc.notifyReviewResult(ctx, event, res.Waitlisted, fmt.Sprintf("您报名的活动「%s」已通过审核，因活动人数已满，已为您加入候补名单，有名额空出时将自动转为正式报名。", event.Title), reason, userID)
+	svc.notifyReviewResult(ctx, event, res.Rejected, fmt.Sprintf("很抱歉，您报名的活动「%s」未通过审核。", event.Title), reason, userID)
+
+	return res, nil
+}
+
+// notifyReviewResult 向同一批审核结果相同的用户发送审核结果通知
+func (svc *EventServiceImpl) notifyReviewResult(ctx context.Context, event *model.Event, userIDs []int, content string, reason string, operateUser int) {
+	if reason != "" {
+		content += "审核意见: " + reason
+	}
+	svc.notifyUsers(ctx, event, userIDs, "活动报名审核结果", content, operateUser)
+}
+
+// notifyUsers 向指定用户发送活动相关的通知
+// 消息群组内的消息对所有成员可见，因此通知发送到各用户的个人通知群组，不使用活动消息群组
+func (svc *EventServiceImpl) notifyUsers(ctx context.Context, event *model.Event, userIDs []int, title string, content string, operateUser int) {
+	for _, userID := range userIDs {
+		group, err := svc.msgSvc.GetUserNoticeGroup(ctx, userID, operateUser)
+		if err != nil {
+			logrus.Errorf("活动[%d]获取用户[%d]的个人通知群组失败: %v", event.ID, userID, err)
+			continue
+		}
+		msg := &msgmodel.Message{
+			Title:      title,
+			Content:    content,
+			SendTime:   time.Now(),
+			CreateUser: operateUser,
+			UpdateUser: operateUser,
+		}
+		if err := svc.sendSvc.SendMessage(ctx, group.ID, msg); err != nil {
+			logrus.Errorf("活动[%d]向用户[%d]发送%s失败: %v", event.ID, userID, title, err)
+		}
+	}
+}
+
+// ListUserRegisteredEvents 获取用户已报名的活动列表，并计算各活动的活动状态和报名状态
+func (svc *EventServiceImpl) ListUserRegisteredEvents(ctx context.Context, page, pageSize int, userID int, eventStatus string, registrationStatus string) ([]*dto.EventListResponse, int, error) {
+	events, total, err := svc.eventRepo.ListUserRegisteredEvents(ctx, page, pageSize, userID, eventStatus, registrationStatus)
+	if err != nil {
+		return nil, 0, err
+	}
+	fillListStatus(events)
+	for _, event := range events {
+		event.CheckInToken = svc.checkInToken(event.ID, userID, event.RegistrationID, event.RegistrationTime)
+	}
+	return events, total, nil
 }
 
 // CreateEvent 创建活动
@@ -239,6 +800,26 @@ func (svc *EventServiceImpl) CreateEvent(ctx context.Context, event *model.Event
 		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "报名开始时间不能晚于结束时间")
 	}
 
+	// 检查报名表单设置
+	if err := validateRegistrationForm(event.RegistrationForm); err != nil {
+		return err
+	}
+
+	// 过滤活动详情中的不安全内容并提取纯文本
+	processed := svc.processor.Process(event.Detail)
+	if strings.TrimSpace(processed.HTML) == "" {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "活动详情不能为空")
+	}
+	event.Detail = processed.HTML
+	event.DetailText = processed.Text
+
+	// 检查或生成别名
+	slug, err := svc.resolveSlug(ctx, event.Slug, event.Title, 0)
+	if err != nil {
+		return err
+	}
+	event.Slug = slug
+
 	// 使用 GORM 函数式事务
 	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
 		// 创建活动
@@ -262,6 +843,11 @@ func (svc *EventServiceImpl) CreateEvent(ctx context.Context, event *model.Event
 	}
 
 	// 活动创建成功后，创建活动消息群组，消息群组创建失败不影响活动创建成功
+	return svc.createEventMsgGroup(ctx, event)
+}
+
+// createEventMsgGroup 创建活动消息群组，报名成功的用户进入该群组接收活动通知
+func (svc *EventServiceImpl) createEventMsgGroup(ctx context.Context, event *model.Event) error {
 	// 构建消息群组模型
 	// 检查是否已存在对应的消息群组，理论上不应该存在
 	_, count, _ := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", event.ID, "")
@@ -269,31 +855,41 @@ func (svc *EventServiceImpl) CreateEvent(ctx context.Context, event *model.Event
 		// 已存在对应的消息群组，直接返回成功，(在正确的业务流程下不应该出现这种情况)
 		return nil
 	}
+	// 系列各场次的标题相同，群组名称附带场次日期以便区分
+	groupName := event.Title
+	if event.SeriesID > 0 {
+		groupName += " " + event.EventStartTime.Format(time.DateOnly)
+	}
 	msgGroup := &msgmodel.UserMessageGroup{
-		GroupName:      event.Title,
-		Desc:           "由活动" + event.Title + "自动创建",
+		GroupName:      groupName,
+		Desc:           "由活动" + groupName + "自动创建",
 		EventID:        event.ID,
 		IncludeAllUser: utils.FlagNo,
 		CreateUser:     event.CreateUser,
 		UpdateUser:     event.UpdateUser,
 	}
-	if err = svc.msgSvc.CreateMsgGroup(ctx, msgGroup, []int{}); err != nil {
+	if err := svc.msgSvc.CreateMsgGroup(ctx, msgGroup, []int{}); err != nil {
 		return utils.NewBusinessError(utils.ErrCodeServerInternalError, "自动创建活动消息群组失败"+err.Error())
 	}
 
 	return nil
 }
 
-// UpdateEvent 更新活动
+// UpdateEvent 更新活动，单独修改系列场次后，修改系列时不再同步到该场次
 func (svc *EventServiceImpl) UpdateEvent(ctx context.Context, eventID int, req dto.UpdateEventRequest, userID int) error {
+	return svc.updateEvent(ctx, eventID, req, userID, true)
+}
+
+// updateEvent 更新活动，override 表示是否为单独修改，修改系列同步到各场次时为false
+func (svc *EventServiceImpl) updateEvent(ctx context.Context, eventID int, req dto.UpdateEventRequest, userID int, override bool) error {
 	// 检查活动是否存在
 	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
 	if err != nil {
 		return err
 	}
 
-	// 当标题修改时，检查是否有重复的活动标题
-	if req.Title != nil && *req.Title != event.Title {
+	// 当标题修改时，检查是否有重复的活动标题，系列场次的标题与系列相同，不参与查重
+	if req.Title != nil && *req.Title != event.Title && event.SeriesID == 0 {
 		existingEvent, err := svc.eventRepo.GetEventByTitle(ctx, *req.Title)
 		if err != nil {
 			return err
@@ -309,6 +905,36 @@ func (svc *EventServiceImpl) UpdateEvent(ctx context.Context, eventID int, req d
 		return err
 	}
 
+	// 检查报名表单设置
+	if req.RegistrationForm != nil {
+		if err := validateRegistrationForm(req.RegistrationForm); err != nil {
+			return err
+		}
+		updateFields["registration_form"] = req.RegistrationForm
+	}
+
+	// 过滤活动详情中的不安全内容并提取纯文本
+	if req.Detail != nil {
+		processed := svc.processor.Process(*req.Detail)
+		if strings.TrimSpace(processed.HTML) == "" {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "活动详情不能为空")
+		}
+		updateFields["detail"] = processed.HTML
+		updateFields["detail_text"] = processed.Text
+	}
+
+	// 处理别名，传空字符串表示清除别名；标题修改时不自动变更别名，保证已有链接可用
+	if req.Slug != nil {
+		if *req.Slug == "" {
+			updateFields["slug"] = gorm.Expr("NULL")
+		} else if *req.Slug != event.Slug {
+			if _, err := svc.resolveSlug(ctx, *req.Slug, "", eventID); err != nil {
+				return err
+			}
+			updateFields["slug"] = *req.Slug
+		}
+	}
+
 	var imageIDList []int
 	if req.ImageIDList != nil {
 		imageIDList = *req.ImageIDList
@@ -320,14 +946,50 @@ func (svc *EventServiceImpl) UpdateEvent(ctx context.Context, eventID int, req d
 
 	// 设置更新人
 	updateFields["update_user"] = userID
+	if override && event.SeriesID > 0 {
+		updateFields["series_overridden"] = utils.FlagYes
+	}
+
+	// 日历中展示的内容变更时递增修订序号，已订阅的日历据此更新
+	for _, field := range calendarFields {
+		if _, ok := updateFields[field]; ok {
+			updateFields["sequence"] = gorm.Expr("sequence + 1")
+			break
+		}
+	}
+
+	// 更新后的活动，用于候补转正，同时修改报名费用时转正的用户按新的费用生成订单
+	updated := *event
+	if req.MaxParticipants != nil {
+		updated.MaxParticipants = *req.MaxParticipants
+	}
+	if req.RegistrationFee != nil {
+		updated.RegistrationFee = *req.RegistrationFee
+	}
 
 	// 使用 GORM 函数式事务
+	var promoted []int
 	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		// 修改人数上限时先锁定活动，与报名串行执行
+		if req.MaxParticipants != nil {
+			if _, err := svc.eventRepo.LockEvent(ctx, tx, eventID); err != nil {
+				return err
+			}
+		}
+
 		// 更新活动
 		if err := svc.eventRepo.UpdateEvent(ctx, tx, eventID, updateFields); err != nil {
 			return err
 		}
 
+		// 调大或取消人数上限后，候补用户按顺序转为正式报名
+		if req.MaxParticipants != nil {
+			var err error
+			if promoted, err = svc.promoteWaitlist(ctx, tx, &updated); err != nil {
+				return err
+			}
+		}
+
 		// 如果有图片，更新images表的biz_id和biz_type
 		if len(imageIDList) > 0 {
 			if err := svc.fileRepo.BatchUpdateImageBizID(ctx, tx, imageIDList, eventID, utils.TypeEvent); err != nil {
@@ -343,6 +1005,8 @@ func (svc *EventServiceImpl) UpdateEvent(ctx context.Context, eventID int, req d
 		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
 	}
 
+	svc.notifyPromoted(ctx, &updated, promoted, userID)
+
 	return nil
 }
 
@@ -402,18 +1066,27 @@ func makeUpdateFields(event *model.Event, req dto.UpdateEventRequest) (map[strin
 	if req.Title != nil {
 		updateFields["title"] = *req.Title
 	}
-	if req.Detail != nil {
-		updateFields["detail"] = *req.Detail
-	}
 	if req.EventAddress != nil {
 		updateFields["event_address"] = *req.EventAddress
 	}
 	if req.RegistrationFee != nil {
 		updateFields["registration_fee"] = *req.RegistrationFee
 	}
+	if req.MaxParticipants != nil {
+		updateFields["max_participants"] = *req.MaxParticipants
+	}
+	if req.RequireApproval != nil {
+		updateFields["require_approval"] = *req.RequireApproval
+	}
 	if req.CoverImageURL != nil {
 		updateFields["cover_image_url"] = *req.CoverImageURL
 	}
+	if req.MetaDescription != nil {
+		updateFields["meta_description"] = *req.MetaDescription
+	}
+	if req.OgImageURL != nil {
+		updateFields["og_image_url"] = *req.OgImageURL
+	}
 
 	return updateFields, nil
 }
@@ -432,9 +1105,24 @@ func (svc *EventServiceImpl) DeleteEvent(ctx context.Context, eventID int, userI
 
 	// 使用 GORM 函数式事务
 	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
-		// 软删除（更新is_deleted为Y，记录更新人）
+		// 存在已支付或退款中的订单时不能删除，需先取消活动并完成退款，避免报名费用无法退回
+		if _, err := svc.eventRepo.LockEvent(ctx, tx, eventID); err != nil {
+			return err
+		}
+		for _, status := range []string{model.OrderStatusPaid, model.OrderStatusRefunding} {
+			orders, err := svc.orderRepo.LockEventOrders(ctx, tx, eventID, status)
+			if err != nil {
+				return err
+			}
+			if len(orders) > 0 {
+				return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动存在已支付或退款中的订单，请先取消活动并等待退款完成后再删除")
+			}
+		}
+
+		// 软删除（更新is_deleted为Y，记录更新人），递增修订序号使已订阅的日历将活动标记为已取消
 		updateFields := map[string]interface{}{
 			"is_deleted":  utils.DeletedFlagYes,
+			"sequence":    gorm.Expr("sequence + 1"),
 			"update_user": userID,
 		}
 		if err := svc.eventRepo.UpdateEvent(ctx, tx, eventID, updateFields); err != nil {
@@ -447,6 +1135,9 @@ func (svc *EventServiceImpl) DeleteEvent(ctx context.Context, eventID int, userI
 
 	// 处理事务执行结果
 	if err != nil {
+		if _, ok := utils.GetBusinessError(err); ok {
+			return err
+		}
 		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
 	}
 	// 删除活动成功后，删除活动对应的消息群组，消息群组删除失败不影响活动删除成功
@@ -464,7 +1155,82 @@ func (svc *EventServiceImpl) DeleteEvent(ctx context.Context, eventID int, userI
 	return nil
 }
 
-// ListEventRegisteredUser 获取活动报名用户列表
-func (svc *EventServiceImpl) ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int) ([]*dto.ListEventRegUserResponse, int, error) {
-	return svc.eventRepo.ListEventRegisteredUser(ctx, page, pageSize, eventID)
+// RestoreEvent 恢复已删除的活动及随活动删除的消息群组，恢复前重新检查标题是否与未删除的活动重复
+func (svc *EventServiceImpl) RestoreEvent(ctx context.Context, eventID int, userID int) error {
+	// 检查活动是否存在且已删除
+	event, err := svc.eventRepo.GetEventDetail(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if event.IsDeleted != utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动未被删除，无需恢复")
+	}
+
+	// 删除期间可能已创建同名活动，系列场次不参与查重
+	existingEvent, err := svc.eventRepo.GetEventByTitle(ctx, event.Title)
+	if err != nil {
+		return err
+	}
+	if existingEvent != nil && event.SeriesID == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名活动，请修改同名活动的标题后重试")
+	}
+
+	// 使用 GORM 函数式事务
+	err = svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		updateFields := map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagNo,
+			"sequence":    gorm.Expr("sequence + 1"),
+			"update_user": userID,
+		}
+		return svc.eventRepo.UpdateEvent(ctx, tx, eventID, updateFields)
+	})
+
+	// 处理事务执行结果
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+	// 恢复活动成功后，恢复活动对应的消息群组
+	group, count, err := svc.msgSvc.ListMsgGroups(ctx, 0, 0, "", eventID, utils.QueryScopeDeleted)
+	if err != nil || count == 0 {
+		// 不存在已删除的消息群组，直接返回成功
+		return nil
+	}
+	err = svc.msgSvc.RestoreMsgGroup(ctx, group[0].ID, userID)
+	if err != nil {
+		return utils.NewBusinessError(utils.ErrCodeServerInternalError, "恢复活动消息群组失败"+err.Error())
+	}
+	return nil
+}
+
+// ListEventRegisteredUser 按报名状态获取活动报名用户列表，未指定状态时查询已报名用户
+func (svc *EventServiceImpl) ListEventRegisteredUser(ctx context.Context, page, pageSize int, eventID int, status string) ([]*dto.ListEventRegUserResponse, int, error) {
+	if status == "" {
+		status = model.RegistrationStatusRegistered
+	}
+	return svc.eventRepo.ListEventRegisteredUser(ctx, page, pageSize, eventID, status)
+}
+
+// FillDetailText 分批为尚未提取纯文本的活动补全详情纯文本，直到全部处理完成
+func (svc *EventServiceImpl) FillDetailText(ctx context.Context) error {
+	total := 0
+	for {
+		events, err := svc.eventRepo.ListEventsWithoutText(ctx, detailTextBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := svc.eventRepo.UpdateEventDetailText(ctx, event.ID, svc.processor.Process(event.Detail).Text); err != nil {
+				return err
+			}
+		}
+		total += len(events)
+		if len(events) < detailTextBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logrus.Infof("已补全 %d 个活动的详情纯文本", total)
+	}
+	return nil
 }
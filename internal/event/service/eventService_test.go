@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"testing"
+	"time"
+)
+
+func TestReviewRegistrationsCancelledEvent(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+	event.RequireApproval = utils.FlagYes
+	env.register(t, event.ID, 101)
+
+	// 活动在审核前被取消
+	env.events.events[event.ID].IsCancelled = utils.FlagYes
+	_, err := env.svc.ReviewRegistrations(context.Background(), event.ID, dto.ReviewRegistrationRequest{
+		Action:  model.ReviewActionApprove,
+		UserIDs: []int{101},
+	}, 1)
+	assertBusinessError(t, err, "活动已取消")
+	if got := env.status(event.ID, 101); got != model.RegistrationStatusPending {
+		t.Errorf("报名状态为 %s，期望保持 %s", got, model.RegistrationStatusPending)
+	}
+	if env.orders.userOrder(event.ID, 101) != nil {
+		t.Error("已取消的活动审核时生成了订单")
+	}
+}
+
+func TestCancelRegistrationRefund(t *testing.T) {
+	tests := []struct {
+		name       string
+		startAfter time.Duration
+		want       string
+	}{
+		{name: "退款截止时间前全额退款", startAfter: 48 * time.Hour, want: model.OrderStatusRefunded},
+		{name: "超过退款截止时间不退款", startAfter: 12 * time.Hour, want: model.OrderStatusPaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			event := env.addEvent(50, 10)
+			event.EventStartTime = time.Now().Add(tt.startAfter)
+			env.register(t, event.ID, 101)
+			env.pay(t, event.ID, 101)
+
+			if err := env.svc.CancelRegistrationEvent(context.Background(), event.ID, 101); err != nil {
+				t.Fatalf("取消报名失败: %v", err)
+			}
+			if got := env.orders.userOrder(event.ID, 101).Status; got != tt.want {
+				t.Errorf("订单状态为 %s，期望 %s", got, tt.want)
+			}
+			if env.groups.members[101] {
+				t.Error("取消报名的用户未退出活动消息群组")
+			}
+		})
+	}
+}
+
+func TestDeleteEventWithPaidOrders(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+	env.register(t, event.ID, 101)
+	env.pay(t, event.ID, 101)
+
+	ctx := context.Background()
+	err := env.svc.DeleteEvent(ctx, event.ID, 1)
+	assertBusinessError(t, err, "已支付或退款中的订单")
+	if event.IsDeleted != utils.DeletedFlagNo {
+		t.Fatal("存在已支付订单的活动被删除")
+	}
+
+	// 取消活动并完成退款后可以删除
+	if err := env.svc.CancelEvent(ctx, event.ID, "场地调整", 1); err != nil {
+		t.Fatalf("取消活动失败: %v", err)
+	}
+	if err := env.svc.DeleteEvent(ctx, event.ID, 1); err != nil {
+		t.Fatalf("退款完成后删除活动失败: %v", err)
+	}
+	if event.IsDeleted != utils.DeletedFlagYes {
+		t.Error("退款完成后活动未删除")
+	}
+}
+
+func TestRegistrationCapacityAndWaitlist(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(0, 2)
+	ctx := context.Background()
+	for _, userID := range []int{101, 102, 103, 104} {
+		env.register(t, event.ID, userID)
+	}
+
+	_, err := env.svc.RegistrationEvent(ctx, event.ID, 103, nil)
+	assertBusinessError(t, err, "候补名单")
+
+	tests := []struct {
+		userID   int
+		status   string
+		position int
+	}{
+		{userID: 101, status: model.RegistrationStatusRegistered},
+		{userID: 102, status: model.RegistrationStatusRegistered},
+		{userID: 103, status: model.RegistrationStatusWaitlisted, position: 1},
+		{userID: 104, status: model.RegistrationStatusWaitlisted, position: 2},
+	}
+	for _, tt := range tests {
+		res, err := env.svc.GetRegistrationStatus(ctx, event.ID, tt.userID)
+		if err != nil {
+			t.Fatalf("查询用户[%d]报名状态失败: %v", tt.userID, err)
+		}
+		if res.Status != tt.status || res.WaitlistPosition != tt.position {
+			t.Errorf("用户[%d]报名状态为 %s，候补位置 %d，期望 %s，候补位置 %d",
+				tt.userID, res.Status, res.WaitlistPosition, tt.status, tt.position)
+		}
+		if got := env.groups.members[tt.userID]; got != (tt.status == model.RegistrationStatusRegistered) {
+			t.Errorf("用户[%d]是否在活动消息群组中为 %v", tt.userID, got)
+		}
+	}
+}
+
+func TestCancelRegistrationPromotesWaitlist(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(0, 1)
+	ctx := context.Background()
+	for _, userID := range []int{101, 102, 103} {
+		env.register(t, event.ID, userID)
+	}
+
+	// 候补用户取消不空出名额
+	if err := env.svc.CancelRegistrationEvent(ctx, event.ID, 103); err != nil {
+		t.Fatalf("候补用户取消报名失败: %v", err)
+	}
+	if got := env.status(event.ID, 102); got != model.RegistrationStatusWaitlisted {
+		t.Fatalf("候补用户取消后用户[102]报名状态为 %s，期望 %s", got, model.RegistrationStatusWaitlisted)
+	}
+
+	if err := env.svc.CancelRegistrationEvent(ctx, event.ID, 101); err != nil {
+		t.Fatalf("取消报名失败: %v", err)
+	}
+	if got := env.status(event.ID, 102); got != model.RegistrationStatusRegistered {
+		t.Errorf("用户[102]报名状态为 %s，期望候补转正 %s", got, model.RegistrationStatusRegistered)
+	}
+	if !env.groups.members[102] || env.groups.members[101] {
+		t.Errorf("活动消息群组成员为 %v，期望只有用户[102]", env.groups.members)
+	}
+	if occupied, _ := env.events.CountOccupiedSeats(ctx, nil, event.ID); occupied != 1 {
+		t.Errorf("占用名额 %d 个，期望不超过人数上限 1 个", occupied)
+	}
+}
+
+func TestCancelRegistrationPromotesWaitlistToUnpaid(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 1)
+	env.register(t, event.ID, 101)
+	env.pay(t, event.ID, 101)
+	env.register(t, event.ID, 102)
+
+	if err := env.svc.CancelRegistrationEvent(context.Background(), event.ID, 101); err != nil {
+		t.Fatalf("取消报名失败: %v", err)
+	}
+	if got := env.status(event.ID, 102); got != model.RegistrationStatusUnpaid {
+		t.Fatalf("用户[102]报名状态为 %s，期望 %s", got, model.RegistrationStatusUnpaid)
+	}
+	if order := env.orders.userOrder(event.ID, 102); order == nil || order.Status != model.OrderStatusPending {
+		t.Fatalf("候补转正的用户订单为 %+v，期望待支付订单", order)
+	}
+	if env.groups.members[102] {
+		t.Error("未支付的候补转正用户进入了活动消息群组")
+	}
+	if got := len(env.messages.noticesTo(102)); got != 1 {
+		t.Errorf("候补转正的用户收到 %d 条支付通知，期望 1 条", got)
+	}
+
+	env.pay(t, event.ID, 102)
+	if got := env.status(event.ID, 102); got != model.RegistrationStatusRegistered {
+		t.Errorf("支付后用户[102]报名状态为 %s，期望 %s", got, model.RegistrationStatusRegistered)
+	}
+}
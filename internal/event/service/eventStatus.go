@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/event/dto"
+	"news-release/internal/event/model"
+	"news-release/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// eventStatusLabels 活动状态的显示名称
+var eventStatusLabels = map[string]string{
+	model.EventStatusUpcoming:  "未开始",
+	model.EventStatusOngoing:   "进行中",
+	model.EventStatusEnded:     "已结束",
+	model.EventStatusCancelled: "已取消",
+}
+
+// registrationStatusLabels 报名状态的显示名称
+var registrationStatusLabels = map[string]string{
+	model.RegistrationStateNotOpen: "未开放报名",
+	model.RegistrationStateOpen:    "报名中",
+	model.RegistrationStateClosed:  "报名已截止",
+	model.RegistrationStateFull:    "名额已满",
+}
+
+// eventStatusLabel 根据活动时间计算活动状态，规则与按活动状态查询的条件保持一致
+func eventStatusLabel(cancelled bool, startTime, endTime, now time.Time) dto.StatusLabel {
+	code := model.EventStatusOngoing
+	switch {
+	case cancelled:
+		code = model.EventStatusCancelled
+	case startTime.After(now):
+		code = model.EventStatusUpcoming
+	case endTime.Before(now):
+		code = model.EventStatusEnded
+	}
+	return dto.StatusLabel{Code: code, Label: eventStatusLabels[code]}
+}
+
+// registrationStatusLabel 根据报名时间和已占用名额计算报名状态，规则与按报名状态查询的条件保持一致
+func registrationStatusLabel(cancelled bool, startTime, endTime time.Time, maxParticipants, occupied int, now time.Time) dto.StatusLabel {
+	code := model.RegistrationStateOpen
+	switch {
+	case cancelled || endTime.Before(now):
+		code = model.RegistrationStateClosed
+	case startTime.After(now):
+		code = model.RegistrationStateNotOpen
+	case maxParticipants > 0 && occupied >= maxParticipants:
+		code = model.RegistrationStateFull
+	}
+	return dto.StatusLabel{Code: code, Label: registrationStatusLabels[code]}
+}
+
+// fillListStatus 根据列表查询统计的已占用名额数计算各活动的活动状态和报名状态
+func fillListStatus(events []*dto.EventListResponse) {
+	now := time.Now()
+	for _, event := range events {
+		cancelled := event.IsCancelled == utils.FlagYes
+		event.EventStatus = eventStatusLabel(cancelled, event.EventStartTime, event.EventEndTime, now)
+		event.RegistrationStatus = registrationStatusLabel(cancelled, event.RegistrationStartTime, event.RegistrationEndTime,
+			event.MaxParticipants, event.OccupiedSeats, now)
+	}
+}
+
+// GetEventStatus 计算活动状态和报名状态，只有报名中且限制人数的活动需要统计已占用名额
+func (svc *EventServiceImpl) GetEventStatus(ctx context.Context, event *model.Event) (dto.StatusLabel, dto.StatusLabel, error) {
+	now := time.Now()
+	cancelled := event.IsCancelled == utils.FlagYes
+
+	var occupied int64
+	if !cancelled && event.MaxParticipants > 0 &&
+		!event.RegistrationStartTime.After(now) && !event.RegistrationEndTime.Before(now) {
+		var err error
+		if occupied, err = svc.eventRepo.GetOccupiedSeats(ctx, event.ID); err != nil {
+			return dto.StatusLabel{}, dto.StatusLabel{}, err
+		}
+	}
+
+	return eventStatusLabel(cancelled, event.EventStartTime, event.EventEndTime, now),
+		registrationStatusLabel(cancelled, event.RegistrationStartTime, event.RegistrationEndTime, event.MaxParticipants, int(occupied), now),
+		nil
+}
+
+// CancelEvent 取消活动，待支付的订单关闭，已支付的订单不受退款截止时间限制全额退款
+// 待审核、候补中和待支付的报名随活动关闭，取消后活动仍可查看，不能报名和签到，已订阅的日历将活动标记为已取消
+func (svc *EventServiceImpl) CancelEvent(ctx context.Context, eventID int, reason string, userID int) error {
+	var event *model.Event
+	var refunding []model.EventOrder
+	var registered, closed []int
+	err := svc.eventRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		var err error
+		event, err = svc.eventRepo.LockEvent(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+		if event.IsDeleted == utils.DeletedFlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已失效")
+		}
+		if event.IsCancelled == utils.FlagYes {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已取消，请勿重复操作")
+		}
+		if event.EventEndTime.Before(time.Now()) {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "活动已结束，无法取消")
+		}
+
+		if err := svc.eventRepo.UpdateEvent(ctx, tx, eventID, map[string]interface{}{
+			"is_cancelled":  utils.FlagYes,
+			"cancel_reason": reason,
+			"sequence":      gorm.Expr("sequence + 1"),
+			"update_user":   userID,
+		}); err != nil {
+			return err
+		}
+
+		// 已报名的用户保留报名记录，其余未完成的报名关闭
+		registered, err = svc.eventRepo.ListActiveUserIDs(ctx, tx, eventID, []string{model.RegistrationStatusRegistered})
+		if err != nil {
+			return err
+		}
+		closed, err = svc.eventRepo.ListActiveUserIDs(ctx, tx, eventID, []string{
+			model.RegistrationStatusUnpaid, model.RegistrationStatusWaitlisted, model.RegistrationStatusPending,
+		})
+		if err != nil {
+			return err
+		}
+		if err := svc.eventRepo.UpdateEventUserMaps(ctx, tx, eventID, closed, map[string]interface{}{
+			"status":        model.RegistrationStatusCancelled,
+			"waitlist_time": nil,
+		}); err != nil {
+			return err
+		}
+
+		pending, err := svc.orderRepo.LockEventOrders(ctx, tx, eventID, model.OrderStatusPending)
+		if err != nil {
+			return err
+		}
+		for _, order := range pending {
+			if err := svc.orderRepo.UpdateOrder(ctx, tx, order.ID, model.OrderStatusPending, map[string]interface{}{
+				"status": model.OrderStatusCancelled,
+			}); err != nil {
+				return err
+			}
+		}
+
+		refunding, err = svc.orderRepo.LockEventOrders(ctx, tx, eventID, model.OrderStatusPaid)
+		if err != nil {
+			return err
+		}
+		for i := range refunding {
+			if err := svc.orderRepo.UpdateOrder(ctx, tx, refunding[i].ID, model.OrderStatusPaid, refundFields(&refunding[i], "活动已取消")); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 退款失败不影响取消活动成功，由定时任务重试
+	for i := range refunding {
+		if err := svc.refundOrder(ctx, &refunding[i]); err != nil {
+			logrus.Errorf("%v，将由定时任务重试", err)
+		}
+	}
+
+	// 通知所有报名用户，通知失败不影响取消活动成功
+	content := fmt.Sprintf("很抱歉，您报名的活动《%s》（原定于%s开始）已取消，取消原因：%s。",
+		event.Title, event.EventStartTime.Format(reminderTimeFormat), reason)
+	registeredContent := content
+	if len(refunding) > 0 {
+		registeredContent += "已支付的报名费用将全额原路退回。"
+	}
+	svc.notifyUsers(ctx, event, registered, "活动取消通知", registeredContent, userID)
+	svc.notifyUsers(ctx, event, closed, "活动取消通知", content+"您的报名已关闭，未支付的订单已取消。", userID)
+	return nil
+}
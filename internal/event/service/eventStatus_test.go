@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/event/model"
+	"testing"
+)
+
+func TestCancelEventTwice(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 10)
+	env.register(t, event.ID, 101)
+	env.pay(t, event.ID, 101)
+
+	ctx := context.Background()
+	if err := env.svc.CancelEvent(ctx, event.ID, "场地调整", 1); err != nil {
+		t.Fatalf("取消活动失败: %v", err)
+	}
+	if got := env.orders.userOrder(event.ID, 101).Status; got != model.OrderStatusRefunded {
+		t.Fatalf("取消活动后订单状态为 %s，期望 %s", got, model.OrderStatusRefunded)
+	}
+	sequence := env.events.events[event.ID].Sequence
+
+	err := env.svc.CancelEvent(ctx, event.ID, "场地调整", 1)
+	assertBusinessError(t, err, "请勿重复操作")
+	if got := len(env.messages.noticesTo(101)); got != 1 {
+		t.Errorf("重复取消后用户收到 %d 条取消通知，期望 1 条", got)
+	}
+	if got := env.events.events[event.ID].Sequence; got != sequence {
+		t.Errorf("重复取消后日历修订序号为 %d，期望保持 %d", got, sequence)
+	}
+}
+
+func TestCancelEventRefundsAndClosesRegistrations(t *testing.T) {
+	env := newTestEnv(t)
+	event := env.addEvent(50, 2)
+	env.register(t, event.ID, 101)
+	env.pay(t, event.ID, 101)
+	env.register(t, event.ID, 102)
+	env.register(t, event.ID, 103)
+
+	if err := env.svc.CancelEvent(context.Background(), event.ID, "场地调整", 1); err != nil {
+		t.Fatalf("取消活动失败: %v", err)
+	}
+
+	if got := env.orders.userOrder(event.ID, 101).Status; got != model.OrderStatusRefunded {
+		t.Errorf("已支付订单状态为 %s，期望 %s", got, model.OrderStatusRefunded)
+	}
+	if got := env.orders.userOrder(event.ID, 102).Status; got != model.OrderStatusCancelled {
+		t.Errorf("待支付订单状态为 %s，期望 %s", got, model.OrderStatusCancelled)
+	}
+	tests := map[int]string{
+		101: model.RegistrationStatusRegistered,
+		102: model.RegistrationStatusCancelled,
+		103: model.RegistrationStatusCancelled,
+	}
+	for userID, want := range tests {
+		if got := env.status(event.ID, userID); got != want {
+			t.Errorf("用户[%d]报名状态为 %s，期望 %s", userID, got, want)
+		}
+		if got := len(env.messages.noticesTo(userID)); got != 1 {
+			t.Errorf("用户[%d]收到 %d 条取消通知，期望 1 条", userID, got)
+		}
+	}
+}
@@ -0,0 +1,523 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/event/model"
+	"news-release/internal/event/repository"
+	filerepo "news-release/internal/file/repository"
+	msgdto "news-release/internal/message/dto"
+	msgmodel "news-release/internal/message/model"
+	msgsvc "news-release/internal/message/service"
+	"news-release/internal/payment"
+	userdto "news-release/internal/user/dto"
+	userrepo "news-release/internal/user/repository"
+	"news-release/internal/utils"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 以下为服务层测试使用的内存实现，只实现被测流程用到的方法，调用未实现的方法时测试会因空接口而崩溃
+// 事务直接执行回调，不模拟回滚；更新字段按 gorm 标签中的列名写入结构体，与数据库更新的效果一致
+
+// testEventGroupID 活动消息群组ID，测试中每个活动共用一个
+const testEventGroupID = 1
+
+// applyFields 按列名将更新字段写入结构体，未知的列名视为测试数据错误
+func applyFields(dst interface{}, fields map[string]interface{}) {
+	v := reflect.ValueOf(dst).Elem()
+	columns := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		for _, part := range strings.Split(v.Type().Field(i).Tag.Get("gorm"), ";") {
+			if column, ok := strings.CutPrefix(part, "column:"); ok {
+				columns[column] = v.Field(i)
+			}
+		}
+	}
+
+	for column, value := range fields {
+		field, ok := columns[column]
+		if !ok {
+			panic(fmt.Sprintf("%s 没有 %s 列", v.Type().Name(), column))
+		}
+		switch val := value.(type) {
+		case nil:
+			field.Set(reflect.Zero(field.Type()))
+		case clause.Expr:
+			// 只支持 列 + 1 形式的自增表达式
+			if val.SQL != column+" + 1" {
+				panic("不支持的更新表达式: " + val.SQL)
+			}
+			field.SetInt(field.Int() + 1)
+		case time.Time:
+			if field.Kind() == reflect.Ptr {
+				field.Set(reflect.ValueOf(&val))
+			} else {
+				field.Set(reflect.ValueOf(val))
+			}
+		default:
+			field.Set(reflect.ValueOf(value).Convert(field.Type()))
+		}
+	}
+}
+
+// fakeEventRepo 活动数据访问的内存实现
+type fakeEventRepo struct {
+	repository.EventRepository
+	events   map[int]*model.Event
+	mappings []*model.EventUserMapping
+}
+
+func (repo *fakeEventRepo) ExecTransaction(_ context.Context, fn func(tx *gorm.DB) error) error {
+	return fn(nil)
+}
+
+func (repo *fakeEventRepo) GetEventDetail(_ context.Context, eventID int) (*model.Event, error) {
+	event, ok := repo.events[eventID]
+	if !ok {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "活动不存在或已被删除，请刷新页面后重试")
+	}
+	copied := *event
+	return &copied, nil
+}
+
+func (repo *fakeEventRepo) LockEvent(ctx context.Context, _ *gorm.DB, eventID int) (*model.Event, error) {
+	return repo.GetEventDetail(ctx, eventID)
+}
+
+func (repo *fakeEventRepo) UpdateEvent(_ context.Context, _ *gorm.DB, eventID int, updateFields map[string]interface{}) error {
+	applyFields(repo.events[eventID], updateFields)
+	return nil
+}
+
+// mapping 查询活动-用户关联映射，不存在时返回nil
+func (repo *fakeEventRepo) mapping(eventID int, userID int) *model.EventUserMapping {
+	for _, m := range repo.mappings {
+		if m.EventID == eventID && m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
+func (repo *fakeEventRepo) GetEventUserMap(_ context.Context, eventID int, userID int) (*model.EventUserMapping, error) {
+	m := repo.mapping(eventID, userID)
+	if m == nil {
+		return nil, nil
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (repo *fakeEventRepo) LockEventUserMap(ctx context.Context, _ *gorm.DB, eventID int, userID int) (*model.EventUserMapping, error) {
+	return repo.GetEventUserMap(ctx, eventID, userID)
+}
+
+func (repo *fakeEventRepo) CreatEventUserMap(_ context.Context, _ *gorm.DB, mapping *model.EventUserMapping) error {
+	mapping.ID = len(repo.mappings) + 1
+	mapping.IsDeleted = utils.DeletedFlagNo
+	mapping.CreateTime = time.Now().Truncate(time.Second)
+	copied := *mapping
+	repo.mappings = append(repo.mappings, &copied)
+	return nil
+}
+
+func (repo *fakeEventRepo) UpdateEventUserMap(_ context.Context, _ *gorm.DB, eventID int, userID int, updateFields map[string]interface{}) error {
+	m := repo.mapping(eventID, userID)
+	if m == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "数据更新异常，未找到活动或状态已更新，请刷新页面后重试")
+	}
+	applyFields(m, updateFields)
+	return nil
+}
+
+func (repo *fakeEventRepo) UpdateEventUserMaps(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, updateFields map[string]interface{}) error {
+	for _, userID := range userIDs {
+		if err := repo.UpdateEventUserMap(ctx, tx, eventID, userID, updateFields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// active 按报名先后查询指定状态的有效报名
+func (repo *fakeEventRepo) active(eventID int, statuses ...string) []*model.EventUserMapping {
+	var result []*model.EventUserMapping
+	for _, m := range repo.mappings {
+		if m.EventID != eventID || m.IsDeleted == utils.DeletedFlagYes {
+			continue
+		}
+		for _, status := range statuses {
+			if m.Status == status {
+				result = append(result, m)
+			}
+		}
+	}
+	return result
+}
+
+func (repo *fakeEventRepo) CountOccupiedSeats(_ context.Context, _ *gorm.DB, eventID int) (int64, error) {
+	return int64(len(repo.active(eventID, model.RegistrationStatusRegistered, model.RegistrationStatusUnpaid))), nil
+}
+
+// waitlist 按候补顺序排列的候补用户
+func (repo *fakeEventRepo) waitlist(eventID int) []*model.EventUserMapping {
+	waitlisted := repo.active(eventID, model.RegistrationStatusWaitlisted)
+	sort.SliceStable(waitlisted, func(i, j int) bool {
+		return waitlisted[i].WaitlistTime.Before(*waitlisted[j].WaitlistTime)
+	})
+	return waitlisted
+}
+
+func (repo *fakeEventRepo) ListWaitlistedUserIDs(_ context.Context, _ *gorm.DB, eventID int, limit int) ([]int, error) {
+	var userIDs []int
+	for _, m := range repo.waitlist(eventID) {
+		if limit > 0 && len(userIDs) >= limit {
+			break
+		}
+		userIDs = append(userIDs, m.UserID)
+	}
+	return userIDs, nil
+}
+
+func (repo *fakeEventRepo) PromoteWaitlistedUsers(ctx context.Context, tx *gorm.DB, eventID int, userIDs []int, status string) error {
+	return repo.UpdateEventUserMaps(ctx, tx, eventID, userIDs, map[string]interface{}{
+		"status":        status,
+		"waitlist_time": nil,
+	})
+}
+
+func (repo *fakeEventRepo) GetWaitlistPosition(_ context.Context, mapping *model.EventUserMapping) (int, error) {
+	for i, m := range repo.waitlist(mapping.EventID) {
+		if m.UserID == mapping.UserID {
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (repo *fakeEventRepo) ListPendingUserIDs(_ context.Context, _ *gorm.DB, eventID int, userIDs []int) ([]int, error) {
+	var pending []int
+	for _, m := range repo.active(eventID, model.RegistrationStatusPending) {
+		for _, userID := range userIDs {
+			if m.UserID == userID {
+				pending = append(pending, userID)
+			}
+		}
+	}
+	return pending, nil
+}
+
+func (repo *fakeEventRepo) ListActiveUserIDs(_ context.Context, _ *gorm.DB, eventID int, statuses []string) ([]int, error) {
+	var userIDs []int
+	for _, m := range repo.active(eventID, statuses...) {
+		userIDs = append(userIDs, m.UserID)
+	}
+	return userIDs, nil
+}
+
+// fakeOrderRepo 活动订单数据访问的内存实现
+type fakeOrderRepo struct {
+	repository.OrderRepository
+	orders []*model.EventOrder
+}
+
+func (repo *fakeOrderRepo) CreateOrder(_ context.Context, _ *gorm.DB, order *model.EventOrder) error {
+	order.ID = len(repo.orders) + 1
+	order.CreateTime = time.Now()
+	copied := *order
+	repo.orders = append(repo.orders, &copied)
+	return nil
+}
+
+func (repo *fakeOrderRepo) GetOrderByNo(_ context.Context, orderNo string) (*model.EventOrder, error) {
+	for _, order := range repo.orders {
+		if order.OrderNo == orderNo {
+			copied := *order
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *fakeOrderRepo) LockOrder(ctx context.Context, _ *gorm.DB, orderNo string) (*model.EventOrder, error) {
+	return repo.GetOrderByNo(ctx, orderNo)
+}
+
+func (repo *fakeOrderRepo) GetUserOrder(_ context.Context, eventID int, userID int, status string) (*model.EventOrder, error) {
+	for i := len(repo.orders) - 1; i >= 0; i-- {
+		order := repo.orders[i]
+		if order.EventID == eventID && order.UserID == userID && order.Status == status {
+			copied := *order
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *fakeOrderRepo) LockUserOrder(ctx context.Context, _ *gorm.DB, eventID int, userID int, status string) (*model.EventOrder, error) {
+	return repo.GetUserOrder(ctx, eventID, userID, status)
+}
+
+func (repo *fakeOrderRepo) LockEventOrders(_ context.Context, _ *gorm.DB, eventID int, status string) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+	for _, order := range repo.orders {
+		if order.EventID == eventID && order.Status == status {
+			orders = append(orders, *order)
+		}
+	}
+	return orders, nil
+}
+
+func (repo *fakeOrderRepo) UpdateOrder(_ context.Context, _ *gorm.DB, orderID int, status string, updateFields map[string]interface{}) error {
+	for _, order := range repo.orders {
+		if order.ID == orderID && order.Status == status {
+			applyFields(order, updateFields)
+			return nil
+		}
+	}
+	return utils.NewBusinessError(utils.ErrCodeResourceConflict, "订单状态已变化，请刷新页面后重试")
+}
+
+func (repo *fakeOrderRepo) CompleteRefund(_ context.Context, orderID int, refundTradeNo string) error {
+	for _, order := range repo.orders {
+		if order.ID == orderID && order.Status == model.OrderStatusRefunding {
+			now := time.Now()
+			order.Status = model.OrderStatusRefunded
+			order.RefundTradeNo = refundTradeNo
+			order.RefundTime = &now
+		}
+	}
+	return nil
+}
+
+func (repo *fakeOrderRepo) ListExpiredOrders(_ context.Context, before time.Time, afterID int, limit int) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+	for _, order := range repo.orders {
+		if order.ID > afterID && order.Status == model.OrderStatusPending && order.ExpireTime.Before(before) && len(orders) < limit {
+			orders = append(orders, *order)
+		}
+	}
+	return orders, nil
+}
+
+func (repo *fakeOrderRepo) ListRefundingOrders(_ context.Context, afterID int, limit int) ([]model.EventOrder, error) {
+	var orders []model.EventOrder
+	for _, order := range repo.orders {
+		if order.ID > afterID && order.Status == model.OrderStatusRefunding && len(orders) < limit {
+			orders = append(orders, *order)
+		}
+	}
+	return orders, nil
+}
+
+// userOrder 查询用户在活动中的最新订单，不存在时返回nil
+func (repo *fakeOrderRepo) userOrder(eventID int, userID int) *model.EventOrder {
+	for i := len(repo.orders) - 1; i >= 0; i-- {
+		if repo.orders[i].EventID == eventID && repo.orders[i].UserID == userID {
+			return repo.orders[i]
+		}
+	}
+	return nil
+}
+
+// fakeUserRepo 用户数据访问的内存实现，所有用户的资料均已填写完整
+type fakeUserRepo struct {
+	userrepo.UserRepository
+}
+
+func (repo *fakeUserRepo) GetUserByID(_ context.Context, userID int) (*userdto.UserInfoResponse, error) {
+	return &userdto.UserInfoResponse{
+		Name:        fmt.Sprintf("用户%d", userID),
+		Nickname:    fmt.Sprintf("user%d", userID),
+		PhoneNumber: "13800000000",
+		Email:       "user@example.com",
+		Unit:        "单位",
+		Department:  "部门",
+		Position:    "职位",
+		Industry:    "行业",
+	}, nil
+}
+
+// fakeFileRepo 文件数据访问的内存实现，报名表单不包含文件上传题
+type fakeFileRepo struct {
+	filerepo.FileRepository
+}
+
+func (repo *fakeFileRepo) BatchUpdateImageBizID(_ context.Context, _ *gorm.DB, imageIDs []int, _ int, _ string) error {
+	if len(imageIDs) > 0 {
+		panic("测试数据不应包含图片")
+	}
+	return nil
+}
+
+// fakeMsgGroupService 消息群组服务的内存实现，记录活动消息群组的成员
+type fakeMsgGroupService struct {
+	msgsvc.MsgGroupService
+	members map[int]bool
+}
+
+func (svc *fakeMsgGroupService) ListMsgGroups(_ context.Context, _ int, _ int, _ string, eventID int, _ string) ([]msgdto.ListMsgGroupResponse, int64, error) {
+	return []msgdto.ListMsgGroupResponse{{ID: testEventGroupID, EventID: eventID}}, 1, nil
+}
+
+func (svc *fakeMsgGroupService) AddUserToGroup(_ context.Context, _ int, userIDs []int, _ int) error {
+	for _, userID := range userIDs {
+		svc.members[userID] = true
+	}
+	return nil
+}
+
+func (svc *fakeMsgGroupService) DeleteUserFromGroup(_ context.Context, _ int, userIDs []int, _ int) error {
+	for _, userID := range userIDs {
+		delete(svc.members, userID)
+	}
+	return nil
+}
+
+func (svc *fakeMsgGroupService) DeleteMsgGroup(_ context.Context, _ int, _ int) error {
+	return nil
+}
+
+// GetUserNoticeGroup 个人通知群组ID为用户ID的相反数，与活动消息群组区分
+func (svc *fakeMsgGroupService) GetUserNoticeGroup(_ context.Context, userID int, _ int) (*msgmodel.UserMessageGroup, error) {
+	return &msgmodel.UserMessageGroup{ID: -userID, OwnerUserID: userID}, nil
+}
+
+// sentMessage 已发送的消息
+type sentMessage struct {
+	GroupID int
+	Title   string
+	Content string
+}
+
+// fakeMessageService 消息服务的内存实现，记录发送的消息
+type fakeMessageService struct {
+	msgsvc.MessageService
+	sent []sentMessage
+}
+
+func (svc *fakeMessageService) SendMessage(_ context.Context, msgGroupID int, msg *msgmodel.Message) error {
+	svc.sent = append(svc.sent, sentMessage{GroupID: msgGroupID, Title: msg.Title, Content: msg.Content})
+	return nil
+}
+
+// noticesTo 发送到用户个人通知群组的消息
+func (svc *fakeMessageService) noticesTo(userID int) []sentMessage {
+	var result []sentMessage
+	for _, msg := range svc.sent {
+		if msg.GroupID == -userID {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+// testEnv 服务层测试环境
+type testEnv struct {
+	svc      *EventServiceImpl
+	events   *fakeEventRepo
+	orders   *fakeOrderRepo
+	groups   *fakeMsgGroupService
+	messages *fakeMessageService
+	provider *payment.FakeProvider
+	nextID   int
+}
+
+// newTestEnv 创建使用内存数据和模拟支付渠道的服务
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		events:   &fakeEventRepo{events: make(map[int]*model.Event)},
+		orders:   &fakeOrderRepo{},
+		groups:   &fakeMsgGroupService{members: make(map[int]bool)},
+		messages: &fakeMessageService{},
+		provider: payment.NewFakeProvider("test-callback-secret"),
+	}
+	env.svc = &EventServiceImpl{
+		eventRepo:         env.events,
+		orderRepo:         env.orders,
+		userRepo:          &fakeUserRepo{},
+		fileRepo:          &fakeFileRepo{},
+		msgSvc:            env.groups,
+		sendSvc:           env.messages,
+		checkInSecret:     []byte("test-check-in-secret"),
+		checkInOpenBefore: 2 * time.Hour,
+		payProvider:       env.provider,
+		payTimeout:        30 * time.Minute,
+		refundBefore:      24 * time.Hour,
+	}
+	return env
+}
+
+// addEvent 添加报名中的活动，活动在两天后开始，fee 为报名费用（元），maxParticipants 为0时不限人数
+func (env *testEnv) addEvent(fee float64, maxParticipants int) *model.Event {
+	env.nextID++
+	now := time.Now()
+	event := &model.Event{
+		ID:                    env.nextID,
+		Title:                 fmt.Sprintf("测试活动%d", env.nextID),
+		EventStartTime:        now.Add(48 * time.Hour),
+		EventEndTime:          now.Add(50 * time.Hour),
+		RegistrationStartTime: now.Add(-time.Hour),
+		RegistrationEndTime:   now.Add(24 * time.Hour),
+		MaxParticipants:       maxParticipants,
+		RegistrationFee:       fee,
+		RequireApproval:       utils.FlagNo,
+		IsCancelled:           utils.FlagNo,
+		IsDeleted:             utils.DeletedFlagNo,
+	}
+	env.events.events[event.ID] = event
+	return event
+}
+
+// register 报名活动，失败时终止测试
+func (env *testEnv) register(t *testing.T, eventID int, userID int) {
+	t.Helper()
+	if _, err := env.svc.RegistrationEvent(context.Background(), eventID, userID, nil); err != nil {
+		t.Fatalf("用户[%d]报名活动失败: %v", userID, err)
+	}
+}
+
+// pay 通过模拟支付回调完成用户的待支付订单，失败时终止测试
+func (env *testEnv) pay(t *testing.T, eventID int, userID int) {
+	t.Helper()
+	order := env.orders.userOrder(eventID, userID)
+	if order == nil {
+		t.Fatalf("用户[%d]没有订单", userID)
+	}
+	header, body, err := env.provider.BuildCallback(order.OrderNo, order.Amount)
+	if err != nil {
+		t.Fatalf("构造支付回调失败: %v", err)
+	}
+	if err := env.svc.HandlePaymentCallback(context.Background(), payment.ProviderFake, header, body); err != nil {
+		t.Fatalf("处理支付回调失败: %v", err)
+	}
+}
+
+// status 用户的报名状态，未报名或已取消报名时返回空字符串
+func (env *testEnv) status(eventID int, userID int) string {
+	m := env.events.mapping(eventID, userID)
+	if m == nil || m.IsDeleted == utils.DeletedFlagYes {
+		return ""
+	}
+	return m.Status
+}
+
+// assertBusinessError 检查返回的错误为包含指定内容的业务错误
+func assertBusinessError(t *testing.T, err error, contains string) {
+	t.Helper()
+	bizErr, ok := utils.GetBusinessError(err)
+	if !ok {
+		t.Fatalf("期望业务错误「%s」，实际为 %v", contains, err)
+	}
+	if !strings.Contains(bizErr.Msg, contains) {
+		t.Fatalf("期望业务错误包含「%s」，实际为「%s」", contains, bizErr.Msg)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"news-release/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 支持的重复频率
+const (
+	recurrenceDaily   = "DAILY"   // 每天
+	recurrenceWeekly  = "WEEKLY"  // 每周
+	recurrenceMonthly = "MONTHLY" // 每月
+)
+
+// maxSeriesOccurrences 一个活动系列最多生成的场次数
+const maxSeriesOccurrences = 100
+
+// recurrenceRule 重复规则，支持 RRULE 的 FREQ、INTERVAL、COUNT、UNTIL 四个属性
+// 例如 FREQ=MONTHLY;INTERVAL=1;COUNT=12 表示每月一次，共12次
+type recurrenceRule struct {
+	Freq     string    // 重复频率
+	Interval int       // 间隔，默认为1
+	Count    int       // 重复次数，与 Until 二选一
+	Until    time.Time // 最后一场的开始时间不晚于该时间，与 Count 二选一
+}
+
+// parseRecurrenceRule 解析重复规则，兼容带 RRULE: 前缀的写法
+func parseRecurrenceRule(rule string) (*recurrenceRule, error) {
+	invalid := func(msg string) error {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "重复规则无效: "+msg)
+	}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	result := &recurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, invalid(part)
+		}
+		if seen[key] {
+			return nil, invalid("重复的属性 " + key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			result.Freq = strings.ToUpper(value)
+			if result.Freq != recurrenceDaily && result.Freq != recurrenceWeekly && result.Freq != recurrenceMonthly {
+				return nil, invalid("FREQ 只支持 DAILY、WEEKLY、MONTHLY")
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > 365 {
+				return nil, invalid("INTERVAL 必须为1-365的整数")
+			}
+			result.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 || count > maxSeriesOccurrences {
+				return nil, invalid("COUNT 必须为1-" + strconv.Itoa(maxSeriesOccurrences) + "的整数")
+			}
+			result.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(value)
+			if err != nil {
+				return nil, invalid("UNTIL 格式应为 YYYYMMDD 或 YYYYMMDDTHHMMSSZ")
+			}
+			result.Until = until
+		default:
+			return nil, invalid("不支持的属性 " + key)
+		}
+	}
+
+	if result.Freq == "" {
+		return nil, invalid("缺少 FREQ")
+	}
+	if (result.Count == 0) == result.Until.IsZero() {
+		return nil, invalid("COUNT 和 UNTIL 必须且只能指定一个")
+	}
+	return result, nil
+}
+
+// parseRecurrenceUntil 解析 UNTIL 的值，只有日期时包含当天全天
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+// occurrences 从第一场的开始时间起生成各场次的开始时间
+// 按月重复时跳过没有对应日期的月份（如31日），场次数超过上限时返回错误
+func (r *recurrenceRule) occurrences(start time.Time) ([]time.Time, error) {
+	var result []time.Time
+generate:
+	for i := 0; ; i++ {
+		var next time.Time
+		switch r.Freq {
+		case recurrenceDaily:
+			next = start.AddDate(0, 0, i*r.Interval)
+		case recurrenceWeekly:
+			next = start.AddDate(0, 0, 7*i*r.Interval)
+		case recurrenceMonthly:
+			// 先定位到目标月份的1日，避免 AddDate 将不存在的日期顺延到下个月
+			first := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			first = first.AddDate(0, i*r.Interval, 0)
+			next = first.AddDate(0, 0, start.Day()-1)
+			if next.Month() != first.Month() {
+				if !r.Until.IsZero() && first.After(r.Until) {
+					break generate
+				}
+				continue
+			}
+		}
+
+		if !r.Until.IsZero() && next.After(r.Until) {
+			break generate
+		}
+		if len(result) >= maxSeriesOccurrences {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid,
+				"重复规则生成的场次过多，最多"+strconv.Itoa(maxSeriesOccurrences)+"场")
+		}
+		result = append(result, next)
+		if r.Count > 0 && len(result) >= r.Count {
+			break generate
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "重复规则未生成任何场次，请检查 UNTIL 是否早于第一场的开始时间")
+	}
+	return result, nil
+}
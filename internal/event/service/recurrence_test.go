@@ -0,0 +1,126 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 19, 30, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		start   time.Time
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name:  "每天按次数",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: at(2026, time.March, 30),
+			want:  []time.Time{at(2026, time.March, 30), at(2026, time.March, 31), at(2026, time.April, 1)},
+		},
+		{
+			name:  "每两周按次数",
+			rule:  "RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			start: at(2026, time.December, 20),
+			want:  []time.Time{at(2026, time.December, 20), at(2027, time.January, 3), at(2027, time.January, 17)},
+		},
+		{
+			name:  "每月31日跳过没有31日的月份",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: at(2026, time.January, 31),
+			want:  []time.Time{at(2026, time.January, 31), at(2026, time.March, 31), at(2026, time.May, 31), at(2026, time.July, 31)},
+		},
+		{
+			name:  "每月29日在平年跳过2月",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: at(2027, time.January, 29),
+			want:  []time.Time{at(2027, time.January, 29), at(2027, time.March, 29), at(2027, time.April, 29)},
+		},
+		{
+			name:  "每月29日在闰年包含2月",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: at(2028, time.January, 29),
+			want:  []time.Time{at(2028, time.January, 29), at(2028, time.February, 29), at(2028, time.March, 29)},
+		},
+		{
+			name:  "UNTIL只有日期时包含当天",
+			rule:  "FREQ=DAILY;UNTIL=20260403",
+			start: at(2026, time.April, 1),
+			want:  []time.Time{at(2026, time.April, 1), at(2026, time.April, 2), at(2026, time.April, 3)},
+		},
+		{
+			name:  "UNTIL早于下一场时只生成第一场",
+			rule:  "FREQ=WEEKLY;UNTIL=20260405",
+			start: at(2026, time.April, 1),
+			want:  []time.Time{at(2026, time.April, 1)},
+		},
+		{
+			name:  "按月重复时UNTIL落在跳过的月份",
+			rule:  "FREQ=MONTHLY;UNTIL=20260430",
+			start: at(2026, time.January, 31),
+			want:  []time.Time{at(2026, time.January, 31), at(2026, time.March, 31)},
+		},
+		{
+			name:    "UNTIL早于第一场",
+			rule:    "FREQ=DAILY;UNTIL=20260331",
+			start:   at(2026, time.April, 1),
+			wantErr: true,
+		},
+		{
+			name:    "场次超过上限",
+			rule:    "FREQ=DAILY;UNTIL=20270101",
+			start:   at(2026, time.January, 1),
+			wantErr: true,
+		},
+		{
+			name:    "COUNT和UNTIL同时指定",
+			rule:    "FREQ=DAILY;COUNT=3;UNTIL=20260403",
+			start:   at(2026, time.April, 1),
+			wantErr: true,
+		},
+		{
+			name:    "缺少COUNT和UNTIL",
+			rule:    "FREQ=DAILY",
+			start:   at(2026, time.April, 1),
+			wantErr: true,
+		},
+		{
+			name:    "不支持的频率",
+			rule:    "FREQ=YEARLY;COUNT=2",
+			start:   at(2026, time.April, 1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRecurrenceRule(tt.rule)
+			var got []time.Time
+			if err == nil {
+				got, err = rule.occurrences(tt.start)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("期望返回错误，实际生成 %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("返回错误: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("生成 %d 场，期望 %d 场: %v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("第 %d 场为 %v，期望 %v", i+1, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/event/dto"
+	userdto "news-release/internal/user/dto"
+	"news-release/internal/utils"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultTextMaxLength = 500 // 文本题答案默认最大字数
+	defaultMaxFiles      = 1   // 文件上传题默认最多上传文件数
+)
+
+// defaultRegistrationForm 未设置报名表单的活动使用的默认表单，要求填写全部用户资料字段
+func defaultRegistrationForm() *dto.RegistrationForm {
+	fields := make([]string, 0, len(dto.ProfileFields))
+	for _, field := range dto.ProfileFields {
+		fields = append(fields, field.Key)
+	}
+	return &dto.RegistrationForm{RequiredFields: fields, Questions: []dto.FormQuestion{}}
+}
+
+// effectiveRegistrationForm 获取活动实际使用的报名表单
+func effectiveRegistrationForm(form *dto.RegistrationForm) *dto.RegistrationForm {
+	if form == nil {
+		return defaultRegistrationForm()
+	}
+	return form
+}
+
+// validateRegistrationForm 校验报名表单的问题设置，字段取值范围由请求参数绑定校验
+func validateRegistrationForm(form *dto.RegistrationForm) error {
+	if form == nil {
+		return nil
+	}
+
+	keys := make(map[string]bool, len(form.Questions))
+	for _, question := range form.Questions {
+		if keys[question.Key] {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "报名表单问题标识重复: "+question.Key)
+		}
+		keys[question.Key] = true
+
+		isChoice := question.Type == dto.QuestionTypeSingleChoice || question.Type == dto.QuestionTypeMultiChoice
+		if isChoice && len(question.Options) == 0 {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "选择题至少需要一个选项: "+question.Label)
+		}
+		if !isChoice && len(question.Options) > 0 {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "只有选择题可以设置选项: "+question.Label)
+		}
+		options := make(map[string]bool, len(question.Options))
+		for _, option := range question.Options {
+			if options[option] {
+				return utils.NewBusinessError(utils.ErrCodeParamInvalid, "选项重复: "+option)
+			}
+			options[option] = true
+		}
+	}
+	return nil
+}
+
+// checkRequiredProfile 检查用户资料是否已填写报名表单要求的字段
+func checkRequiredProfile(user *userdto.UserInfoResponse, form *dto.RegistrationForm) error {
+	values := map[string]string{
+		"name":         user.Name,
+		"phone_number": user.PhoneNumber,
+		"email":        user.Email,
+		"unit":         user.Unit,
+		"department":   user.Department,
+		"position":     user.Position,
+		"industry":     user.Industry,
+	}
+	required := make(map[string]bool, len(form.RequiredFields))
+	for _, key := range form.RequiredFields {
+		required[key] = true
+	}
+
+	// 按用户资料字段的固定顺序提示缺少的字段
+	var missing []string
+	for _, field := range dto.ProfileFields {
+		if required[field.Key] && strings.TrimSpace(values[field.Key]) == "" {
+			missing = append(missing, field.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "用户信息不完整，请完善个人信息: "+strings.Join(missing, "、"))
+	}
+	return nil
+}
+
+// buildAnswers 按报名表单校验答案，返回按问题顺序排列的答案和文件上传题使用的图片ID
+// mappingID 为重新报名时已有的活动-用户关联映射ID，首次报名时为0
+func (svc *EventServiceImpl) buildAnswers(ctx context.Context, form *dto.RegistrationForm, answers []dto.FormAnswer, userID int, mappingID int) (dto.FormAnswers, []int, error) {
+	answerMap := make(map[string]dto.FormAnswer, len(answers))
+	for _, answer := range answers {
+		if _, ok := answerMap[answer.Key]; ok {
+			return nil, nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "问题重复作答: "+answer.Key)
+		}
+		answerMap[answer.Key] = answer
+	}
+
+	result := make(dto.FormAnswers, 0, len(form.Questions))
+	var imageIDs []int
+	for _, question := range form.Questions {
+		answer := answerMap[question.Key]
+		delete(answerMap, question.Key)
+
+		values := make([]string, 0, len(answer.Values))
+		for _, value := range answer.Values {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		var err error
+		switch question.Type {
+		case dto.QuestionTypeText:
+			err = checkTextAnswer(question, values)
+		case dto.QuestionTypeSingleChoice, dto.QuestionTypeMultiChoice:
+			err = checkChoiceAnswer(question, values)
+		case dto.QuestionTypeFile:
+			values, err = svc.checkFileAnswer(ctx, question, answer.ImageIDs, userID, mappingID)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(values) == 0 {
+			if question.Required {
+				return nil, nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "请回答: "+question.Label)
+			}
+			continue
+		}
+		item := dto.FormAnswer{Key: question.Key, Label: question.Label, Values: values}
+		if question.Type == dto.QuestionTypeFile {
+			item.ImageIDs = answer.ImageIDs
+			imageIDs = append(imageIDs, answer.ImageIDs...)
+		}
+		result = append(result, item)
+	}
+
+	for key := range answerMap {
+		return nil, nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "报名表单中不存在该问题: "+key)
+	}
+	return result, imageIDs, nil
+}
+
+// checkTextAnswer 校验文本题答案
+func checkTextAnswer(question dto.FormQuestion, values []string) error {
+	if len(values) > 1 {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "文本题只能填写一个答案: "+question.Label)
+	}
+	maxLength := question.MaxLength
+	if maxLength <= 0 {
+		maxLength = defaultTextMaxLength
+	}
+	if len(values) == 1 && utf8.RuneCountInString(values[0]) > maxLength {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "答案超过最大字数限制: "+question.Label)
+	}
+	return nil
+}
+
+// checkChoiceAnswer 校验选择题答案，答案必须是问题的选项且不能重复
+func checkChoiceAnswer(question dto.FormQuestion, values []string) error {
+	if question.Type == dto.QuestionTypeSingleChoice && len(values) > 1 {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "单选题只能选择一个选项: "+question.Label)
+	}
+	options := make(map[string]bool, len(question.Options))
+	for _, option := range question.Options {
+		options[option] = true
+	}
+	chosen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if !options[value] || chosen[value] {
+			return utils.NewBusinessError(utils.ErrCodeParamInvalid, "选项无效: "+question.Label)
+		}
+		chosen[value] = true
+	}
+	return nil
+}
+
+// checkFileAnswer 校验文件上传题答案，图片必须由报名用户上传且未关联其他业务数据，返回图片URL
+func (svc *EventServiceImpl) checkFileAnswer(ctx context.Context, question dto.FormQuestion, imageIDs []int, userID int, mappingID int) ([]string, error) {
+	maxFiles := question.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultMaxFiles
+	}
+	if len(imageIDs) > maxFiles {
+		return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "上传文件数超过限制: "+question.Label)
+	}
+
+	urls := make([]string, 0, len(imageIDs))
+	for _, imageID := range imageIDs {
+		image, err := svc.fileRepo.GetImageByID(ctx, imageID)
+		if err != nil {
+			return nil, err
+		}
+		// 重新报名时允许沿用上次报名已关联的图片
+		reused := mappingID > 0 && image != nil && image.BizType == utils.TypeRegistration && image.BizID == mappingID
+		if image == nil || image.UploadUserID != userID || (image.BizID != 0 && !reused) {
+			return nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "上传的文件无效，请重新上传: "+question.Label)
+		}
+		urls = append(urls, image.URL)
+	}
+	return urls, nil
+}
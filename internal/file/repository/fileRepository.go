@@ -20,6 +20,8 @@ type FileRepository interface {
 	GetImageByID(ctx context.Context, imageID int) (*model.Image, error)
 	// DeleteImage 删除图片
 	DeleteImage(ctx context.Context, imageID int) error
+	// ListImagesByBizIDs 查询多个业务数据关联的图片
+	ListImagesByBizIDs(ctx context.Context, bizType string, bizIDs []int) ([]model.Image, error)
 }
 
 // FileRepositoryImpl 文件存储库实现
@@ -87,3 +89,19 @@ func (repo *FileRepositoryImpl) DeleteImage(ctx context.Context, imageID int) er
 	}
 	return nil
 }
+
+// ListImagesByBizIDs 查询指定业务类型下多个业务数据关联的图片，按图片ID升序排列
+func (repo *FileRepositoryImpl) ListImagesByBizIDs(ctx context.Context, bizType string, bizIDs []int) ([]model.Image, error) {
+	var images []model.Image
+	if len(bizIDs) == 0 {
+		return images, nil
+	}
+
+	if err := repo.db.WithContext(ctx).
+		Where("biz_type = ? AND biz_id IN ?", bizType, bizIDs).
+		Order("id").
+		Find(&images).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询关联图片失败: %w", err))
+	}
+	return images, nil
+}
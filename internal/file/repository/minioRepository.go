@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
 	"news-release/internal/utils"
 )
 
@@ -12,6 +13,7 @@ import (
 type MinIORepository interface {
 	UploadFile(ctx context.Context, objectName, filePath string) (string, error)
 	DeleteFile(ctx context.Context, objectName string) error
+	GetFile(ctx context.Context, objectName string) (io.ReadCloser, error)
 	//GetFileURL(ctx context.Context, objectName string) (string, error)
 }
 
@@ -71,6 +73,15 @@ func (repo *MinIORepositoryImpl) DeleteFile(ctx context.Context, objectName stri
 	return err
 }
 
+// GetFile 读取MinIO中的文件内容，调用方负责关闭
+func (repo *MinIORepositoryImpl) GetFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := repo.client.GetObject(ctx, repo.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("读取MinIO文件失败: %w", err))
+	}
+	return object, nil
+}
+
 // GetFileURL 获取文件预签名URL
 //func (repo *MinIORepositoryImpl) GetFileURL(ctx context.Context, objectName string) (string, error) {
 //	// 生成预签名URL，有效期1小时
@@ -162,16 +162,11 @@ func (ctr *MessageController) ListMsgByGroups(ctx *gin.Context) {
 		return
 	}
 
-	// page 默认1
-	page := req.Page
-	if page == 0 {
-		page = 1
-	}
-
-	// pageSize 默认10
-	pageSize := req.PageSize
-	if pageSize == 0 {
-		pageSize = 10
+	// 页码分页与游标分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
 	// 获取userID
@@ -183,19 +178,14 @@ func (ctr *MessageController) ListMsgByGroups(ctx *gin.Context) {
 	}
 
 	// 调用服务层
-	list, total, err := ctr.messageService.ListMsgByGroups(ctx, page, pageSize, urlReq.MsgGroupID, userID)
+	list, err := ctr.messageService.ListMsgByGroups(ctx, pg, urlReq.MsgGroupID, userID)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      list,
-	})
+	ctx.JSON(http.StatusOK, pg.Response(list))
 }
 
 // SendMessage 发送消息
@@ -257,32 +247,22 @@ func (ctr *MessageController) ListMessagesByGroupID(ctx *gin.Context) {
 		return
 	}
 
-	// page 默认1
-	page := req.Page
-	if page == 0 {
-		page = 1
-	}
-
-	// pageSize 默认10
-	pageSize := req.PageSize
-	if pageSize == 0 {
-		pageSize = 10
+	// 页码分页与游标分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
 	// 调用服务层
-	list, total, err := ctr.messageService.ListMessagesByGroupID(ctx, page, pageSize, urlReq.MsgGroupID, req.Title, req.QueryScope)
+	list, err := ctr.messageService.ListMessagesByGroupID(ctx, pg, urlReq.MsgGroupID, req.Title, req.QueryScope)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
-		"data":      list,
-	})
+	ctx.JSON(http.StatusOK, pg.Response(list))
 }
 
 // RevokeGroupMessage 撤回群组消息
@@ -204,6 +204,34 @@ func (ctr *MsgGroupController) DeleteMsgGroup(ctx *gin.Context) {
 	})
 }
 
+// RestoreMsgGroup 恢复消息群组
+func (ctr *MsgGroupController) RestoreMsgGroup(ctx *gin.Context) {
+	// 初始化参数结构体并绑定URL路径参数
+	var urlReq dto.MsgGroupIDRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+	// 获取当前登录userID
+	userID, err := utils.GetUserID(ctx)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	// 调用服务层
+	err = ctr.msgGroupService.RestoreMsgGroup(ctx, urlReq.MsgGroupID, userID)
+	// 处理异常
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	// 返回成功响应
+	ctx.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "消息群组恢复成功",
+	})
+}
+
 // ListMsgGroups 获取消息群组列表
 func (ctr *MsgGroupController) ListMsgGroups(ctx *gin.Context) {
 	// 初始化参数结构体并绑定查询参数
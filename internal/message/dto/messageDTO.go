@@ -27,14 +27,18 @@ type ListUserGroupMessageRequest struct {
 
 // ListMessageByGroupRequest 分页查询分组内消息列表请求参数
 type ListMessageByGroupRequest struct {
-	Page     int `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
-	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+	Page      int    `form:"page" binding:"omitempty,min=1"`              // 页码，最小为1
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=100"` // 页大小，1-100
+	Cursor    string `form:"cursor" binding:"omitempty,max=200"`          // 游标，传入时按游标分页并忽略页码
+	WithTotal *bool  `form:"with_total"`                                  // 是否返回总数，页码分页默认返回，游标分页默认不返回
 }
 
 // ListMessageByGroupIDRequest 分页查询分组内消息列表请求参数
 type ListMessageByGroupIDRequest struct {
 	Page       int    `form:"page" binding:"omitempty,min=1"`              // 页码，默认1
 	PageSize   int    `form:"page_size" binding:"omitempty,min=1,max=100"` // 每页数量，默认10，最大100
+	Cursor     string `form:"cursor" binding:"omitempty,max=200"`          // 游标，传入时按游标分页并忽略页码
+	WithTotal  *bool  `form:"with_total"`                                  // 是否返回总数，页码分页默认返回，游标分页默认不返回
 	QueryScope string `form:"query_scope" binding:"omitempty,query_scope"` // 查询范围
 	Title      string `form:"title" binding:"omitempty,max=255"`           // 消息标题
 }
@@ -10,6 +10,7 @@ type UserMessageGroup struct {
 	GroupName      string    `json:"group_name" gorm:"not null;column:group_name;type:varchar(255)"`
 	Desc           string    `json:"desc" gorm:"column:desc;type:text"`
 	EventID        int       `json:"event_id" gorm:"column:event_id;default:NULL"`
+	OwnerUserID    int       `json:"owner_user_id" gorm:"column:owner_user_id;default:0;index:idx_owner_user_id"`        // 个人通知群组所属的用户ID，为0时为普通群组
 	IncludeAllUser string    `json:"include_all_user" gorm:"not null;default:N;column:include_all_user;type:varchar(5)"` // 全体用户包含标记：默认 N
 	LatestMsgID    int       `json:"latest_msg_id" gorm:"column:latest_msg_id;default:0"`
 	IsDeleted      string    `json:"is_deleted" gorm:"not null;default:N;column:is_deleted;type:varchar(5)"` // 软删除标记：默认 N
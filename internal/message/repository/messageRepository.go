@@ -21,7 +21,7 @@ type MessageRepository interface {
 	// ListMessageGroupsByUserID 查询用户消息群组列表
 	ListMessageGroupsByUserID(ctx context.Context, page, pageSize int, userID int, typeCode string) ([]*dto.MessageGroupDTO, int64, error)
 	// ListMsgByGroups 分页查询分组内消息列表
-	ListMsgByGroups(ctx context.Context, page, pageSize int, msgGroupID int, userID int) ([]*dto.ListMessageDTO, int64, error)
+	ListMsgByGroups(ctx context.Context, pg *utils.Pagination, msgGroupID int, userID int) ([]*dto.ListMessageDTO, error)
 	// MarkAsReadByGroup 按分组更新消息为已读
 	MarkAsReadByGroup(ctx context.Context, userID int, msgGroupID int)
 	// CheckUserMsgPermission 权限校验查询，确保普通用户只能查看自己的消息
@@ -35,7 +35,7 @@ type MessageRepository interface {
 	// CreateMessageGroupMapping 创建消息-群组关联记录
 	CreateMessageGroupMapping(ctx context.Context, tx *gorm.DB, mapping *model.MessageGroupMapping) error
 	// ListMessagesByGroupID 查询指定消息组的所有消息
-	ListMessagesByGroupID(ctx context.Context, page, pageSize int, msgGroupID int, title string, queryScope string) ([]*dto.ListMessageDTO, int64, error)
+	ListMessagesByGroupID(ctx context.Context, pg *utils.Pagination, msgGroupID int, title string, queryScope string) ([]*dto.ListMessageDTO, error)
 	// DeleteMessageGroupMapping 删除消息-群组关联记录
 	DeleteMessageGroupMapping(ctx context.Context, mapID int, userID int) error
 }
@@ -224,16 +224,13 @@ func (repo *MessageRepositoryImpl) ListMessageGroupsByUserID(ctx context.Context
 	return results, total, nil
 }
 
-// ListMsgByGroups 分页查询分组内消息列表
-func (repo *MessageRepositoryImpl) ListMsgByGroups(ctx context.Context, page, pageSize int, msgGroupID int, userID int) ([]*dto.ListMessageDTO, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
+// listMessageCursor 以发送时间和消息ID作为消息列表的游标位置
+func listMessageCursor(m *dto.ListMessageDTO) utils.Cursor {
+	return utils.Cursor{Time: m.SendTime, ID: m.ID}
+}
 
-	offset := (page - 1) * pageSize
+// ListMsgByGroups 分页查询分组内消息列表
+func (repo *MessageRepositoryImpl) ListMsgByGroups(ctx context.Context, pg *utils.Pagination, msgGroupID int, userID int) ([]*dto.ListMessageDTO, error) {
 	var results []*dto.ListMessageDTO
 	query := repo.db.WithContext(ctx)
 
@@ -247,22 +244,26 @@ func (repo *MessageRepositoryImpl) ListMsgByGroups(ctx context.Context, page, pa
 		Where("m.is_deleted = ?", utils.DeletedFlagNo).  // 只查询未删除的消息
 		Where("mgm.is_deleted = ?", utils.DeletedFlagNo) // 只查询未删除的组内消息
 
-	// 按发送时间降序排列
-	query = query.Order("m.send_time DESC")
-
 	// 计算总数
-	var total int64
-	countQuery := query.Session(&gorm.Session{})
-	if err := countQuery.Select("count(distinct m.id)").Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	if pg.WithTotal {
+		if err := query.Session(&gorm.Session{}).Select("count(distinct m.id)").Count(&pg.Total).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+		}
 	}
 
+	// 按发送时间降序排列，发送时间相同按消息ID降序
+	if pg.Cursor != nil {
+		keyset, args := pg.Cursor.After("m.send_time", "m.id", true)
+		query = query.Where(keyset, args...)
+	}
+	query = query.Order("m.send_time DESC").Order("m.id DESC")
+
 	// 查询数据
-	if err := query.Offset(offset).Limit(pageSize).Find(&results).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	if err := query.Offset(pg.Offset()).Limit(pg.Limit()).Find(&results).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
 	}
 
-	return results, total, nil
+	return utils.TrimPage(pg, results, listMessageCursor), nil
 }
 
 // MarkAsReadByGroup 更新组内消息为已读
@@ -371,15 +372,7 @@ func (repo *MessageRepositoryImpl) CreateMessageGroupMapping(ctx context.Context
 }
 
 // ListMessagesByGroupID 查询指定消息组的所有消息
-func (repo *MessageRepositoryImpl) ListMessagesByGroupID(ctx context.Context, page, pageSize int, msgGroupID int, title string, queryScope string) ([]*dto.ListMessageDTO, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	offset := (page - 1) * pageSize
+func (repo *MessageRepositoryImpl) ListMessagesByGroupID(ctx context.Context, pg *utils.Pagination, msgGroupID int, title string, queryScope string) ([]*dto.ListMessageDTO, error) {
 	var results []*dto.ListMessageDTO
 	query := repo.db.WithContext(ctx)
 
@@ -408,22 +401,26 @@ func (repo *MessageRepositoryImpl) ListMessagesByGroupID(ctx context.Context, pa
 		query = query.Where("m.title LIKE ?", "%"+title+"%")
 	}
 
-	// 按发送时间降序排列
-	query = query.Order("m.send_time DESC")
-
 	// 计算总数
-	var total int64
-	countQuery := query.Session(&gorm.Session{})
-	if err := countQuery.Select("count(distinct m.id)").Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	if pg.WithTotal {
+		if err := query.Session(&gorm.Session{}).Select("count(distinct m.id)").Count(&pg.Total).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+		}
+	}
+
+	// 按发送时间降序排列，发送时间相同按消息ID降序
+	if pg.Cursor != nil {
+		keyset, args := pg.Cursor.After("m.send_time", "m.id", true)
+		query = query.Where(keyset, args...)
 	}
+	query = query.Order("m.send_time DESC").Order("m.id DESC")
 
 	// 查询数据
-	if err := query.Offset(offset).Limit(pageSize).Find(&results).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	if err := query.Offset(pg.Offset()).Limit(pg.Limit()).Find(&results).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
 	}
 
-	return results, total, nil
+	return utils.TrimPage(pg, results, listMessageCursor), nil
 }
 
 // DeleteMessageGroupMapping 删除消息-群组关联记录
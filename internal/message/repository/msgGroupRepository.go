@@ -7,6 +7,7 @@ import (
 	"news-release/internal/message/dto"
 	"news-release/internal/message/model"
 	"news-release/internal/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,6 +20,14 @@ type MsgGroupRepository interface {
 	CreateMsgGroup(ctx context.Context, group *model.UserMessageGroup) error
 	// GetMsgGroupByID 根据ID获取消息群组
 	GetMsgGroupByID(ctx context.Context, msgGroupID int) (*model.UserMessageGroup, error)
+	// GetDeletedMsgGroupByID 根据ID获取已删除的消息群组
+	GetDeletedMsgGroupByID(ctx context.Context, msgGroupID int) (*model.UserMessageGroup, error)
+	// GetMsgGroupByName 根据名称获取未删除的消息群组
+	GetMsgGroupByName(ctx context.Context, groupName string) (*model.UserMessageGroup, error)
+	// GetUserNoticeGroup 获取用户未删除的个人通知群组
+	GetUserNoticeGroup(ctx context.Context, userID int) (*model.UserMessageGroup, error)
+	// IsEventDeleted 检查消息群组关联的活动是否已删除
+	IsEventDeleted(ctx context.Context, eventID int) (bool, error)
 	// GetExistingMappings 查询指定群组中已存在的用户关联记录
 	GetExistingMappings(ctx context.Context, groupID int, userIDs []int) (map[int]model.UserMsgGroupMapping, error)
 	// CreateUserMsgGroupMappings 批量创建用户-消息群组关联记录
@@ -41,6 +50,8 @@ type MsgGroupRepository interface {
 	GetAllUserGroupIDs(ctx context.Context) ([]int, error)
 	// DeleteUserByGroupID 删除指定群组内的全部用户
 	DeleteUserByGroupID(ctx context.Context, tx *gorm.DB, msgGroupID int, updateField map[string]interface{}) error
+	// RecoverUserByGroupID 恢复删除群组时一并删除的群组用户
+	RecoverUserByGroupID(ctx context.Context, tx *gorm.DB, msgGroupID int, deletedSince time.Time, operateUser int) error
 }
 
 // MsgGroupRepositoryImpl 实现消息群组数据访问接口的具体结构体
@@ -85,6 +96,56 @@ func (repo *MsgGroupRepositoryImpl) GetMsgGroupByID(ctx context.Context, msgGrou
 	return &group, nil
 }
 
+// GetDeletedMsgGroupByID 根据ID获取已删除的消息群组
+func (repo *MsgGroupRepositoryImpl) GetDeletedMsgGroupByID(ctx context.Context, msgGroupID int) (*model.UserMessageGroup, error) {
+	var group model.UserMessageGroup
+	err := repo.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", msgGroupID, utils.DeletedFlagYes).First(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询消息群组失败: %v", err))
+	}
+	return &group, nil
+}
+
+// GetMsgGroupByName 根据名称获取未删除的消息群组
+func (repo *MsgGroupRepositoryImpl) GetMsgGroupByName(ctx context.Context, groupName string) (*model.UserMessageGroup, error) {
+	var group model.UserMessageGroup
+	err := repo.db.WithContext(ctx).Where("group_name = ? AND is_deleted = ?", groupName, utils.DeletedFlagNo).First(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询消息群组失败: %v", err))
+	}
+	return &group, nil
+}
+
+// GetUserNoticeGroup 获取用户未删除的个人通知群组
+func (repo *MsgGroupRepositoryImpl) GetUserNoticeGroup(ctx context.Context, userID int) (*model.UserMessageGroup, error) {
+	var group model.UserMessageGroup
+	err := repo.db.WithContext(ctx).Where("owner_user_id = ? AND is_deleted = ?", userID, utils.DeletedFlagNo).First(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询个人通知群组失败: %v", err))
+	}
+	return &group, nil
+}
+
+// IsEventDeleted 检查消息群组关联的活动是否已删除，活动不存在时视为已删除
+func (repo *MsgGroupRepositoryImpl) IsEventDeleted(ctx context.Context, eventID int) (bool, error) {
+	var count int64
+	if err := repo.db.WithContext(ctx).Table("events").
+		Where("id = ? AND is_deleted = ?", eventID, utils.DeletedFlagNo).
+		Count(&count).Error; err != nil {
+		return false, utils.NewSystemError(fmt.Errorf("查询关联活动失败: %v", err))
+	}
+	return count == 0, nil
+}
+
 // GetExistingMappings 查询指定群组中已存在的用户关联记录
 func (repo *MsgGroupRepositoryImpl) GetExistingMappings(ctx context.Context, groupID int, userIDs []int) (map[int]model.UserMsgGroupMapping, error) {
 	var mappings []model.UserMsgGroupMapping
@@ -353,15 +414,16 @@ func (repo *MsgGroupRepositoryImpl) GetAllUserGroupIDs(ctx context.Context) ([]i
 }
 
 // DeleteUserByGroupID 删除指定群组内的全部用户
+// 只更新未删除的用户，保留此前已退群用户的更新时间，恢复群组时据此区分随群组删除的用户
 func (repo *MsgGroupRepositoryImpl) DeleteUserByGroupID(ctx context.Context, tx *gorm.DB, msgGroupID int, updateField map[string]interface{}) error {
 	var err error
 	if tx == nil {
 		err = repo.db.WithContext(ctx).Model(&model.UserMsgGroupMapping{}).
-			Where("msg_group_id = ?", msgGroupID).
+			Where("msg_group_id = ? AND is_deleted = ?", msgGroupID, utils.DeletedFlagNo).
 			Updates(updateField).Error
 	} else {
 		err = tx.Model(&model.UserMsgGroupMapping{}).
-			Where("msg_group_id = ?", msgGroupID).
+			Where("msg_group_id = ? AND is_deleted = ?", msgGroupID, utils.DeletedFlagNo).
 			Updates(updateField).Error
 	}
 	if err != nil {
@@ -369,3 +431,17 @@ func (repo *MsgGroupRepositoryImpl) DeleteUserByGroupID(ctx context.Context, tx
 	}
 	return nil
 }
+
+// RecoverUserByGroupID 恢复删除群组时一并删除的群组用户
+// 删除群组时先更新群组再更新群组用户，因此更新时间不早于群组删除时间的已删除用户即为随群组删除的用户
+func (repo *MsgGroupRepositoryImpl) RecoverUserByGroupID(ctx context.Context, tx *gorm.DB, msgGroupID int, deletedSince time.Time, operateUser int) error {
+	if err := tx.WithContext(ctx).Model(&model.UserMsgGroupMapping{}).
+		Where("msg_group_id = ? AND is_deleted = ? AND update_time >= ?", msgGroupID, utils.DeletedFlagYes, deletedSince).
+		Updates(map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagNo,
+			"update_user": operateUser,
+		}).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("恢复群组[%d]内用户失败: %v", msgGroupID, err))
+	}
+	return nil
+}
@@ -20,13 +20,13 @@ type MessageService interface {
 	// ListMessageGroupsByUserID 分页查询用户消息群组列表
 	ListMessageGroupsByUserID(ctx context.Context, page, pageSize int, userID int, typeCode string) ([]*dto.MessageGroupDTO, int64, error)
 	// ListMsgByGroups 分页查询分组内消息列表
-	ListMsgByGroups(ctx context.Context, page, pageSize int, groupID int, userID int) ([]*dto.ListMessageDTO, int64, error)
+	ListMsgByGroups(ctx context.Context, pg *utils.Pagination, groupID int, userID int) ([]*dto.ListMessageDTO, error)
 	// HasUnreadMessages 检查用户是否有未读消息
 	HasUnreadMessages(ctx context.Context, userID int, typeCode string) (string, error)
 	// SendMessage 发送消息
 	SendMessage(ctx context.Context, msgGroupID int, msg *model.Message) error
 	// ListMessagesByGroupID 根据消息群组ID查询消息列表
-	ListMessagesByGroupID(ctx context.Context, page, pageSize int, groupID int, title string, queryScope string) ([]*dto.ListMessageDTO, int64, error)
+	ListMessagesByGroupID(ctx context.Context, pg *utils.Pagination, groupID int, title string, queryScope string) ([]*dto.ListMessageDTO, error)
 	// RevokeGroupMessage 撤回群组消息
 	RevokeGroupMessage(ctx context.Context, mapID int, userID int) error
 }
@@ -63,16 +63,16 @@ func (svc *MessageServiceImpl) ListMessageGroupsByUserID(ctx context.Context, pa
 }
 
 // ListMsgByGroups 分页查询分组内消息列表
-func (svc *MessageServiceImpl) ListMsgByGroups(ctx context.Context, page, pageSize int, groupID int, userID int) ([]*dto.ListMessageDTO, int64, error) {
+func (svc *MessageServiceImpl) ListMsgByGroups(ctx context.Context, pg *utils.Pagination, groupID int, userID int) ([]*dto.ListMessageDTO, error) {
 	// 校验权限，确保普通用户只能查看自己的消息
 	err := svc.messageRepo.CheckUserMsgPermission(ctx, userID, groupID)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	// 标记组内所有消息为已读
 	svc.messageRepo.MarkAsReadByGroup(ctx, userID, groupID)
 
-	return svc.messageRepo.ListMsgByGroups(ctx, page, pageSize, groupID, userID)
+	return svc.messageRepo.ListMsgByGroups(ctx, pg, groupID, userID)
 }
 
 // SendMessage 发送消息
@@ -109,8 +109,8 @@ func (svc *MessageServiceImpl) SendMessage(ctx context.Context, msgGroupID int,
 }
 
 // ListMessagesByGroupID 根据消息群组ID查询消息列表
-func (svc *MessageServiceImpl) ListMessagesByGroupID(ctx context.Context, page, pageSize int, groupID int, title string, queryScope string) ([]*dto.ListMessageDTO, int64, error) {
-	return svc.messageRepo.ListMessagesByGroupID(ctx, page, pageSize, groupID, title, queryScope)
+func (svc *MessageServiceImpl) ListMessagesByGroupID(ctx context.Context, pg *utils.Pagination, groupID int, title string, queryScope string) ([]*dto.ListMessageDTO, error) {
+	return svc.messageRepo.ListMessagesByGroupID(ctx, pg, groupID, title, queryScope)
 }
 
 // RevokeGroupMessage 撤回群组消息
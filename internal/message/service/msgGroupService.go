@@ -19,12 +19,16 @@ type MsgGroupService interface {
 	AddUserToGroup(ctx context.Context, msgGroupID int, userIDs []int, operateUser int) error
 	// CreateMsgGroup 创建消息群组
 	CreateMsgGroup(ctx context.Context, msgGroup *model.UserMessageGroup, userIDs []int) error
+	// GetUserNoticeGroup 获取用户的个人通知群组，不存在时自动创建
+	GetUserNoticeGroup(ctx context.Context, userID int, operateUser int) (*model.UserMessageGroup, error)
 	// DeleteUserFromGroup 用户退群
 	DeleteUserFromGroup(ctx context.Context, msgGroupID int, userIDs []int, operateUser int) error
 	// UpdateMsgGroup 更新消息群组
 	UpdateMsgGroup(ctx context.Context, msgGroupID int, request dto.UpdateMsgGroupRequest, userID int) error
 	// DeleteMsgGroup 删除消息群组
 	DeleteMsgGroup(ctx context.Context, msgGroupID int, userID int) error
+	// RestoreMsgGroup 恢复已删除的消息群组
+	RestoreMsgGroup(ctx context.Context, msgGroupID int, userID int) error
 	// ListMsgGroups 列表查询消息群组
 	ListMsgGroups(ctx context.Context, page int, pageSize int, groupName string, eventID int, queryScope string) ([]dto.ListMsgGroupResponse, int64, error)
 	// ListGroupsUsers 获取指定群组内用户
@@ -198,31 +202,63 @@ func (svc *MsgGroupServiceImpl) CreateMsgGroup(ctx context.Context, msgGroup *mo
 	}
 	// 如果是包含全体用户，则将全体用户添加到群组
 	if msgGroup.IncludeAllUser == utils.FlagYes {
-		var UIDs []int
-		page := 1
-		// 避免数据量过大，采用循环分批处理方式
-		for {
-			UIDs, err = svc.msgGroupRepo.GetAllUserIDs(ctx, page)
-			if err != nil {
-				logrus.Errorf("添加用户到群组失败 %s", err.Error())
-				return utils.NewBusinessError(utils.ErrCodeServerInternalError, "添加用户到群组失败，请手动添加")
-			}
-			// 若没有更多数据，退出循环
-			if len(UIDs) == 0 {
-				break
-			}
-			// 批量入群
-			err = svc.AddUserToGroup(ctx, msgGroup.ID, UIDs, msgGroup.CreateUser)
-			if err != nil {
-				logrus.Errorf("添加用户到群组失败 %s", err.Error())
-				return utils.NewBusinessError(utils.ErrCodeServerInternalError, "添加用户到群组失败，请手动添加")
+		return svc.addAllUsersToGroup(ctx, msgGroup.ID, msgGroup.CreateUser)
+	}
+
+	return nil
+}
+
+// GetUserNoticeGroup 获取用户的个人通知群组，不存在时自动创建
+// 个人通知群组只包含该用户一人，用于发送审核结果等只有本人可见的通知，每个用户只有一个，所有通知共用
+func (svc *MsgGroupServiceImpl) GetUserNoticeGroup(ctx context.Context, userID int, operateUser int) (*model.UserMessageGroup, error) {
+	group, err := svc.msgGroupRepo.GetUserNoticeGroup(ctx, userID)
+	if err != nil || group != nil {
+		return group, err
+	}
+
+	group = &model.UserMessageGroup{
+		GroupName:      fmt.Sprintf("个人通知-%d", userID),
+		Desc:           "仅本人可见的系统通知，由系统自动创建",
+		OwnerUserID:    userID,
+		IncludeAllUser: utils.FlagNo,
+		CreateUser:     operateUser,
+		UpdateUser:     operateUser,
+	}
+	if err := svc.CreateMsgGroup(ctx, group, []int{userID}); err != nil {
+		// 并发创建时群组名称冲突，使用已创建的群组
+		if bizErr, ok := utils.GetBusinessError(err); ok && bizErr.Code == utils.ErrCodeResourceExists {
+			existing, getErr := svc.msgGroupRepo.GetUserNoticeGroup(ctx, userID)
+			if getErr == nil && existing != nil {
+				return existing, nil
 			}
-			page++
 		}
-
+		return nil, err
 	}
+	return group, nil
+}
 
-	return nil
+// addAllUsersToGroup 将全体用户添加到群组，已在群组内的用户不受影响
+func (svc *MsgGroupServiceImpl) addAllUsersToGroup(ctx context.Context, msgGroupID int, operateUser int) error {
+	page := 1
+	// 避免数据量过大，采用循环分批处理方式
+	for {
+		UIDs, err := svc.msgGroupRepo.GetAllUserIDs(ctx, page)
+		if err != nil {
+			logrus.Errorf("添加用户到群组失败 %s", err.Error())
+			return utils.NewBusinessError(utils.ErrCodeServerInternalError, "添加用户到群组失败，请手动添加")
+		}
+		// 若没有更多数据，退出循环
+		if len(UIDs) == 0 {
+			return nil
+		}
+		// 批量入群
+		err = svc.AddUserToGroup(ctx, msgGroupID, UIDs, operateUser)
+		if err != nil {
+			logrus.Errorf("添加用户到群组失败 %s", err.Error())
+			return utils.NewBusinessError(utils.ErrCodeServerInternalError, "添加用户到群组失败，请手动添加")
+		}
+		page++
+	}
 }
 
 // DeleteUserFromGroup 用户退群
@@ -311,6 +347,63 @@ func (svc *MsgGroupServiceImpl) DeleteMsgGroup(ctx context.Context, msgGroupID i
 	return nil
 }
 
+// RestoreMsgGroup 恢复已删除的消息群组及随群组删除的群组用户
+func (svc *MsgGroupServiceImpl) RestoreMsgGroup(ctx context.Context, msgGroupID int, userID int) error {
+	// 检查群组是否存在且已删除
+	group, err := svc.msgGroupRepo.GetDeletedMsgGroupByID(ctx, msgGroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "消息群组不存在或未被删除")
+	}
+
+	// 活动群组随活动删除，需先恢复活动
+	if group.EventID != 0 {
+		deleted, err := svc.msgGroupRepo.IsEventDeleted(ctx, group.EventID)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "群组关联的活动已删除，请先恢复活动")
+		}
+	}
+
+	// 删除期间可能已创建同名群组
+	existing, err := svc.msgGroupRepo.GetMsgGroupByName(ctx, group.GroupName)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在同名消息群组，请修改同名群组的名称后重试")
+	}
+
+	// 使用 GORM 函数式事务执行
+	err = svc.msgGroupRepo.ExecTransaction(ctx, func(tx *gorm.DB) error {
+		updateField := map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagNo,
+			"update_user": userID,
+		}
+		if err := svc.msgGroupRepo.UpdateMsgGroup(ctx, tx, msgGroupID, updateField); err != nil {
+			return err
+		}
+
+		// 恢复随群组删除的用户，删除群组前已退群的用户不恢复
+		return svc.msgGroupRepo.RecoverUserByGroupID(ctx, tx, msgGroupID, group.UpdateTime, userID)
+	})
+
+	// 处理事务执行结果
+	if err != nil {
+		return utils.NewSystemError(fmt.Errorf("事务执行失败: %w", err))
+	}
+
+	// 包含全体用户的群组需补充删除期间注册的用户
+	if group.IncludeAllUser == utils.FlagYes {
+		return svc.addAllUsersToGroup(ctx, msgGroupID, userID)
+	}
+	return nil
+}
+
 // ListMsgGroups 列表查询消息群组
 func (svc *MsgGroupServiceImpl) ListMsgGroups(ctx context.Context, page int, pageSize int, groupName string, eventID int, queryScope string) ([]dto.ListMsgGroupResponse, int64, error) {
 	return svc.msgGroupRepo.ListMsgGroups(ctx, page, pageSize, groupName, eventID, queryScope)
@@ -86,6 +86,23 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware 可选JWT认证中间件
+// 携带有效令牌时与 AuthMiddleware 一样写入用户信息，未携带或令牌无效时按匿名用户继续处理
+func OptionalAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := parseToken(cfg, parts[1]); err == nil {
+				c.Set("openid", claims.OpenID)
+				c.Set("userid", claims.UserID)
+				c.Set("user_role", claims.UserRole)
+			}
+		}
+
+		c.Next()
+	}
+}
+
 // 解析JWT令牌
 func parseToken(cfg *config.Config, tokenString string) (*CustomClaims, error) {
 	secret := []byte(cfg.JWT.JwtSecret)
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"news-release/internal/config"
+	"news-release/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageMiddleware 语言中间件，依次根据 lang 查询参数、Accept-Language 请求头确定内容语言，均未匹配时使用默认语言
+func LanguageMiddleware(cfg *config.Config) gin.HandlerFunc {
+	defaultLang := cfg.I18n.Default()
+	return func(ctx *gin.Context) {
+		lang := matchLang(ctx.Query("lang"))
+		if lang == "" {
+			lang = parseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+		}
+		if lang == "" {
+			lang = defaultLang
+		}
+
+		ctx.Set("lang", lang)
+		ctx.Header("Vary", "Accept-Language")
+		ctx.Next()
+	}
+}
+
+// parseAcceptLanguage 按权重从高到低返回 Accept-Language 中第一个支持的语言，如 "en-US,en;q=0.9,zh;q=0.8"
+func parseAcceptLanguage(header string) string {
+	type weightedLang struct {
+		lang   string
+		weight float64
+	}
+
+	candidates := make([]weightedLang, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang := matchLang(tag)
+		if lang == "" {
+			continue
+		}
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight > 0 {
+			candidates = append(candidates, weightedLang{lang: lang, weight: weight})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].weight > candidates[j].weight })
+	return candidates[0].lang
+}
+
+// matchLang 取语言标签的主标签匹配支持的语言，如 zh-CN、zh_Hans 均匹配 zh，不支持时返回空字符串
+func matchLang(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if utils.IsSupportedLang(tag) {
+		return tag
+	}
+	return ""
+}
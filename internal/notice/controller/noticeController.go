@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"news-release/internal/notice/dto"
 	"news-release/internal/notice/service"
+	translationsvc "news-release/internal/translation/service"
 	"news-release/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -11,12 +12,13 @@ import (
 
 // NoticeController 控制器
 type NoticeController struct {
-	noticeService service.NoticeService
+	noticeService      service.NoticeService
+	translationService translationsvc.TranslationService
 }
 
 // NewNoticeController 创建控制器实例
-func NewNoticeController(noticeService service.NoticeService) *NoticeController {
-	return &NoticeController{noticeService: noticeService}
+func NewNoticeController(noticeService service.NoticeService, translationService translationsvc.TranslationService) *NoticeController {
+	return &NoticeController{noticeService: noticeService, translationService: translationService}
 }
 
 // ListNotice 分页查询公告列表
@@ -47,15 +49,34 @@ func (ctr *NoticeController) ListNotice(ctx *gin.Context) {
 		return
 	}
 
+	// 查询请求语言的译文
+	noticeIDs := make([]int, 0, len(notice))
+	for _, n := range notice {
+		noticeIDs = append(noticeIDs, n.ID)
+	}
+	translations, err := ctr.translationService.Localize(ctx, utils.TypeNotice, utils.GetLang(ctx), noticeIDs)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
 	var result []dto.NoticeResponse
 	for _, n := range notice {
-		result = append(result, dto.NoticeResponse{
+		item := dto.NoticeResponse{
 			ID:          n.ID,
 			Title:       n.Title,
 			Content:     n.Content,
 			ReleaseTime: *n.ReleaseTime,
+			Lang:        ctr.translationService.DefaultLang(),
 			// Status:      map[int]string{1: "有效", 0: "无效"}[n.Status],
-		})
+		}
+		// 缺少译文时保留原文
+		if translation, ok := translations[n.ID]; ok {
+			item.Title = translation.Title
+			item.Content = translation.Content
+			item.Lang = translation.Lang
+		}
+		result = append(result, item)
 	}
 
 	// 返回分页结果
@@ -88,6 +109,19 @@ func (ctr *NoticeController) GetNoticeContent(ctx *gin.Context) {
 		Title:       notice.Title,
 		Content:     notice.Content,
 		ReleaseTime: *notice.ReleaseTime,
+		Lang:        ctr.translationService.DefaultLang(),
+	}
+
+	// 替换为请求语言的译文，缺少译文时保留原文
+	translations, err := ctr.translationService.Localize(ctx, utils.TypeNotice, utils.GetLang(ctx), []int{notice.ID})
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	if translation, ok := translations[notice.ID]; ok {
+		result.Title = translation.Title
+		result.Content = translation.Content
+		result.Lang = translation.Lang
 	}
 
 	// 返回成功响应
@@ -19,6 +19,7 @@ type NoticeResponse struct {
 	Title       string    `json:"title"`
 	Content     string    `json:"content"`
 	ReleaseTime time.Time `json:"release_time"`
+	Lang        string    `json:"lang"` // 标题和内容实际使用的语言，缺少请求语言的译文时为默认语言
 }
 
 // NoticeContentResponse 公告内容响应结构体
@@ -27,4 +28,5 @@ type NoticeContentResponse struct {
 	Title       string    `json:"title"`
 	Content     string    `json:"content"`
 	ReleaseTime time.Time `json:"release_time"`
+	Lang        string    `json:"lang"` // 标题和内容实际使用的语言，缺少请求语言的译文时为默认语言
 }
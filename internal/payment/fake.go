@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// fakeSignatureHeader 模拟支付回调的签名请求头
+const fakeSignatureHeader = "X-Fake-Signature"
+
+// fakeCallback 模拟支付回调的请求体
+type fakeCallback struct {
+	OrderNo  string    `json:"order_no"`
+	TradeNo  string    `json:"trade_no"`
+	Amount   int       `json:"amount"`
+	PaidTime time.Time `json:"paid_time"`
+}
+
+// FakeProvider 本地模拟支付渠道，不产生真实交易，仅用于开发和测试
+// 回调请求体为JSON，签名为请求体的 HMAC-SHA256 十六进制值
+type FakeProvider struct {
+	secret []byte
+}
+
+// NewFakeProvider 创建模拟支付渠道
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: []byte(secret)}
+}
+
+// Name 支付渠道名称
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// CreatePayment 模拟发起支付，支付地址仅用于展示
+func (p *FakeProvider) CreatePayment(ctx context.Context, order Order) (*PayParams, error) {
+	return &PayParams{
+		Provider: ProviderFake,
+		PayURL:   "fake://pay?order_no=" + order.OrderNo,
+		Params: map[string]string{
+			"order_no":    order.OrderNo,
+			"amount":      strconv.Itoa(order.Amount),
+			"expire_time": order.ExpireTime.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// ParseCallback 校验签名并解析模拟支付回调
+func (p *FakeProvider) ParseCallback(header http.Header, body []byte) (*CallbackResult, error) {
+	signature, err := hex.DecodeString(header.Get(fakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.sign(body)) {
+		return nil, fmt.Errorf("模拟支付回调签名无效")
+	}
+
+	var callback fakeCallback
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, fmt.Errorf("解析模拟支付回调失败: %w", err)
+	}
+	if callback.OrderNo == "" || callback.TradeNo == "" {
+		return nil, fmt.Errorf("模拟支付回调缺少订单号或交易号")
+	}
+
+	return &CallbackResult{
+		OrderNo:  callback.OrderNo,
+		TradeNo:  callback.TradeNo,
+		Amount:   callback.Amount,
+		PaidTime: callback.PaidTime,
+	}, nil
+}
+
+// Refund 模拟退款，总是成功
+func (p *FakeProvider) Refund(ctx context.Context, order Order, refundNo string, amount int) (string, error) {
+	return "FAKE-" + refundNo, nil
+}
+
+// BuildCallback 构造已签名的支付成功回调，用于模拟用户完成支付
+func (p *FakeProvider) BuildCallback(orderNo string, amount int) (http.Header, []byte, error) {
+	body, err := json.Marshal(fakeCallback{
+		OrderNo:  orderNo,
+		TradeNo:  "FAKE-" + orderNo,
+		Amount:   amount,
+		PaidTime: time.Now(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := make(http.Header)
+	header.Set(fakeSignatureHeader, hex.EncodeToString(p.sign(body)))
+	return header, body, nil
+}
+
+// sign 计算请求体签名
+func (p *FakeProvider) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
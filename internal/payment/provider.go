@@ -0,0 +1,62 @@
+// Package payment 定义支付渠道接口，业务模块通过接口发起支付、校验回调和退款
+package payment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"news-release/internal/config"
+	"time"
+)
+
+// 支付渠道名称
+const (
+	ProviderFake = "fake" // 本地模拟支付
+)
+
+// Order 发起支付和退款所需的订单信息
+type Order struct {
+	OrderNo    string    // 订单号
+	Amount     int       // 订单金额，单位分
+	Subject    string    // 订单标题
+	ExpireTime time.Time // 支付截止时间
+}
+
+// PayParams 客户端拉起支付所需的参数
+type PayParams struct {
+	Provider string            `json:"provider"`         // 支付渠道
+	PayURL   string            `json:"pay_url"`          // 支付地址
+	Params   map[string]string `json:"params,omitempty"` // 拉起支付的其他参数
+}
+
+// CallbackResult 校验通过的支付结果通知
+type CallbackResult struct {
+	OrderNo  string    // 订单号
+	TradeNo  string    // 支付渠道的交易号
+	Amount   int       // 实际支付金额，单位分
+	PaidTime time.Time // 支付完成时间
+}
+
+// Provider 支付渠道接口
+type Provider interface {
+	// Name 支付渠道名称，与回调地址中的渠道名称一致
+	Name() string
+	// CreatePayment 发起支付，返回客户端拉起支付所需的参数
+	CreatePayment(ctx context.Context, order Order) (*PayParams, error)
+	// ParseCallback 校验支付结果通知的签名并解析支付结果，签名无效时返回错误
+	ParseCallback(header http.Header, body []byte) (*CallbackResult, error)
+	// Refund 发起退款，refundNo 为退款单号，同一退款单号重复调用不会重复退款，返回支付渠道的退款交易号
+	Refund(ctx context.Context, order Order, refundNo string, amount int) (string, error)
+}
+
+// NewProvider 根据配置创建支付渠道，未配置支付渠道时返回nil
+func NewProvider(cfg config.PaymentConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderFake:
+		return NewFakeProvider(cfg.CallbackSecret), nil
+	default:
+		return nil, fmt.Errorf("不支持的支付渠道: %s", cfg.Provider)
+	}
+}
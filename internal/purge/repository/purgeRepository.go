@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 可清理的数据类型
+const (
+	ResourceArticle  = utils.TypeArticle     // 文章
+	ResourceEvent    = utils.TypeEvent       // 活动
+	ResourceSeries   = utils.TypeEventSeries // 活动系列
+	ResourceMsgGroup = "MSG_GROUP"           // 消息群组
+	ResourceIndustry = "INDUSTRY"            // 行业
+)
+
+// purgeTable 各类型数据所在的表，软删除时的更新时间即为删除时间
+type purgeTable struct {
+	table    string // 表名
+	idColumn string // 主键列名
+}
+
+// purgeTables 各类型数据所在的表
+var purgeTables = map[string]purgeTable{
+	ResourceArticle:  {table: "articles", idColumn: "article_id"},
+	ResourceEvent:    {table: "events", idColumn: "id"},
+	ResourceSeries:   {table: "event_series", idColumn: "id"},
+	ResourceMsgGroup: {table: "user_message_groups", idColumn: "id"},
+	ResourceIndustry: {table: "industries", idColumn: "id"},
+}
+
+// PurgeRepository 已删除数据清理的数据访问接口
+type PurgeRepository interface {
+	// ListExpiredIDs 按ID升序查询删除时间早于before的数据ID
+	ListExpiredIDs(ctx context.Context, resource string, before time.Time, limit int) ([]int, error)
+	// ListImageObjects 查询数据关联图片的存储对象名，按数据ID分组
+	ListImageObjects(ctx context.Context, resource string, ids []int) (map[int][]string, error)
+	// Purge 彻底删除数据及其关联数据，返回实际删除的数据ID
+	Purge(ctx context.Context, resource string, ids []int, before time.Time) ([]int, error)
+}
+
+// PurgeRepositoryImpl 实现接口的具体结构体
+type PurgeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPurgeRepository 创建数据访问实例
+func NewPurgeRepository(db *gorm.DB) PurgeRepository {
+	return &PurgeRepositoryImpl{db: db}
+}
+
+// expiredQuery 构建查询删除时间早于before的数据的条件
+// 行业仍被用户引用时保留，避免用户资料中的行业名称丢失；活动系列仍有场次时保留，场次共用系列的图片
+func expiredQuery(db *gorm.DB, resource string, before time.Time) (*gorm.DB, purgeTable, error) {
+	t, ok := purgeTables[resource]
+	if !ok {
+		return nil, t, utils.NewSystemError(fmt.Errorf("不支持清理的数据类型: %s", resource))
+	}
+
+	query := db.Table(t.table).
+		Where("is_deleted = ? AND update_time < ?", utils.DeletedFlagYes, before)
+	if resource == ResourceIndustry {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM users u WHERE u.industry = industries.industry_code)")
+	}
+	if resource == ResourceSeries {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM events e WHERE e.series_id = event_series.id)")
+	}
+	return query, t, nil
+}
+
+// ListExpiredIDs 按ID升序查询删除时间早于before的数据ID
+func (repo *PurgeRepositoryImpl) ListExpiredIDs(ctx context.Context, resource string, before time.Time, limit int) ([]int, error) {
+	query, t, err := expiredQuery(repo.db.WithContext(ctx), resource, before)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	if err := query.Order(t.idColumn).Limit(limit).Pluck(t.idColumn, &ids).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询待清理数据失败: %v", err))
+	}
+	return ids, nil
+}
+
+// imageObject 图片存储对象及其所属数据ID
+type imageObject struct {
+	OwnerID    int    // 所属数据ID
+	ObjectName string // MinIO中的对象名
+}
+
+// ListImageObjects 查询数据关联图片的存储对象名，按数据ID分组
+// 活动的图片包含报名表单上传的文件
+func (repo *PurgeRepositoryImpl) ListImageObjects(ctx context.Context, resource string, ids []int) (map[int][]string, error) {
+	if resource != ResourceArticle && resource != ResourceEvent && resource != ResourceSeries {
+		return nil, nil
+	}
+
+	var objects []imageObject
+	if err := repo.db.WithContext(ctx).Table("images").
+		Select("biz_id AS owner_id, object_name").
+		Where("biz_type = ? AND biz_id IN ?", resource, ids).
+		Find(&objects).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询关联图片失败: %w", err))
+	}
+	if resource == ResourceEvent {
+		var uploads []imageObject
+		if err := repo.db.WithContext(ctx).Table("images i").
+			Select("m.event_id AS owner_id, i.object_name").
+			Joins("JOIN event_user_mappings m ON i.biz_id = m.id").
+			Where("i.biz_type = ? AND m.event_id IN ?", utils.TypeRegistration, ids).
+			Find(&uploads).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("查询报名上传文件失败: %w", err))
+		}
+		objects = append(objects, uploads...)
+	}
+
+	result := make(map[int][]string)
+	for _, object := range objects {
+		result[object.OwnerID] = append(result[object.OwnerID], object.ObjectName)
+	}
+	return result, nil
+}
+
+// Purge 彻底删除数据及其关联数据，返回实际删除的数据ID
+// 事务内重新锁定并确认数据仍满足清理条件，查询后被恢复的数据不会被删除
+func (repo *PurgeRepositoryImpl) Purge(ctx context.Context, resource string, ids []int, before time.Time) ([]int, error) {
+	var purged []int
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query, t, err := expiredQuery(tx, resource, before)
+		if err != nil {
+			return err
+		}
+		if err := query.Where(t.idColumn+" IN ?", ids).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck(t.idColumn, &purged).Error; err != nil {
+			return fmt.Errorf("锁定待清理数据失败: %w", err)
+		}
+		if len(purged) == 0 {
+			return nil
+		}
+
+		if err := purgeRelated(tx, resource, purged); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM "+t.table+" WHERE "+t.idColumn+" IN ?", purged).Error; err != nil {
+			return fmt.Errorf("删除%s失败: %w", t.table, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("清理已删除数据失败: %w", err))
+	}
+	return purged, nil
+}
+
+// purgeRelated 删除数据的关联数据
+func purgeRelated(tx *gorm.DB, resource string, ids []int) error {
+	args := map[string]interface{}{"ids": ids, "bizType": resource, "registration": utils.TypeRegistration}
+	switch resource {
+	case ResourceArticle:
+		return execAll(tx, args,
+			"DELETE FROM article_tag_mappings WHERE article_id IN @ids",
+			"DELETE FROM article_revisions WHERE article_id IN @ids",
+			"DELETE FROM article_pins WHERE article_id IN @ids",
+			"DELETE FROM article_view_logs WHERE article_id IN @ids",
+			"DELETE FROM article_likes WHERE article_id IN @ids",
+			"DELETE FROM article_favorites WHERE article_id IN @ids",
+			"DELETE FROM comments WHERE article_id IN @ids",
+			"DELETE FROM translations WHERE biz_type = @bizType AND biz_id IN @ids",
+			"DELETE FROM images WHERE biz_type = @bizType AND biz_id IN @ids",
+		)
+	case ResourceEvent:
+		return execAll(tx, args,
+			"DELETE FROM images WHERE biz_type = @registration AND biz_id IN (SELECT id FROM event_user_mappings WHERE event_id IN @ids)",
+			"DELETE FROM event_user_mappings WHERE event_id IN @ids",
+			"DELETE FROM event_orders WHERE event_id IN @ids",
+			"DELETE FROM event_reminders WHERE event_id IN @ids",
+			"DELETE FROM translations WHERE biz_type = @bizType AND biz_id IN @ids",
+			"DELETE FROM images WHERE biz_type = @bizType AND biz_id IN @ids",
+		)
+	case ResourceSeries:
+		return execAll(tx, args,
+			"DELETE FROM images WHERE biz_type = @bizType AND biz_id IN @ids",
+		)
+	case ResourceMsgGroup:
+		var messageIDs []int
+		if err := tx.Table("message_group_mappings").Where("msg_group_id IN ?", ids).
+			Distinct().Pluck("message_id", &messageIDs).Error; err != nil {
+			return fmt.Errorf("查询群组消息失败: %w", err)
+		}
+		if err := execAll(tx, args,
+			"DELETE FROM message_group_mappings WHERE msg_group_id IN @ids",
+			"DELETE FROM user_msg_group_mappings WHERE msg_group_id IN @ids",
+		); err != nil {
+			return err
+		}
+		if len(messageIDs) == 0 {
+			return nil
+		}
+		// 消息可发送到多个群组，仅删除不再属于任何群组的消息
+		return execAll(tx, map[string]interface{}{"messageIDs": messageIDs},
+			"DELETE FROM messages WHERE id IN @messageIDs AND NOT EXISTS (SELECT 1 FROM message_group_mappings mgm WHERE mgm.message_id = messages.id)",
+		)
+	}
+	return nil
+}
+
+// execAll 依次执行删除语句
+func execAll(tx *gorm.DB, args map[string]interface{}, statements ...string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement, args).Error; err != nil {
+			return fmt.Errorf("执行[%s]失败: %w", statement, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/config"
+	"news-release/internal/purge/repository"
+	"time"
+
+	filerepo "news-release/internal/file/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultPurgeBatchSize 默认每批清理的数据条数
+const defaultPurgeBatchSize = 100
+
+// purgeTarget 清理对象
+type purgeTarget struct {
+	resource string // 数据类型
+	name     string // 名称，用于日志
+}
+
+// purgeTargets 需要清理的数据，活动删除时其消息群组一并删除，随消息群组清理
+// 活动系列在其场次全部清理后才能清理，需排在活动之后
+var purgeTargets = []purgeTarget{
+	{resource: repository.ResourceArticle, name: "文章"},
+	{resource: repository.ResourceEvent, name: "活动"},
+	{resource: repository.ResourceSeries, name: "活动系列"},
+	{resource: repository.ResourceMsgGroup, name: "消息群组"},
+	{resource: repository.ResourceIndustry, name: "行业"},
+}
+
+// PurgeService 已删除数据清理服务接口
+type PurgeService interface {
+	// PurgeExpired 彻底删除超过保留期的已删除数据及其关联图片，由后台定时任务调用
+	PurgeExpired(ctx context.Context) error
+}
+
+// PurgeServiceImpl 实现接口的具体结构体
+type PurgeServiceImpl struct {
+	purgeRepo repository.PurgeRepository
+	minioRepo filerepo.MinIORepository
+	retention time.Duration
+	batchSize int
+}
+
+// NewPurgeService 创建服务实例
+func NewPurgeService(purgeRepo repository.PurgeRepository, minioRepo filerepo.MinIORepository, cfg *config.Config) PurgeService {
+	batchSize := cfg.Purge.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+	return &PurgeServiceImpl{
+		purgeRepo: purgeRepo,
+		minioRepo: minioRepo,
+		retention: cfg.Purge.Retention(),
+		batchSize: batchSize,
+	}
+}
+
+// PurgeExpired 依次清理各类超过保留期的已删除数据
+func (svc *PurgeServiceImpl) PurgeExpired(ctx context.Context) error {
+	before := time.Now().Add(-svc.retention)
+	for _, target := range purgeTargets {
+		if err := svc.purgeTarget(ctx, target, before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeTarget 分批清理一类数据，直到没有超过保留期的数据
+func (svc *PurgeServiceImpl) purgeTarget(ctx context.Context, target purgeTarget, before time.Time) error {
+	total := 0
+	for {
+		ids, err := svc.purgeRepo.ListExpiredIDs(ctx, target.resource, before, svc.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		// 图片记录随数据在事务中删除，需提前查出存储对象
+		objects, err := svc.purgeRepo.ListImageObjects(ctx, target.resource, ids)
+		if err != nil {
+			return err
+		}
+
+		purged, err := svc.purgeRepo.Purge(ctx, target.resource, ids, before)
+		if err != nil {
+			return err
+		}
+		total += len(purged)
+
+		// 数据库记录已删除，存储对象删除失败只记录日志，不影响后续清理
+		for _, id := range purged {
+			for _, objectName := range objects[id] {
+				if err := svc.minioRepo.DeleteFile(ctx, objectName); err != nil {
+					logrus.Errorf("清理%s[%d]的图片[%s]失败: %v", target.name, id, objectName, err)
+				}
+			}
+		}
+
+		if len(ids) < svc.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logrus.Infof("已彻底删除%d条超过保留期的%s", total, target.name)
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package routes
 
 import (
+	"context"
 	"fmt"
 	"news-release/internal/config"
+	"news-release/internal/content"
 	"news-release/internal/database"
 	"news-release/internal/middleware"
+	"news-release/internal/payment"
+	"news-release/internal/scheduler"
 	"news-release/internal/utils"
+	"time"
 
 	articlectr "news-release/internal/article/controller"
 	articlerepo "news-release/internal/article/repository"
@@ -31,6 +36,25 @@ import (
 	eventrepo "news-release/internal/event/repository"
 	eventsvc "news-release/internal/event/service"
 
+	searchctr "news-release/internal/search/controller"
+	searchrepo "news-release/internal/search/repository"
+	searchsvc "news-release/internal/search/service"
+
+	sitemapctr "news-release/internal/sitemap/controller"
+	sitemaprepo "news-release/internal/sitemap/repository"
+	sitemapsvc "news-release/internal/sitemap/service"
+
+	translationctr "news-release/internal/translation/controller"
+	translationrepo "news-release/internal/translation/repository"
+	translationsvc "news-release/internal/translation/service"
+
+	commentctr "news-release/internal/comment/controller"
+	commentrepo "news-release/internal/comment/repository"
+	commentsvc "news-release/internal/comment/service"
+
+	purgerepo "news-release/internal/purge/repository"
+	purgesvc "news-release/internal/purge/service"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
@@ -71,66 +95,239 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 	// 初始化仓库
 	articleRepo := articlerepo.NewArticleRepository(db)
 	fieldTypeRepo := articlerepo.NewFieldTypeRepository(db)
+	articleTypeRepo := articlerepo.NewArticleTypeRepository(db)
+	articlePinRepo := articlerepo.NewArticlePinRepository(db)
+	articleRevisionRepo := articlerepo.NewArticleRevisionRepository(db)
+	tagRepo := articlerepo.NewTagRepository(db)
+	articleEngagementRepo := articlerepo.NewArticleEngagementRepository(db)
 	noticeRepo := noticerepo.NewNoticeRepository(db)
 	fileRepo := filerepo.NewFileRepository(db)
 	userRepo := userrepo.NewUserRepository(db)
 	industryRepo := userrepo.NewIndustryRepository(db)
 	msgRepo := msgrepo.NewMessageRepository(db)
 	eventRepo := eventrepo.NewEventRepository(db)
+	orderRepo := eventrepo.NewOrderRepository(db)
+	seriesRepo := eventrepo.NewSeriesRepository(db)
+	reminderRepo := eventrepo.NewReminderRepository(db)
 	msgGroupRepo := msgrepo.NewMsgGroupRepository(db, msgRepo)
 	userRoleRepo := userrepo.NewUserRoleRepository(db)
+	searchRepo := searchrepo.NewMySQLSearchRepository(db)
+	commentRepo := commentrepo.NewCommentRepository(db)
+	sitemapRepo := sitemaprepo.NewSitemapRepository(db)
+	translationRepo := translationrepo.NewTranslationRepository(db)
+
+	// 创建支付渠道，未配置时不收取活动报名费用
+	payProvider, err := payment.NewProvider(cfg.Payment)
+	if err != nil {
+		logrus.Panic("创建支付渠道失败: ", err)
+	}
 
 	// 初始化服务
-	articleService := articlesvc.NewArticleService(articleRepo, fileRepo)
+	contentProcessor := content.NewProcessor(cfg.MinIO)
+	articleService := articlesvc.NewArticleService(articleRepo, fileRepo, articleRevisionRepo, tagRepo, contentProcessor)
+	articleRevisionService := articlesvc.NewArticleRevisionService(articleRepo, articleRevisionRepo, contentProcessor)
+	tagService := articlesvc.NewTagService(tagRepo)
+	articleEngagementService := articlesvc.NewArticleEngagementService(articleRepo, articleEngagementRepo, tagRepo)
 	fieldService := articlesvc.NewFieldTypeService(fieldTypeRepo)
+	articleTypeService := articlesvc.NewArticleTypeService(articleTypeRepo)
+	articlePinService := articlesvc.NewArticlePinService(articlePinRepo, articleRepo, fieldTypeRepo, articleTypeRepo)
 	noticeService := noticesvc.NewNoticeService(noticeRepo)
 	fileService := filesvc.NewFileService(minioRepo, fileRepo)
+	articleTransferService := articlesvc.NewArticleTransferService(articleService, articleRepo, tagRepo, fileRepo, minioRepo, fileService, contentProcessor)
 	msgService := msgsvc.NewMessageService(msgRepo, msgGroupRepo)
 	msgGroupService := msgsvc.NewMsgGroupService(msgGroupRepo, msgRepo)
 	userService := usersvc.NewUserService(userRepo, msgGroupService, cfg)
 	industryService := usersvc.NewIndustryService(industryRepo)
-	eventService := eventsvc.NewEventService(eventRepo, userRepo, fileRepo, msgGroupService)
+	eventService := eventsvc.NewEventService(eventRepo, orderRepo, seriesRepo, reminderRepo, userRepo, fileRepo, msgGroupService, msgService, contentProcessor, payProvider, cfg)
 	userRoleService := usersvc.NewUserRoleService(userRoleRepo)
+	searchService := searchsvc.NewSearchService(searchRepo)
+	feedService := articlesvc.NewFeedService(articleRepo, tagRepo, cfg)
+	commentService := commentsvc.NewCommentService(commentRepo, articleRepo, cfg)
+	sitemapService := sitemapsvc.NewSitemapService(sitemapRepo, cfg)
+	translationService := translationsvc.NewTranslationService(translationRepo, contentProcessor, cfg)
+	purgeService := purgesvc.NewPurgeService(purgerepo.NewPurgeRepository(db), minioRepo, cfg)
+
+	// 启动后台定时任务
+	scheduler.Every(context.Background(), "文章定时发布", time.Minute, articleService.PublishScheduledArticles)
+	scheduler.Every(context.Background(), "文章正文纯文本补全", time.Hour, articleService.FillContentText)
+	scheduler.Every(context.Background(), "活动详情纯文本补全", time.Hour, eventService.FillDetailText)
+	if cfg.Payment.Enabled() {
+		scheduler.Every(context.Background(), "活动订单处理", cfg.Payment.RunInterval(), eventService.ProcessOrders)
+	}
+	if cfg.Reminder.Enabled() {
+		// 多实例部署时通过数据库锁保证同一时间只有一个实例发送提醒，实例异常退出时锁10分钟后过期
+		locker := scheduler.NewLocker(db)
+		scheduler.Every(context.Background(), "活动提醒", cfg.Reminder.RunInterval(),
+			locker.Exclusive("event_reminder", 10*time.Minute, eventService.SendReminders))
+	}
+	if cfg.Purge.Enabled() {
+		scheduler.Every(context.Background(), "已删除数据清理", cfg.Purge.RunInterval(), purgeService.PurgeExpired)
+	}
 
 	// 初始化控制器
-	articleController := articlectr.NewArticleController(articleService)
+	articleController := articlectr.NewArticleController(articleService, articleEngagementService, translationService)
 	fieldTypeController := articlectr.NewFieldTypeController(fieldService)
-	noticeController := noticectr.NewNoticeController(noticeService)
+	articleTypeController := articlectr.NewArticleTypeController(articleTypeService)
+	articlePinController := articlectr.NewArticlePinController(articlePinService)
+	articleRevisionController := articlectr.NewArticleRevisionController(articleRevisionService)
+	articleTransferController := articlectr.NewArticleTransferController(articleTransferService)
+	tagController := articlectr.NewTagController(tagService)
+	noticeController := noticectr.NewNoticeController(noticeService, translationService)
 	fileController := filectr.NewFileController(fileService)
 	userController := userctr.NewUserController(userService)
 	industryController := userctr.NewIndustryController(industryService)
 	msgController := msgctr.NewMessageController(msgService)
-	eventController := eventctr.NewEventController(eventService)
+	eventController := eventctr.NewEventController(eventService, translationService)
+	orderController := eventctr.NewOrderController(eventService, payProvider)
+	seriesController := eventctr.NewSeriesController(eventService)
 	msgGroupController := msgctr.NewMsgGroupController(msgGroupService)
 	userRoleController := userctr.NewUserRoleController(userRoleService)
+	searchController := searchctr.NewSearchController(searchService)
+	feedController := articlectr.NewFeedController(feedService)
+	commentController := commentctr.NewCommentController(commentService)
+	sitemapController := sitemapctr.NewSitemapController(sitemapService)
+	translationController := translationctr.NewTranslationController(translationService)
+
+	// 文章订阅源，供合作站点聚合
+	feed := router.Group("/feed")
+	{
+		feed.GET("/articles.rss", feedController.ArticleRSS)
+		feed.GET("/articles.atom", feedController.ArticleAtom)
+		feed.GET("/articles.json", feedController.ArticleJSONFeed)
+	}
+
+	// 站点地图，供搜索引擎收录已发布的文章和活动
+	router.GET("/sitemap.xml", sitemapController.GetSitemapIndex)
+	router.GET("/sitemaps/:name", sitemapController.GetSitemap)
 
 	// API分组
 	api := router.Group("/api")
+	api.Use(middleware.LanguageMiddleware(cfg))
 	{
 		// articles
 		articles := api.Group("/articles")
 		{
 			// 公开接口 - 无需认证
 			articles.GET("", articleController.ListArticle)
-			articles.GET("/:id", articleController.GetArticleContent)
+			articles.GET("/:id", middleware.OptionalAuthMiddleware(cfg), articleController.GetArticleContent)
+			articles.GET("/:id/related", articleController.ListRelatedArticles)
 			// 需要认证的用户接口
 			authArticles := articles.Group("")
 			authArticles.Use(middleware.AuthMiddleware(cfg))
 			{
+				// 点赞、收藏
+				authArticles.POST("/like/:id", articleController.LikeArticle)
+				authArticles.DELETE("/unlike/:id", articleController.UnlikeArticle)
+				authArticles.POST("/favorite/:id", articleController.FavoriteArticle)
+				authArticles.DELETE("/unfavorite/:id", articleController.UnfavoriteArticle)
 				// 管理员接口 - 在认证基础上增加角色校验
 				adminArticles := authArticles.Group("")
 				adminArticles.Use(middleware.RoleMiddleware(utils.RoleAdmin))
 				{
+					adminArticles.GET("/listAll", articleController.ListAllArticles)
+					adminArticles.GET("/preview/:id", articleController.PreviewArticle)
 					adminArticles.POST("/create", articleController.CreateArticle)
 					adminArticles.PUT("/update/:id", articleController.UpdateArticle)
 					adminArticles.DELETE("/delete/:id", articleController.DeleteArticle)
+					adminArticles.PUT("/restore/:id", articleController.RestoreArticle)
+					// 文章审核发布流程
+					adminArticles.PUT("/submit/:id", articleController.SubmitArticle)
+					adminArticles.PUT("/approve/:id", articleController.ApproveArticle)
+					adminArticles.PUT("/reject/:id", articleController.RejectArticle)
+					adminArticles.PUT("/schedule/:id", articleController.ScheduleArticle)
+					adminArticles.PUT("/archive/:id", articleController.ArchiveArticle)
+					// 文章修订记录
+					adminArticles.GET("/revisions/:id", articleRevisionController.ListRevisions)
+					adminArticles.GET("/revisionDiff/:id", articleRevisionController.DiffRevisions)
+					adminArticles.PUT("/restoreRevision/:id", articleRevisionController.RestoreRevision)
+
+					adminArticles.POST("/import", articleTransferController.ImportArticles)
+					adminArticles.GET("/export", articleTransferController.ExportArticles)
+					// 文章置顶
+					adminArticles.GET("/pins", articlePinController.ListPins)
+					adminArticles.POST("/pins/create", articlePinController.CreatePin)
+					adminArticles.PUT("/pins/update/:id", articlePinController.UpdatePin)
+					adminArticles.DELETE("/pins/delete/:id", articlePinController.DeletePin)
+					adminArticles.PUT("/pins/reorder", articlePinController.ReorderPins)
 				}
 			}
 		}
+		// 标签相关路由
+		tags := api.Group("/tags")
+		{
+			// 公开接口 - 无需认证
+			tags.GET("", tagController.ListTags)
+			tags.GET("/:id", tagController.GetTag)
+			// 管理员接口 - 在认证基础上增加角色校验
+			adminTags := tags.Group("")
+			adminTags.Use(middleware.AuthMiddleware(cfg), middleware.RoleMiddleware(utils.RoleAdmin))
+			{
+				adminTags.POST("/create", tagController.CreateTag)
+				adminTags.PUT("/update/:id", tagController.UpdateTag)
+				adminTags.DELETE("/delete/:id", tagController.DeleteTag)
+			}
+		}
+		// 评论相关路由
+		comments := api.Group("/comments")
+		{
+			// 公开接口 - 无需认证
+			comments.GET("/article/:id", commentController.ListArticleComments)
+			comments.GET("/replies/:id", commentController.ListReplies)
+			// 需要认证的用户接口
+			authComments := comments.Group("")
+			authComments.Use(middleware.AuthMiddleware(cfg))
+			{
+				authComments.POST("/create", commentController.CreateComment)
+				authComments.DELETE("/delete/:id", commentController.DeleteComment)
+				// 管理员接口 - 评论审核
+				adminComments := authComments.Group("")
+				adminComments.Use(middleware.RoleMiddleware(utils.RoleAdmin))
+				{
+					adminComments.GET("/moderation", commentController.ListModeration)
+					adminComments.PUT("/approve/:id", commentController.ApproveComment)
+					adminComments.PUT("/reject/:id", commentController.RejectComment)
+					adminComments.PUT("/hide/:id", commentController.HideComment)
+				}
+			}
+		}
+		// 全文搜索路由
+		api.GET("/search", searchController.Search)
 		// 领域类型相关路由
 		policyFieldType := api.Group("/fieldType")
 		{
 			policyFieldType.GET("", fieldTypeController.GetFieldType)
+			// 管理员接口 - 领域类型管理
+			adminFieldType := policyFieldType.Group("")
+			adminFieldType.Use(middleware.AuthMiddleware(cfg), middleware.RoleMiddleware(utils.RoleAdmin))
+			{
+				adminFieldType.POST("/create", fieldTypeController.CreateFieldType)
+				adminFieldType.PUT("/update/:field_id", fieldTypeController.UpdateFieldType)
+				adminFieldType.DELETE("/delete/:field_id", fieldTypeController.DeleteFieldType)
+				adminFieldType.PUT("/reorder", fieldTypeController.ReorderFieldTypes)
+			}
+		}
+		// 文章类型相关路由
+		articleType := api.Group("/articleType")
+		{
+			articleType.GET("", articleTypeController.ListArticleTypes)
+			// 管理员接口 - 文章类型管理
+			adminArticleType := articleType.Group("")
+			adminArticleType.Use(middleware.AuthMiddleware(cfg), middleware.RoleMiddleware(utils.RoleAdmin))
+			{
+				adminArticleType.POST("/create", articleTypeController.CreateArticleType)
+				adminArticleType.PUT("/update/:id", articleTypeController.UpdateArticleType)
+				adminArticleType.DELETE("/delete/:id", articleTypeController.DeleteArticleType)
+				adminArticleType.PUT("/reorder", articleTypeController.ReorderArticleTypes)
+			}
+		}
+		// 多语言译文路由，仅管理员可操作
+		translation := api.Group("/translation")
+		translation.Use(middleware.AuthMiddleware(cfg), middleware.RoleMiddleware(utils.RoleAdmin))
+		{
+			translation.GET("/missing", translationController.ListMissing)
+			translation.GET("/:biz_type/:biz_id", translationController.ListTranslations)
+			translation.PUT("/:biz_type/:biz_id/:lang", translationController.SaveTranslation)
+			translation.DELETE("/:biz_type/:biz_id/:lang", translationController.DeleteTranslation)
 		}
 		// 公告相关路由
 		notice := api.Group("/notice")
@@ -150,6 +347,7 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 			{
 				authUser.PUT("/update", middleware.AuthMiddleware(cfg), userController.UpdateUserInfo)
 				authUser.GET("/info", middleware.AuthMiddleware(cfg), userController.GetUserInfo)
+				authUser.GET("/favorites", articleController.ListUserFavorites)
 				// 管理员接口 - 在认证基础上增加角色校验
 				adminUser := authUser.Group("")
 				adminUser.Use(middleware.RoleMiddleware(utils.RoleAdmin))
@@ -179,6 +377,8 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 				{
 					adminIndustry.POST("/create", industryController.CreateIndustry)
 					adminIndustry.PUT("/update/:id", industryController.UpdateIndustry)
+					adminIndustry.DELETE("/delete/:id", industryController.DeleteIndustry)
+					adminIndustry.PUT("/restore/:id", industryController.RestoreIndustry)
 				}
 			}
 		}
@@ -220,6 +420,7 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 				adminMessage.DELETE("/revokeMessage/:id", msgController.RevokeGroupMessage)
 				adminMessage.DELETE("/removeUserFromGroup/:id", msgGroupController.DeleteUserFromGroup)
 				adminMessage.DELETE("/deleteGroup/:id", msgGroupController.DeleteMsgGroup)
+				adminMessage.PUT("/restoreGroup/:id", msgGroupController.RestoreMsgGroup)
 			}
 		}
 		// 活动相关路由
@@ -228,6 +429,10 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 			// 公开接口 - 无需认证
 			event.GET("", eventController.ListEvent)
 			event.GET("/:id", eventController.GetEventDetail)
+			event.GET("/:id/ics", eventController.ExportEventCalendar)
+			// 日历订阅，通过订阅令牌识别用户，供日历客户端定期拉取
+			event.GET("/calendar/:token", eventController.SubscribeCalendar)
+			event.GET("/series/:id", seriesController.GetSeriesDetail)
 
 			// 需要认证的用户接口
 			authEvent := event.Group("")
@@ -237,6 +442,10 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 				authEvent.GET("/isUserRegistered/:id", eventController.IsUserRegistered)
 				authEvent.DELETE("/cancelRegistration/:id", eventController.CancelRegistrationEvent)
 				authEvent.GET("/userRegisteredEvents", eventController.ListUserRegisteredEvents)
+				authEvent.GET("/checkInCode/:id", eventController.GetCheckInQRCode)
+				authEvent.GET("/calendarSubscription", eventController.GetCalendarSubscription)
+				authEvent.POST("/series/registration", seriesController.RegisterSeries)
+				authEvent.DELETE("/series/cancelRegistration/:id", seriesController.CancelSeriesRegistration)
 
 				// 管理员接口 - 在认证基础上增加角色校验
 				adminEvent := authEvent.Group("")
@@ -245,9 +454,32 @@ func SetupRoutes(cfg *config.Config, router *gin.Engine) {
 					adminEvent.POST("/create", eventController.CreateEvent)
 					adminEvent.PUT("/update/:id", eventController.UpdateEvent)
 					adminEvent.DELETE("/delete/:id", eventController.DeleteEvent)
+					adminEvent.PUT("/restore/:id", eventController.RestoreEvent)
+					adminEvent.PUT("/cancel/:id", eventController.CancelEvent)
 					adminEvent.GET("/regUsers/:id", eventController.ListEventRegisteredUsers)
+					adminEvent.GET("/exportRegUsers/:id", eventController.ExportEventRegisteredUsers)
+					adminEvent.PUT("/reviewRegistrations/:id", eventController.ReviewRegistrations)
+					adminEvent.POST("/checkIn", eventController.CheckIn)
+					adminEvent.GET("/attendance/:id", eventController.GetAttendance)
+					adminEvent.GET("/series", seriesController.ListSeries)
+					adminEvent.POST("/series/create", seriesController.CreateSeries)
+					adminEvent.PUT("/series/update/:id", seriesController.UpdateSeries)
+					adminEvent.DELETE("/series/delete/:id", seriesController.DeleteSeries)
 				}
 			}
 		}
+		// 活动订单相关路由
+		order := api.Group("/order")
+		order.Use(middleware.AuthMiddleware(cfg))
+		{
+			order.GET("/list", orderController.ListUserOrders)
+			order.POST("/pay/:orderNo", orderController.PayOrder)
+			if cfg.Payment.Provider == payment.ProviderFake {
+				// 模拟支付，仅用于开发和测试
+				order.POST("/fakePay/:orderNo", orderController.FakePay)
+			}
+		}
+		// 支付渠道回调，通过签名校验请求来源，无需认证
+		api.POST("/payment/callback/:provider", orderController.PaymentCallback)
 	}
 }
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lock 对应 scheduler_locks 表的数据模型，多实例部署时同一任务同时只在一个实例上执行
+type Lock struct {
+	Name       string    `gorm:"type:varchar(64);primaryKey;column:name"` // 锁名称，通常为任务名称
+	Owner      string    `gorm:"type:varchar(128);not null;column:owner"` // 持有锁的实例标识
+	ExpireTime time.Time `gorm:"not null;column:expire_time"`             // 锁过期时间，实例异常退出未释放的锁过期后可被其他实例获取
+}
+
+// TableName 设置表名
+func (*Lock) TableName() string {
+	return "scheduler_locks"
+}
+
+// Locker 基于数据库的任务锁
+type Locker struct {
+	db    *gorm.DB
+	owner string // 当前实例标识
+}
+
+// NewLocker 创建任务锁实例，实例标识由主机名、进程号和随机数组成
+func NewLocker(db *gorm.DB) *Locker {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return &Locker{db: db, owner: fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))}
+}
+
+// Exclusive 包装任务，执行前获取锁，未获取到锁时跳过本次执行
+// ttl 应大于任务的最长执行时间，超时后锁可能被其他实例获取
+func (l *Locker) Exclusive(name string, ttl time.Duration, job JobFunc) JobFunc {
+	return func(ctx context.Context) error {
+		acquired, err := l.tryLock(ctx, name, ttl)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			logrus.Debugf("定时任务锁[%s]已被其他实例持有，跳过本次执行", name)
+			return nil
+		}
+		defer l.unlock(name)
+
+		return job(ctx)
+	}
+}
+
+// tryLock 尝试获取锁，锁不存在时创建，已存在时仅在过期后抢占
+func (l *Locker) tryLock(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	lock := &Lock{Name: name, Owner: l.owner, ExpireTime: now.Add(ttl)}
+	result := l.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	if result.Error != nil {
+		return false, fmt.Errorf("获取定时任务锁[%s]失败: %w", name, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = l.db.WithContext(ctx).Model(&Lock{}).
+		Where("name = ? AND expire_time < ?", name, now).
+		Updates(map[string]interface{}{"owner": l.owner, "expire_time": now.Add(ttl)})
+	if result.Error != nil {
+		return false, fmt.Errorf("获取定时任务锁[%s]失败: %w", name, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// unlock 释放当前实例持有的锁，任务被取消时也需要释放，因此不使用任务的ctx
+func (l *Locker) unlock(name string) {
+	err := l.db.Where("name = ? AND owner = ?", name, l.owner).Delete(&Lock{}).Error
+	if err != nil {
+		logrus.Errorf("释放定时任务锁[%s]失败: %v", name, err)
+	}
+}
@@ -0,0 +1,46 @@
+// Package scheduler 用于运行后台定时任务
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JobFunc 定时任务执行函数
+type JobFunc func(ctx context.Context) error
+
+// Every 启动后台协程，按固定间隔执行任务，直到ctx被取消
+// 任务执行失败只记录日志，不影响下一次执行
+func Every(ctx context.Context, name string, interval time.Duration, job JobFunc) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		logrus.Infof("定时任务[%s]已启动，执行间隔 %s", name, interval)
+		for {
+			runJob(ctx, name, job)
+
+			select {
+			case <-ctx.Done():
+				logrus.Infof("定时任务[%s]已停止", name)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runJob 执行一次任务，捕获panic避免后台协程退出
+func runJob(ctx context.Context, name string, job JobFunc) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("定时任务[%s]发生异常: %v", name, r)
+		}
+	}()
+
+	if err := job(ctx); err != nil {
+		logrus.Errorf("定时任务[%s]执行失败: %v", name, err)
+	}
+}
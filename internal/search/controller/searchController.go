@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/search/dto"
+	"news-release/internal/search/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController 全文搜索控制器
+type SearchController struct {
+	searchService service.SearchService
+}
+
+// NewSearchController 创建控制器实例
+func NewSearchController(searchService service.SearchService) *SearchController {
+	return &SearchController{searchService: searchService}
+}
+
+// Search 按关键词检索文章、活动和公告
+func (ctr *SearchController) Search(ctx *gin.Context) {
+	// 初始化参数结构体并绑定查询参数
+	var req dto.SearchRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	// 调用服务层
+	results, total, facets, err := ctr.searchService.Search(ctx, page, pageSize, req)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回分页结果
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+		"facets":    facets,
+	})
+}
@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+// SearchRequest 全文搜索请求参数
+type SearchRequest struct {
+	Keyword   string `form:"q" binding:"required,non_empty_string,max=100"`       // 搜索关键词，多个关键词以空格分隔
+	Type      string `form:"type" binding:"omitempty,oneof=ARTICLE EVENT NOTICE"` // 文档类型，为空时搜索全部类型
+	StartDate string `form:"start_date" binding:"omitempty,time_format"`          // 发布时间起
+	EndDate   string `form:"end_date" binding:"omitempty,time_format"`            // 发布时间止
+	Page      int    `form:"page" binding:"omitempty,min=1"`                      // 页码，最小为1
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=50"`          // 页大小，1-50
+}
+
+// SearchQuery 搜索引擎查询条件
+type SearchQuery struct {
+	Keyword   string     // 搜索关键词
+	Type      string     // 文档类型，为空时不限
+	StartTime *time.Time // 发布时间起（含）
+	EndTime   *time.Time // 发布时间止（不含）
+	Offset    int        // 偏移量
+	Limit     int        // 返回条数
+}
+
+// SearchHit 搜索引擎返回的单条命中结果
+type SearchHit struct {
+	Type        string    `gorm:"column:doc_type"`
+	ID          int       `gorm:"column:doc_id"`
+	Title       string    `gorm:"column:title"`
+	Content     string    `gorm:"column:content"`
+	PublishTime time.Time `gorm:"column:publish_time"`
+	Score       float64   `gorm:"column:score"`
+}
+
+// FacetCount 分面统计项
+type FacetCount struct {
+	Key   string `json:"key" gorm:"column:facet_key"`
+	Label string `json:"label" gorm:"-"`
+	Count int64  `json:"count" gorm:"column:facet_count"`
+}
+
+// SearchResult 搜索引擎返回的结果
+type SearchResult struct {
+	Hits       []SearchHit  // 当前页命中结果，按相关度降序
+	Total      int64        // 命中总数
+	TypeFacets []FacetCount // 按文档类型统计（不受类型筛选影响）
+	DateFacets []FacetCount // 按发布月份统计（不受日期筛选影响）
+}
+
+// SearchItemResponse 搜索结果项
+type SearchItemResponse struct {
+	Type        string    `json:"type"`
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`        // 标题，命中关键词以 <em> 标签高亮
+	Snippet     string    `json:"snippet"`      // 正文摘要片段，命中关键词以 <em> 标签高亮
+	PublishTime time.Time `json:"publish_time"` // 发布时间
+	Score       float64   `json:"score"`        // 相关度得分
+}
+
+// SearchFacetsResponse 搜索分面统计
+type SearchFacetsResponse struct {
+	Type []FacetCount `json:"type"` // 按文档类型统计
+	Date []FacetCount `json:"date"` // 按发布月份统计，key 格式为 YYYY-MM
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Document 可被检索的文档，文章、活动和公告统一转换为该结构
+type Document struct {
+	Type        string    // 文档类型，取值为 utils.TypeArticle / utils.TypeEvent / utils.TypeNotice
+	ID          int       // 文档在各自业务表中的主键
+	Title       string    // 标题
+	Content     string    // 正文（文章为摘要+内容，活动为详情，公告为内容）
+	PublishTime time.Time // 发布时间，用于日期筛选与日期分面
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/search/dto"
+	"news-release/internal/search/model"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// titleWeight 标题命中的权重，标题命中比正文命中更相关
+const titleWeight = 3
+
+// MemorySearchRepository 基于内存的搜索实现，用于测试或本地调试
+// 分词规则与 MySQL ngram 解析器保持一致，相关度为各检索词在标题和正文中出现次数的加权和
+type MemorySearchRepository struct {
+	mu        sync.RWMutex
+	documents map[string]model.Document
+}
+
+// NewMemorySearchRepository 创建数据访问实例
+func NewMemorySearchRepository() *MemorySearchRepository {
+	return &MemorySearchRepository{documents: make(map[string]model.Document)}
+}
+
+// documentKey 生成文档的唯一键
+func documentKey(docType string, id int) string {
+	return fmt.Sprintf("%s:%d", docType, id)
+}
+
+// Index 写入或覆盖一篇文档
+func (repo *MemorySearchRepository) Index(doc model.Document) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.documents[documentKey(doc.Type, doc.ID)] = doc
+}
+
+// Remove 移除一篇文档
+func (repo *MemorySearchRepository) Remove(docType string, id int) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.documents, documentKey(docType, id))
+}
+
+// score 计算文档与检索词的相关度，未命中时返回0
+func score(doc model.Document, tokens []string) float64 {
+	title := strings.ToLower(doc.Title)
+	content := strings.ToLower(doc.Content)
+
+	var total float64
+	for _, token := range tokens {
+		total += float64(strings.Count(title, token)*titleWeight + strings.Count(content, token))
+	}
+	return total
+}
+
+// Search 按关键词检索，结果按相关度降序、发布时间降序排列
+func (repo *MemorySearchRepository) Search(_ context.Context, q dto.SearchQuery) (*dto.SearchResult, error) {
+	tokens := Tokenize(q.Keyword)
+
+	repo.mu.RLock()
+	var matched []dto.SearchHit
+	for _, doc := range repo.documents {
+		s := score(doc, tokens)
+		if s == 0 {
+			continue
+		}
+		matched = append(matched, dto.SearchHit{
+			Type:        doc.Type,
+			ID:          doc.ID,
+			Title:       doc.Title,
+			Content:     doc.Content,
+			PublishTime: doc.PublishTime,
+			Score:       s,
+		})
+	}
+	repo.mu.RUnlock()
+
+	inType := func(hit dto.SearchHit) bool {
+		return q.Type == "" || hit.Type == q.Type
+	}
+	inDate := func(hit dto.SearchHit) bool {
+		if q.StartTime != nil && hit.PublishTime.Before(*q.StartTime) {
+			return false
+		}
+		if q.EndTime != nil && !hit.PublishTime.Before(*q.EndTime) {
+			return false
+		}
+		return true
+	}
+
+	// 分面统计：类型分面仅应用日期筛选，日期分面仅应用类型筛选
+	typeCounts := make(map[string]int64)
+	dateCounts := make(map[string]int64)
+	var hits []dto.SearchHit
+	for _, hit := range matched {
+		if inDate(hit) {
+			typeCounts[hit.Type]++
+		}
+		if inType(hit) {
+			dateCounts[hit.PublishTime.Format("2006-01")]++
+		}
+		if inType(hit) && inDate(hit) {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].PublishTime.After(hits[j].PublishTime)
+	})
+
+	result := &dto.SearchResult{
+		Total:      int64(len(hits)),
+		TypeFacets: toFacets(typeCounts),
+		DateFacets: toFacets(dateCounts),
+	}
+	sort.Slice(result.TypeFacets, func(i, j int) bool {
+		if result.TypeFacets[i].Count != result.TypeFacets[j].Count {
+			return result.TypeFacets[i].Count > result.TypeFacets[j].Count
+		}
+		return result.TypeFacets[i].Key < result.TypeFacets[j].Key
+	})
+	sort.Slice(result.DateFacets, func(i, j int) bool {
+		return result.DateFacets[i].Key > result.DateFacets[j].Key
+	})
+
+	// 分页
+	start := min(q.Offset, len(hits))
+	end := len(hits)
+	if q.Limit > 0 {
+		end = min(start+q.Limit, len(hits))
+	}
+	result.Hits = hits[start:end]
+
+	return result, nil
+}
+
+// toFacets 将统计结果转为分面列表
+func toFacets(counts map[string]int64) []dto.FacetCount {
+	facets := make([]dto.FacetCount, 0, len(counts))
+	for key, count := range counts {
+		facets = append(facets, dto.FacetCount{Key: key, Count: count})
+	}
+	return facets
+}
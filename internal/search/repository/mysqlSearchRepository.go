@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/search/dto"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// articleStatusPublished 已发布文章的状态值，与 articles 表 status 列的取值保持一致
+const articleStatusPublished = "PUBLISHED"
+
+// MySQLSearchRepository 基于 MySQL FULLTEXT 索引的搜索实现，只查询各表的列，不依赖其他模块的模型定义
+// 依赖以下使用 ngram 解析器的全文索引（见 migrations 目录中的建索引脚本）：
+//   - articles(article_title, brief_content, content_text)
+//   - events(title, detail_text)
+//   - notices(title, content)
+type MySQLSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLSearchRepository 创建数据访问实例
+func NewMySQLSearchRepository(db *gorm.DB) SearchRepository {
+	return &MySQLSearchRepository{db: db}
+}
+
+// documents 将三类数据统一为 doc_type、doc_id、title、content、publish_time、score 列并合并
+func (repo *MySQLSearchRepository) documents(ctx context.Context, keyword string) *gorm.DB {
+	db := repo.db.WithContext(ctx)
+	now := time.Now()
+
+	articles := db.Table("articles").
+		Select("? AS doc_type, article_id AS doc_id, article_title AS title, CONCAT_WS(' ', brief_content, content_text) AS content, release_time AS publish_time, "+
+			"MATCH(article_title, brief_content, content_text) AGAINST (? IN NATURAL LANGUAGE MODE) AS score", utils.TypeArticle, keyword).
+		Where("is_deleted = ? AND status = ? AND release_time <= ?", utils.DeletedFlagNo, articleStatusPublished, now).
+		Where("MATCH(article_title, brief_content, content_text) AGAINST (? IN NATURAL LANGUAGE MODE)", keyword)
+
+	events := db.Table("events").
+		Select("? AS doc_type, id AS doc_id, title, detail_text AS content, create_time AS publish_time, "+
+			"MATCH(title, detail_text) AGAINST (? IN NATURAL LANGUAGE MODE) AS score", utils.TypeEvent, keyword).
+		Where("is_deleted = ?", utils.DeletedFlagNo).
+		Where("MATCH(title, detail_text) AGAINST (? IN NATURAL LANGUAGE MODE)", keyword)
+
+	notices := db.Table("notices").
+		Select("? AS doc_type, id AS doc_id, title, content, release_time AS publish_time, "+
+			"MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS score", utils.TypeNotice, keyword).
+		Where("is_deleted = ? AND release_time <= ?", utils.DeletedFlagNo, now).
+		Where("MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE)", keyword)
+
+	return db.Table("((?) UNION ALL (?) UNION ALL (?)) AS d", articles, events, notices)
+}
+
+// withType 添加文档类型筛选
+func withType(query *gorm.DB, q dto.SearchQuery) *gorm.DB {
+	if q.Type != "" {
+		query = query.Where("d.doc_type = ?", q.Type)
+	}
+	return query
+}
+
+// withDate 添加发布时间筛选
+func withDate(query *gorm.DB, q dto.SearchQuery) *gorm.DB {
+	if q.StartTime != nil {
+		query = query.Where("d.publish_time >= ?", *q.StartTime)
+	}
+	if q.EndTime != nil {
+		query = query.Where("d.publish_time < ?", *q.EndTime)
+	}
+	return query
+}
+
+// Search 按关键词检索，结果按相关度降序、发布时间降序排列
+func (repo *MySQLSearchRepository) Search(ctx context.Context, q dto.SearchQuery) (*dto.SearchResult, error) {
+	result := &dto.SearchResult{}
+
+	// 计算总数
+	if err := withDate(withType(repo.documents(ctx, q.Keyword), q), q).
+		Count(&result.Total).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("计算总数时数据库查询失败: %v", err))
+	}
+
+	// 查询当前页数据
+	if err := withDate(withType(repo.documents(ctx, q.Keyword), q), q).
+		Select("d.*").
+		Order("d.score DESC, d.publish_time DESC").
+		Offset(q.Offset).Limit(q.Limit).
+		Scan(&result.Hits).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("数据库查询失败: %v", err))
+	}
+
+	// 按类型统计，仅应用日期筛选
+	if err := withDate(repo.documents(ctx, q.Keyword), q).
+		Select("d.doc_type AS facet_key, COUNT(*) AS facet_count").
+		Group("d.doc_type").
+		Order("facet_count DESC").
+		Scan(&result.TypeFacets).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("统计类型分面时数据库查询失败: %v", err))
+	}
+
+	// 按发布月份统计，仅应用类型筛选
+	if err := withType(repo.documents(ctx, q.Keyword), q).
+		Select("DATE_FORMAT(d.publish_time, '%Y-%m') AS facet_key, COUNT(*) AS facet_count").
+		Group("facet_key").
+		Order("facet_key DESC").
+		Scan(&result.DateFacets).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("统计日期分面时数据库查询失败: %v", err))
+	}
+
+	return result, nil
+}
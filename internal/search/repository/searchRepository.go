@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"news-release/internal/search/dto"
+	"strings"
+	"unicode"
+)
+
+// SearchRepository 全文搜索数据访问接口
+// 搜索实现可替换：生产环境使用 MySQL ngram 全文索引，测试或本地调试可使用内存实现
+type SearchRepository interface {
+	// Search 按关键词检索文章、活动和公告，返回当前页命中结果、命中总数及分面统计
+	Search(ctx context.Context, query dto.SearchQuery) (*dto.SearchResult, error)
+}
+
+// ngramTokenSize 中文分词粒度，与 MySQL ngram_token_size 默认值保持一致
+const ngramTokenSize = 2
+
+// Tokenize 将文本切分为检索词
+// 英文、数字按非字母数字字符切分为单词；连续的中文按 ngram 切分为二元词组，单个汉字保留原样
+// 返回的检索词已转为小写并去重，顺序与出现顺序一致
+func Tokenize(text string) []string {
+	tokens := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		// 将单词拆分为中文片段与非中文片段分别处理
+		var run []rune
+		isHanRun := false
+		flush := func() {
+			if len(run) == 0 {
+				return
+			}
+			if isHanRun && len(run) > ngramTokenSize {
+				for i := 0; i+ngramTokenSize <= len(run); i++ {
+					add(string(run[i : i+ngramTokenSize]))
+				}
+			} else {
+				add(string(run))
+			}
+			run = run[:0]
+		}
+		for _, r := range word {
+			isHan := unicode.Is(unicode.Han, r)
+			if len(run) > 0 && isHan != isHanRun {
+				flush()
+			}
+			isHanRun = isHan
+			run = append(run, r)
+		}
+		flush()
+	}
+
+	return tokens
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"html"
+	"news-release/internal/utils"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetLength  = 120 // 摘要片段的最大字数
+	snippetLeading = 30  // 命中位置之前保留的字数
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+)
+
+// matchMask 标记文本中命中任一检索词的字符位置（忽略大小写）
+func matchMask(runes []rune, tokens []string) []bool {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	mask := make([]bool, len(runes))
+	for _, token := range tokens {
+		t := []rune(token)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == token {
+				for j := i; j < i+len(t); j++ {
+					mask[j] = true
+				}
+			}
+		}
+	}
+	return mask
+}
+
+// highlight 对文本做HTML转义，并用 <em> 标签包裹命中检索词的片段
+// 相邻的命中片段会合并，例如中文二元词组“人工”“工智”“智能”会合并为一个“人工智能”
+func highlight(runes []rune, mask []bool) string {
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && mask[j] == mask[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if mask[i] {
+			b.WriteString(highlightOpen + segment + highlightClose)
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// highlightTitle 高亮标题中的检索词
+func highlightTitle(title string, tokens []string) string {
+	runes := []rune(title)
+	return highlight(runes, matchMask(runes, tokens))
+}
+
+// buildSnippet 从正文中截取包含首个命中位置的片段并高亮检索词
+// 正文未命中时返回开头部分
+func buildSnippet(content string, tokens []string) string {
+	runes := []rune(utils.PlainText(content))
+	mask := matchMask(runes, tokens)
+
+	start := 0
+	for i, hit := range mask {
+		if hit {
+			start = max(i-snippetLeading, 0)
+			break
+		}
+	}
+	end := min(start+snippetLength, len(runes))
+
+	snippet := highlight(runes[start:end], mask[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/search/dto"
+	"news-release/internal/search/repository"
+	"news-release/internal/utils"
+	"time"
+)
+
+// typeLabels 文档类型显示名称
+var typeLabels = map[string]string{
+	utils.TypeArticle: "文章",
+	utils.TypeEvent:   "活动",
+	utils.TypeNotice:  "公告",
+}
+
+// SearchService 全文搜索服务接口
+type SearchService interface {
+	// Search 按关键词检索文章、活动和公告
+	Search(ctx context.Context, page, pageSize int, req dto.SearchRequest) ([]dto.SearchItemResponse, int64, *dto.SearchFacetsResponse, error)
+}
+
+// SearchServiceImpl 实现接口的具体结构体
+type SearchServiceImpl struct {
+	searchRepo repository.SearchRepository
+}
+
+// NewSearchService 创建服务实例
+func NewSearchService(searchRepo repository.SearchRepository) SearchService {
+	return &SearchServiceImpl{searchRepo: searchRepo}
+}
+
+// Search 按关键词检索，返回高亮后的结果及类型、日期分面统计
+func (svc *SearchServiceImpl) Search(ctx context.Context, page, pageSize int, req dto.SearchRequest) ([]dto.SearchItemResponse, int64, *dto.SearchFacetsResponse, error) {
+	tokens := repository.Tokenize(req.Keyword)
+	if len(tokens) == 0 {
+		return nil, 0, nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "搜索关键词不能只包含标点符号")
+	}
+
+	query := dto.SearchQuery{
+		Keyword: req.Keyword,
+		Type:    req.Type,
+		Offset:  (page - 1) * pageSize,
+		Limit:   pageSize,
+	}
+	if req.StartDate != "" {
+		startTime, err := utils.StringToTime(req.StartDate)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		query.StartTime = &startTime
+	}
+	if req.EndDate != "" {
+		endTime, err := utils.StringToTime(req.EndDate)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		// 截止日期包含当天，截止时间包含该秒
+		if len(req.EndDate) == len(time.DateOnly) {
+			endTime = endTime.AddDate(0, 0, 1)
+		} else {
+			endTime = endTime.Add(time.Second)
+		}
+		query.EndTime = &endTime
+	}
+	if query.StartTime != nil && query.EndTime != nil && !query.StartTime.Before(*query.EndTime) {
+		return nil, 0, nil, utils.NewBusinessError(utils.ErrCodeParamInvalid, "开始日期不能晚于截止日期")
+	}
+
+	result, err := svc.searchRepo.Search(ctx, query)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	items := make([]dto.SearchItemResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		items = append(items, dto.SearchItemResponse{
+			Type:        hit.Type,
+			ID:          hit.ID,
+			Title:       highlightTitle(hit.Title, tokens),
+			Snippet:     buildSnippet(hit.Content, tokens),
+			PublishTime: hit.PublishTime,
+			Score:       hit.Score,
+		})
+	}
+
+	facets := &dto.SearchFacetsResponse{
+		Type: make([]dto.FacetCount, 0, len(result.TypeFacets)),
+		Date: make([]dto.FacetCount, 0, len(result.DateFacets)),
+	}
+	for _, f := range result.TypeFacets {
+		f.Label = typeLabels[f.Key]
+		facets.Type = append(facets.Type, f)
+	}
+	for _, f := range result.DateFacets {
+		if month, err := time.Parse("2006-01", f.Key); err == nil {
+			f.Label = month.Format("2006年01月")
+		}
+		facets.Date = append(facets.Date, f)
+	}
+
+	return items, result.Total, facets, nil
+}
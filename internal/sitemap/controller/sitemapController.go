@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"news-release/internal/sitemap/service"
+	"news-release/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sitemapCacheMaxAge 站点地图允许客户端缓存的时间（秒）
+const sitemapCacheMaxAge = 3600
+
+// SitemapController 站点地图控制器
+type SitemapController struct {
+	sitemapService service.SitemapService
+}
+
+// NewSitemapController 创建控制器实例
+func NewSitemapController(sitemapService service.SitemapService) *SitemapController {
+	return &SitemapController{sitemapService: sitemapService}
+}
+
+// GetSitemapIndex 输出站点地图索引
+func (ctr *SitemapController) GetSitemapIndex(ctx *gin.Context) {
+	index, err := ctr.sitemapService.GetSitemapIndex(ctx, utils.RequestSiteURL(ctx))
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	writeXML(ctx, index)
+}
+
+// GetSitemap 输出分页的站点地图文件
+func (ctr *SitemapController) GetSitemap(ctx *gin.Context) {
+	urlSet, err := ctr.sitemapService.GetSitemap(ctx, ctx.Param("name"), utils.RequestSiteURL(ctx))
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+	writeXML(ctx, urlSet)
+}
+
+// writeXML 序列化XML文档并添加XML声明
+func writeXML(ctx *gin.Context, doc interface{}) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		utils.WrapErrorHandler(ctx, utils.NewSystemError(fmt.Errorf("生成站点地图失败: %w", err)))
+		return
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", sitemapCacheMaxAge))
+	ctx.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
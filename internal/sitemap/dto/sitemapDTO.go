@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// sitemapNamespace 站点地图协议命名空间
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapEntry 站点地图条目查询结果
+type SitemapEntry struct {
+	ID      int       `gorm:"column:id"`
+	Slug    string    `gorm:"column:slug"`
+	LastMod time.Time `gorm:"column:last_mod"`
+}
+
+// SitemapStat 各类型数据的条目数和最后更新时间
+type SitemapStat struct {
+	Total   int64      `gorm:"column:total"`
+	LastMod *time.Time `gorm:"column:last_mod"`
+}
+
+// SitemapIndex 站点地图索引文件
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapRef `xml:"sitemap"`
+}
+
+// SitemapRef 站点地图索引中的子文件
+type SitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URLSet 站点地图文件
+type URLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapURL 站点地图中的链接
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// NewSitemapIndex 创建站点地图索引
+func NewSitemapIndex(sitemaps []SitemapRef) *SitemapIndex {
+	return &SitemapIndex{Xmlns: sitemapNamespace, Sitemaps: sitemaps}
+}
+
+// NewURLSet 创建站点地图文件
+func NewURLSet(urls []SitemapURL) *URLSet {
+	return &URLSet{Xmlns: sitemapNamespace, URLs: urls}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/sitemap/dto"
+	"news-release/internal/utils"
+	"time"
+
+	articlemodel "news-release/internal/article/model"
+
+	"gorm.io/gorm"
+)
+
+// SitemapRepository 站点地图数据访问接口
+type SitemapRepository interface {
+	// GetStat 统计指定类型可公开访问的条目数和最后更新时间
+	GetStat(ctx context.Context, docType string) (*dto.SitemapStat, error)
+	// ListEntries 按ID升序分页查询指定类型可公开访问的条目
+	ListEntries(ctx context.Context, docType string, offset, limit int) ([]dto.SitemapEntry, error)
+}
+
+// SitemapRepositoryImpl 实现接口的具体结构体
+type SitemapRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSitemapRepository 创建数据访问实例
+func NewSitemapRepository(db *gorm.DB) SitemapRepository {
+	return &SitemapRepositoryImpl{db: db}
+}
+
+// baseQuery 构建各类型的基础查询，lastMod 为最后更新时间的计算表达式
+// 文章仅包含已发布且到达发布时间的，活动包含所有未删除的
+func (repo *SitemapRepositoryImpl) baseQuery(ctx context.Context, docType string) (*gorm.DB, string, error) {
+	switch docType {
+	case utils.TypeArticle:
+		query := repo.db.WithContext(ctx).Table("articles").
+			Where("is_deleted = ? AND status = ? AND release_time <= ?", utils.DeletedFlagNo, articlemodel.ArticleStatusPublished, time.Now())
+		return query, "GREATEST(release_time, update_time)", nil
+	case utils.TypeEvent:
+		query := repo.db.WithContext(ctx).Table("events").
+			Where("is_deleted = ?", utils.DeletedFlagNo)
+		return query, "update_time", nil
+	default:
+		return nil, "", utils.NewBusinessError(utils.ErrCodeParamInvalid, "不支持的站点地图类型")
+	}
+}
+
+// GetStat 统计指定类型可公开访问的条目数和最后更新时间
+func (repo *SitemapRepositoryImpl) GetStat(ctx context.Context, docType string) (*dto.SitemapStat, error) {
+	query, lastMod, err := repo.baseQuery(ctx, docType)
+	if err != nil {
+		return nil, err
+	}
+
+	var stat dto.SitemapStat
+	if err := query.Select("COUNT(*) AS total, MAX(" + lastMod + ") AS last_mod").Scan(&stat).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("统计站点地图条目失败: %v", err))
+	}
+	return &stat, nil
+}
+
+// ListEntries 按ID升序分页查询指定类型可公开访问的条目，保证分页稳定
+func (repo *SitemapRepositoryImpl) ListEntries(ctx context.Context, docType string, offset, limit int) ([]dto.SitemapEntry, error) {
+	query, lastMod, err := repo.baseQuery(ctx, docType)
+	if err != nil {
+		return nil, err
+	}
+
+	idColumn := "id"
+	if docType == utils.TypeArticle {
+		idColumn = "article_id"
+	}
+
+	var entries []dto.SitemapEntry
+	if err := query.Select(idColumn + " AS id, slug, " + lastMod + " AS last_mod").
+		Order(idColumn + " ASC").
+		Offset(offset).Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询站点地图条目失败: %v", err))
+	}
+	return entries, nil
+}
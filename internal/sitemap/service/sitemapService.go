@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"news-release/internal/config"
+	"news-release/internal/sitemap/dto"
+	"news-release/internal/sitemap/repository"
+	"news-release/internal/utils"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultSitemapPageSize = 10000 // 默认每个站点地图文件包含的链接数
+	maxSitemapPageSize     = 50000 // 协议规定每个站点地图文件最多包含的链接数
+)
+
+// sitemapSection 站点地图分组，每组按页拆分为多个文件
+type sitemapSection struct {
+	docType string // 数据类型
+	name    string // 文件名前缀
+}
+
+// sitemapSections 站点地图包含的分组，按索引中的顺序排列
+var sitemapSections = []sitemapSection{
+	{docType: utils.TypeArticle, name: "articles"},
+	{docType: utils.TypeEvent, name: "events"},
+}
+
+// sitemapNamePattern 站点地图文件名格式，如 articles-1.xml
+var sitemapNamePattern = regexp.MustCompile(`^([a-z]+)-([1-9][0-9]*)\.xml$`)
+
+// SitemapService 站点地图服务接口
+type SitemapService interface {
+	// GetSitemapIndex 生成站点地图索引，列出各分组的分页文件
+	GetSitemapIndex(ctx context.Context, siteURL string) (*dto.SitemapIndex, error)
+	// GetSitemap 生成指定文件名的站点地图，文件名格式为 分组-页码.xml
+	GetSitemap(ctx context.Context, name string, siteURL string) (*dto.URLSet, error)
+}
+
+// SitemapServiceImpl 实现接口的具体结构体
+type SitemapServiceImpl struct {
+	sitemapRepo repository.SitemapRepository
+	siteCfg     config.SiteConfig
+	pageSize    int
+}
+
+// NewSitemapService 创建服务实例
+func NewSitemapService(sitemapRepo repository.SitemapRepository, cfg *config.Config) SitemapService {
+	pageSize := cfg.Site.SitemapPageSize
+	if pageSize <= 0 {
+		pageSize = defaultSitemapPageSize
+	}
+	pageSize = min(pageSize, maxSitemapPageSize)
+	return &SitemapServiceImpl{sitemapRepo: sitemapRepo, siteCfg: cfg.Site, pageSize: pageSize}
+}
+
+// GetSitemapIndex 生成站点地图索引
+func (svc *SitemapServiceImpl) GetSitemapIndex(ctx context.Context, siteURL string) (*dto.SitemapIndex, error) {
+	baseURL := svc.siteCfg.BaseURL(siteURL)
+
+	refs := make([]dto.SitemapRef, 0)
+	for _, section := range sitemapSections {
+		stat, err := svc.sitemapRepo.GetStat(ctx, section.docType)
+		if err != nil {
+			return nil, err
+		}
+
+		lastMod := ""
+		if stat.LastMod != nil {
+			lastMod = stat.LastMod.Format(time.RFC3339)
+		}
+		pages := int((stat.Total + int64(svc.pageSize) - 1) / int64(svc.pageSize))
+		for page := 1; page <= pages; page++ {
+			refs = append(refs, dto.SitemapRef{
+				Loc:     fmt.Sprintf("%s/sitemaps/%s-%d.xml", baseURL, section.name, page),
+				LastMod: lastMod,
+			})
+		}
+	}
+
+	return dto.NewSitemapIndex(refs), nil
+}
+
+// GetSitemap 生成指定文件名的站点地图
+func (svc *SitemapServiceImpl) GetSitemap(ctx context.Context, name string, siteURL string) (*dto.URLSet, error) {
+	notFound := utils.NewBusinessError(utils.ErrCodeResourceNotFound, "站点地图不存在")
+
+	matches := sitemapNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return nil, notFound
+	}
+	var section *sitemapSection
+	for i := range sitemapSections {
+		if sitemapSections[i].name == matches[1] {
+			section = &sitemapSections[i]
+			break
+		}
+	}
+	page, err := strconv.Atoi(matches[2])
+	if section == nil || err != nil {
+		return nil, notFound
+	}
+
+	entries, err := svc.sitemapRepo.ListEntries(ctx, section.docType, (page-1)*svc.pageSize, svc.pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 && page > 1 {
+		return nil, notFound
+	}
+
+	baseURL := svc.siteCfg.BaseURL(siteURL)
+	urls := make([]dto.SitemapURL, 0, len(entries))
+	for _, entry := range entries {
+		loc := svc.siteCfg.ArticleLink(baseURL, entry.ID, entry.Slug)
+		if section.docType == utils.TypeEvent {
+			loc = svc.siteCfg.EventLink(baseURL, entry.ID, entry.Slug)
+		}
+		urls = append(urls, dto.SitemapURL{Loc: loc, LastMod: entry.LastMod.Format(time.RFC3339)})
+	}
+
+	return dto.NewURLSet(urls), nil
+}
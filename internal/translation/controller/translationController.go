@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+	"news-release/internal/translation/dto"
+	"news-release/internal/translation/service"
+	"news-release/internal/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TranslationController 控制器
+type TranslationController struct {
+	translationService service.TranslationService
+}
+
+// NewTranslationController 创建控制器实例
+func NewTranslationController(translationService service.TranslationService) *TranslationController {
+	return &TranslationController{translationService: translationService}
+}
+
+// ListTranslations 查询内容的全部译文（管理端）
+func (ctr *TranslationController) ListTranslations(ctx *gin.Context) {
+	var req dto.TranslationBizRequest
+	if !utils.BindUrl(ctx, &req) {
+		return
+	}
+
+	results, err := ctr.translationService.ListTranslations(ctx, bizType(req.BizType), req.BizID)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"default_lang": ctr.translationService.DefaultLang(),
+		"data":         results,
+	})
+}
+
+// SaveTranslation 新增或更新译文（管理端）
+func (ctr *TranslationController) SaveTranslation(ctx *gin.Context) {
+	var urlReq dto.TranslationUrlRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	var req dto.SaveTranslationRequest
+	if !utils.BindJSON(ctx, &req) {
+		return
+	}
+
+	userID, err := utils.GetUserID(ctx)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	if err := ctr.translationService.SaveTranslation(ctx, bizType(urlReq.BizType), urlReq.BizID, urlReq.Lang, req, userID); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "译文保存成功"})
+}
+
+// DeleteTranslation 删除译文（管理端）
+func (ctr *TranslationController) DeleteTranslation(ctx *gin.Context) {
+	var urlReq dto.TranslationUrlRequest
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	if err := ctr.translationService.DeleteTranslation(ctx, bizType(urlReq.BizType), urlReq.BizID, urlReq.Lang); err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "译文删除成功"})
+}
+
+// ListMissing 分页查询缺少指定语言译文的内容（管理端）
+func (ctr *TranslationController) ListMissing(ctx *gin.Context) {
+	var req dto.MissingTranslationRequest
+	if !utils.BindQuery(ctx, &req) {
+		return
+	}
+
+	// page 默认1
+	page := req.Page
+	if page == 0 {
+		page = 1
+	}
+
+	// pageSize 默认10
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	results, total, err := ctr.translationService.ListMissing(ctx, bizType(req.BizType), req.Lang, page, pageSize)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"data":      results,
+	})
+}
+
+// bizType 将URL中的小写内容类型转换为业务类型常量，如 article 转换为 ARTICLE
+func bizType(urlType string) string {
+	return strings.ToUpper(urlType)
+}
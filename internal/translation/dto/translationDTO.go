@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// TranslationBizRequest 译文所属内容的URL参数
+type TranslationBizRequest struct {
+	BizType string `uri:"biz_type" binding:"required,oneof=article event notice"` // 内容类型
+	BizID   int    `uri:"biz_id" binding:"required,min=1"`                        // 内容ID
+}
+
+// TranslationUrlRequest 单条译文的URL参数
+type TranslationUrlRequest struct {
+	BizType string `uri:"biz_type" binding:"required,oneof=article event notice"` // 内容类型
+	BizID   int    `uri:"biz_id" binding:"required,min=1"`                        // 内容ID
+	Lang    string `uri:"lang" binding:"required,max=10"`                         // 语言，不能为默认语言
+}
+
+// SaveTranslationRequest 新增或更新译文请求参数
+type SaveTranslationRequest struct {
+	Title   string `json:"title" binding:"required,non_empty_string,max=255"` // 标题
+	Brief   string `json:"brief"`                                             // 摘要，仅文章使用，为空时根据正文自动生成
+	Content string `json:"content" binding:"required"`                        // 文章正文、活动详情或公告内容
+}
+
+// MissingTranslationRequest 缺少译文的内容列表查询参数
+type MissingTranslationRequest struct {
+	BizType  string `form:"biz_type" binding:"required,oneof=article event notice"` // 内容类型
+	Lang     string `form:"lang" binding:"required,max=10"`                         // 语言，不能为默认语言
+	Page     int    `form:"page" binding:"omitempty,min=1"`                         // 页码，最小为1
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`            // 页大小，1-100
+}
+
+// TranslationResponse 译文响应结构体
+type TranslationResponse struct {
+	Lang       string    `json:"lang"`
+	Title      string    `json:"title"`
+	Brief      string    `json:"brief"`
+	Content    string    `json:"content"`
+	Outdated   bool      `json:"outdated"` // 原文在译文之后修改过，译文可能需要同步
+	UpdateTime time.Time `json:"update_time"`
+	UpdateUser int       `json:"update_user"`
+}
+
+// MissingTranslationResponse 缺少译文的内容
+type MissingTranslationResponse struct {
+	BizID      int        `json:"biz_id"`
+	Title      string     `json:"title"`       // 原文标题
+	UpdateTime *time.Time `json:"update_time"` // 原文最后更新时间
+}
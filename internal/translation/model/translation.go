@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// Translation 文章、活动、公告的译文数据模型，原文为默认语言，每种其他语言最多一条译文
+// 文章使用标题、摘要、正文，活动使用标题、详情（存于 Content），公告使用标题、内容
+type Translation struct {
+	ID         int       `json:"id" gorm:"primaryKey;column:id"`
+	BizType    string    `json:"biz_type" gorm:"type:varchar(20);not null;column:biz_type;uniqueIndex:uk_translation,priority:1"` // 业务类型：ARTICLE、EVENT、NOTICE
+	BizID      int       `json:"biz_id" gorm:"not null;column:biz_id;uniqueIndex:uk_translation,priority:2"`                      // 业务ID
+	Lang       string    `json:"lang" gorm:"type:varchar(10);not null;column:lang;uniqueIndex:uk_translation,priority:3"`         // 语言
+	Title      string    `json:"title" gorm:"type:varchar(255);not null;column:title"`                                            // 标题
+	Brief      string    `json:"brief" gorm:"type:text;column:brief"`                                                             // 摘要，仅文章使用
+	Content    string    `json:"content" gorm:"type:mediumtext;column:content"`                                                   // 文章正文、活动详情或公告内容
+	CreateTime time.Time `json:"create_time" gorm:"column:create_time;autoCreateTime"`
+	UpdateTime time.Time `json:"update_time" gorm:"column:update_time;autoUpdateTime"`
+	CreateUser int       `json:"create_user" gorm:"column:create_user"` // 创建人ID
+	UpdateUser int       `json:"update_user" gorm:"column:update_user"` // 最后更新人ID
+}
+
+// TableName 设置表名
+func (*Translation) TableName() string {
+	return "translations"
+}
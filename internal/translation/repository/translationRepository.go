@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"news-release/internal/translation/dto"
+	"news-release/internal/translation/model"
+	"news-release/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// translationSource 译文对应的原文表
+type translationSource struct {
+	table       string // 原文表名
+	idColumn    string // 主键列
+	titleColumn string // 标题列
+}
+
+// translationSources 各业务类型的原文表
+var translationSources = map[string]translationSource{
+	utils.TypeArticle: {table: "articles", idColumn: "article_id", titleColumn: "article_title"},
+	utils.TypeEvent:   {table: "events", idColumn: "id", titleColumn: "title"},
+	utils.TypeNotice:  {table: "notices", idColumn: "id", titleColumn: "title"},
+}
+
+// SourceInfo 原文的基本信息
+type SourceInfo struct {
+	Title      string     `gorm:"column:title"`
+	UpdateTime *time.Time `gorm:"column:update_time"`
+}
+
+// TranslationRepository 数据访问接口，定义数据访问的方法集
+type TranslationRepository interface {
+	// GetSource 查询未删除的原文，不存在时返回nil
+	GetSource(ctx context.Context, bizType string, bizID int) (*SourceInfo, error)
+	// ListByBiz 查询内容的全部译文
+	ListByBiz(ctx context.Context, bizType string, bizID int) ([]*model.Translation, error)
+	// ListByBizIDs 批量查询多个内容在指定语言下的译文
+	ListByBizIDs(ctx context.Context, bizType string, lang string, bizIDs []int) ([]*model.Translation, error)
+	// Save 新增或更新译文
+	Save(ctx context.Context, translation *model.Translation) error
+	// Delete 删除译文
+	Delete(ctx context.Context, bizType string, bizID int, lang string) (bool, error)
+	// ListMissing 分页查询缺少指定语言译文的内容
+	ListMissing(ctx context.Context, bizType string, lang string, page, pageSize int) ([]dto.MissingTranslationResponse, int64, error)
+}
+
+// TranslationRepositoryImpl 实现接口的具体结构体
+type TranslationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTranslationRepository 创建数据访问实例
+func NewTranslationRepository(db *gorm.DB) TranslationRepository {
+	return &TranslationRepositoryImpl{db: db}
+}
+
+// GetSource 查询未删除的原文，不存在时返回nil
+func (repo *TranslationRepositoryImpl) GetSource(ctx context.Context, bizType string, bizID int) (*SourceInfo, error) {
+	source := translationSources[bizType]
+
+	var info SourceInfo
+	err := repo.db.WithContext(ctx).Table(source.table).
+		Select(source.titleColumn+" AS title, update_time").
+		Where(source.idColumn+" = ? AND is_deleted = ?", bizID, utils.DeletedFlagNo).
+		Take(&info).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询原文失败: %w", err))
+	}
+	return &info, nil
+}
+
+// ListByBiz 查询内容的全部译文，按语言排列
+func (repo *TranslationRepositoryImpl) ListByBiz(ctx context.Context, bizType string, bizID int) ([]*model.Translation, error) {
+	var translations []*model.Translation
+	if err := repo.db.WithContext(ctx).
+		Where("biz_type = ? AND biz_id = ?", bizType, bizID).
+		Order("lang ASC").
+		Find(&translations).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询译文失败: %w", err))
+	}
+	return translations, nil
+}
+
+// ListByBizIDs 批量查询多个内容在指定语言下的译文
+func (repo *TranslationRepositoryImpl) ListByBizIDs(ctx context.Context, bizType string, lang string, bizIDs []int) ([]*model.Translation, error) {
+	translations := make([]*model.Translation, 0)
+	if len(bizIDs) == 0 {
+		return translations, nil
+	}
+	if err := repo.db.WithContext(ctx).
+		Where("biz_type = ? AND lang = ? AND biz_id IN ?", bizType, lang, bizIDs).
+		Find(&translations).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询译文失败: %w", err))
+	}
+	return translations, nil
+}
+
+// Save 新增或更新译文，同一内容同一语言已有译文时覆盖标题、摘要和内容
+func (repo *TranslationRepositoryImpl) Save(ctx context.Context, translation *model.Translation) error {
+	if err := repo.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "brief", "content", "update_time", "update_user"}),
+	}).Create(translation).Error; err != nil {
+		return utils.NewSystemError(fmt.Errorf("保存译文失败: %w", err))
+	}
+	return nil
+}
+
+// Delete 删除译文，返回是否存在被删除的译文
+func (repo *TranslationRepositoryImpl) Delete(ctx context.Context, bizType string, bizID int, lang string) (bool, error) {
+	result := repo.db.WithContext(ctx).
+		Where("biz_type = ? AND biz_id = ? AND lang = ?", bizType, bizID, lang).
+		Delete(&model.Translation{})
+	if result.Error != nil {
+		return false, utils.NewSystemError(fmt.Errorf("删除译文失败: %w", result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListMissing 分页查询缺少指定语言译文的未删除内容，按原文更新时间倒序排列
+func (repo *TranslationRepositoryImpl) ListMissing(ctx context.Context, bizType string, lang string, page, pageSize int) ([]dto.MissingTranslationResponse, int64, error) {
+	source := translationSources[bizType]
+
+	query := repo.db.WithContext(ctx).Table(source.table+" s").
+		Where("s.is_deleted = ?", utils.DeletedFlagNo).
+		Where("NOT EXISTS (SELECT 1 FROM translations t WHERE t.biz_type = ? AND t.biz_id = s."+source.idColumn+" AND t.lang = ?)", bizType, lang)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("统计缺少译文的内容失败: %w", err))
+	}
+
+	results := make([]dto.MissingTranslationResponse, 0)
+	if err := query.
+		Select("s." + source.idColumn + " AS biz_id, s." + source.titleColumn + " AS title, s.update_time").
+		Order("s.update_time DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&results).Error; err != nil {
+		return nil, 0, utils.NewSystemError(fmt.Errorf("查询缺少译文的内容失败: %w", err))
+	}
+	return results, total, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"news-release/internal/config"
+	"news-release/internal/content"
+	"news-release/internal/translation/dto"
+	"news-release/internal/translation/model"
+	"news-release/internal/translation/repository"
+	"news-release/internal/utils"
+	"strings"
+)
+
+// briefMaxLength 根据译文正文自动生成摘要的最大字数，与文章摘要一致
+const briefMaxLength = 150
+
+// TranslationService 服务接口，定义方法，接收 context.Context 和数据模型。
+type TranslationService interface {
+	// DefaultLang 返回默认语言，即原文语言
+	DefaultLang() string
+	// Localize 批量查询内容在请求语言下的译文，请求语言为默认语言时返回空结果
+	Localize(ctx context.Context, bizType string, lang string, bizIDs []int) (map[int]*model.Translation, error)
+	// ListTranslations 查询内容的全部译文
+	ListTranslations(ctx context.Context, bizType string, bizID int) ([]dto.TranslationResponse, error)
+	// SaveTranslation 新增或更新译文
+	SaveTranslation(ctx context.Context, bizType string, bizID int, lang string, req dto.SaveTranslationRequest, userID int) error
+	// DeleteTranslation 删除译文
+	DeleteTranslation(ctx context.Context, bizType string, bizID int, lang string) error
+	// ListMissing 分页查询缺少指定语言译文的内容
+	ListMissing(ctx context.Context, bizType string, lang string, page, pageSize int) ([]dto.MissingTranslationResponse, int64, error)
+}
+
+// TranslationServiceImpl 实现接口的具体结构体，持有数据访问层接口 Repository 的实例
+type TranslationServiceImpl struct {
+	translationRepo repository.TranslationRepository
+	processor       *content.Processor
+	defaultLang     string
+}
+
+// NewTranslationService 创建服务实例
+func NewTranslationService(translationRepo repository.TranslationRepository, processor *content.Processor, cfg *config.Config) TranslationService {
+	return &TranslationServiceImpl{
+		translationRepo: translationRepo,
+		processor:       processor,
+		defaultLang:     cfg.I18n.Default(),
+	}
+}
+
+// DefaultLang 返回默认语言，即原文语言
+func (svc *TranslationServiceImpl) DefaultLang() string {
+	return svc.defaultLang
+}
+
+// Localize 批量查询内容在请求语言下的译文，以内容ID为键，缺少译文的内容不在结果中，调用方回退到原文
+func (svc *TranslationServiceImpl) Localize(ctx context.Context, bizType string, lang string, bizIDs []int) (map[int]*model.Translation, error) {
+	result := make(map[int]*model.Translation)
+	if lang == "" || lang == svc.defaultLang || len(bizIDs) == 0 {
+		return result, nil
+	}
+
+	translations, err := svc.translationRepo.ListByBizIDs(ctx, bizType, lang, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, translation := range translations {
+		result[translation.BizID] = translation
+	}
+	return result, nil
+}
+
+// ListTranslations 查询内容的全部译文，并标记原文在译文之后修改过的译文
+func (svc *TranslationServiceImpl) ListTranslations(ctx context.Context, bizType string, bizID int) ([]dto.TranslationResponse, error) {
+	source, err := svc.getSource(ctx, bizType, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := svc.translationRepo.ListByBiz(ctx, bizType, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		results = append(results, dto.TranslationResponse{
+			Lang:       translation.Lang,
+			Title:      translation.Title,
+			Brief:      translation.Brief,
+			Content:    translation.Content,
+			Outdated:   source.UpdateTime != nil && source.UpdateTime.After(translation.UpdateTime),
+			UpdateTime: translation.UpdateTime,
+			UpdateUser: translation.UpdateUser,
+		})
+	}
+	return results, nil
+}
+
+// SaveTranslation 新增或更新译文
+// 文章正文、活动详情和公告内容均清洗HTML，过滤不安全内容，文章摘要为空时根据正文生成，活动和公告不使用摘要
+func (svc *TranslationServiceImpl) SaveTranslation(ctx context.Context, bizType string, bizID int, lang string, req dto.SaveTranslationRequest, userID int) error {
+	if err := svc.checkLang(lang); err != nil {
+		return err
+	}
+	if _, err := svc.getSource(ctx, bizType, bizID); err != nil {
+		return err
+	}
+
+	translation := &model.Translation{
+		BizType:    bizType,
+		BizID:      bizID,
+		Lang:       lang,
+		Title:      strings.TrimSpace(req.Title),
+		Content:    req.Content,
+		CreateUser: userID,
+		UpdateUser: userID,
+	}
+
+	processed := svc.processor.Process(req.Content)
+	if processed.HTML == "" {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "译文内容不能为空")
+	}
+	translation.Content = processed.HTML
+	if bizType == utils.TypeArticle {
+		translation.Brief = strings.TrimSpace(req.Brief)
+		if translation.Brief == "" {
+			translation.Brief = content.Summary(processed.Text, briefMaxLength)
+		}
+	}
+
+	return svc.translationRepo.Save(ctx, translation)
+}
+
+// DeleteTranslation 删除译文
+func (svc *TranslationServiceImpl) DeleteTranslation(ctx context.Context, bizType string, bizID int, lang string) error {
+	deleted, err := svc.translationRepo.Delete(ctx, bizType, bizID, lang)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "译文不存在")
+	}
+	return nil
+}
+
+// ListMissing 分页查询缺少指定语言译文的内容
+func (svc *TranslationServiceImpl) ListMissing(ctx context.Context, bizType string, lang string, page, pageSize int) ([]dto.MissingTranslationResponse, int64, error) {
+	if err := svc.checkLang(lang); err != nil {
+		return nil, 0, err
+	}
+	return svc.translationRepo.ListMissing(ctx, bizType, lang, page, pageSize)
+}
+
+// checkLang 检查译文语言，原文即为默认语言，不能再添加默认语言的译文
+func (svc *TranslationServiceImpl) checkLang(lang string) error {
+	if !utils.IsSupportedLang(lang) {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "不支持的语言: "+lang)
+	}
+	if lang == svc.defaultLang {
+		return utils.NewBusinessError(utils.ErrCodeParamInvalid, "原文即为默认语言，无需添加译文")
+	}
+	return nil
+}
+
+// getSource 查询原文，不存在或已删除时返回业务错误
+func (svc *TranslationServiceImpl) getSource(ctx context.Context, bizType string, bizID int) (*repository.SourceInfo, error) {
+	source, err := svc.translationRepo.GetSource(ctx, bizType, bizID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, utils.NewBusinessError(utils.ErrCodeResourceNotFound, "内容不存在或已被删除")
+	}
+	return source, nil
+}
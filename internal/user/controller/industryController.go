@@ -112,3 +112,23 @@ func (ctr *IndustryController) DeleteIndustry(ctx *gin.Context) {
 	// 返回成功响应
 	ctx.JSON(200, gin.H{"message": "行业删除成功"})
 }
+
+// RestoreIndustry 恢复行业
+func (ctr *IndustryController) RestoreIndustry(ctx *gin.Context) {
+	// 绑定url参数获取行业ID
+	var urlReq dto.IndustryUrlID
+	if !utils.BindUrl(ctx, &urlReq) {
+		return
+	}
+
+	// 调用服务层恢复行业
+	err := ctr.industryService.RestoreIndustry(ctx, urlReq.ID)
+	if err != nil {
+		// 处理异常
+		utils.WrapErrorHandler(ctx, err)
+		return
+	}
+
+	// 返回成功响应
+	ctx.JSON(200, gin.H{"message": "行业恢复成功"})
+}
@@ -111,28 +111,22 @@ func (ctr *UserController) ListAllUsers(ctx *gin.Context) {
 		return
 	}
 
-	// 设置默认分页参数
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.PageSize == 0 {
-		req.PageSize = 10
+	// 设置分页参数，游标优先
+	pg, err := utils.NewPagination(req.Page, req.PageSize, req.Cursor, req.WithTotal)
+	if err != nil {
+		utils.WrapErrorHandler(ctx, err)
+		return
 	}
 
 	// 调用服务获取用户列表
-	users, total, err := ctr.userService.ListAllUsers(ctx, req.Page, req.PageSize, req)
+	users, err := ctr.userService.ListAllUsers(ctx, pg, req)
 	// 处理异常
 	if err != nil {
 		utils.WrapErrorHandler(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"page":      req.Page,
-		"page_size": req.PageSize,
-		"total":     total,
-		"data":      users,
-	})
+	ctx.JSON(http.StatusOK, pg.Response(users))
 }
 
 // BgLogin 后台登录
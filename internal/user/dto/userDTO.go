@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type WxLoginRequest struct {
 	Code string `json:"code" binding:"required"`
 }
@@ -49,6 +51,8 @@ type UserInfoResponse struct {
 type ListUsersRequest struct {
 	Page       int    `form:"page" binding:"omitempty,min=1"`
 	PageSize   int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor     string `form:"cursor" binding:"omitempty,max=200"` // 游标，传入时按游标分页并忽略页码
+	WithTotal  *bool  `form:"with_total"`                         // 是否返回总数，页码分页默认返回，游标分页默认不返回
 	Name       string `form:"name" binding:"omitempty,max=255"`
 	GenderCode string `form:"gender_code" binding:"omitempty,oneof=M F U"`
 	Unit       string `form:"unit" binding:"omitempty,max=255"`
@@ -59,20 +63,21 @@ type ListUsersRequest struct {
 }
 
 type ListUsersResponse struct {
-	UserID       int    `json:"user_id"`
-	Nickname     string `json:"nickname"`
-	AvatarURL    string `json:"avatar_url"`
-	Name         string `json:"name"`
-	GenderCode   string `json:"gender_code"`
-	Gender       string `json:"gender"`
-	PhoneNumber  string `json:"phone_number"`
-	Email        string `json:"email"`
-	Unit         string `json:"unit"`
-	Department   string `json:"department"`
-	Position     string `json:"position"`
-	Industry     string `json:"industry"`
-	IndustryName string `json:"industry_name"`
-	RoleName     string `json:"role_name"`
+	UserID       int       `json:"user_id"`
+	Nickname     string    `json:"nickname"`
+	AvatarURL    string    `json:"avatar_url"`
+	Name         string    `json:"name"`
+	GenderCode   string    `json:"gender_code"`
+	Gender       string    `json:"gender"`
+	PhoneNumber  string    `json:"phone_number"`
+	Email        string    `json:"email"`
+	Unit         string    `json:"unit"`
+	Department   string    `json:"department"`
+	Position     string    `json:"position"`
+	Industry     string    `json:"industry"`
+	IndustryName string    `json:"industry_name"`
+	RoleName     string    `json:"role_name"`
+	CreateTime   time.Time `json:"create_time"`
 }
 
 type CreateAdminRequest struct {
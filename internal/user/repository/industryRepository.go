@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"gorm.io/gorm"
 	"news-release/internal/user/model"
 	"news-release/internal/utils"
+	"time"
 )
 
 type IndustryRepository interface {
@@ -15,6 +17,12 @@ type IndustryRepository interface {
 	CreateIndustry(ctx context.Context, industry *model.Industries) error
 	// UpdateIndustry 更新行业信息
 	UpdateIndustry(ctx context.Context, industryID int, updateFields map[string]interface{}) error
+	// GetIndustryByID 根据ID查询行业，包含已删除的行业
+	GetIndustryByID(ctx context.Context, industryID int) (*model.Industries, error)
+	// ExistsActiveIndustry 检查是否存在编码或名称相同的未删除行业
+	ExistsActiveIndustry(ctx context.Context, industryCode, industryName string) (bool, error)
+	// RestoreIndustry 恢复已删除的行业
+	RestoreIndustry(ctx context.Context, industryID int) error
 }
 
 type IndustryRepositoryImpl struct {
@@ -81,3 +89,48 @@ func (repo *IndustryRepositoryImpl) UpdateIndustry(ctx context.Context, industry
 	}
 	return nil
 }
+
+// GetIndustryByID 根据ID查询行业，包含已删除的行业
+func (repo *IndustryRepositoryImpl) GetIndustryByID(ctx context.Context, industryID int) (*model.Industries, error) {
+	var industry model.Industries
+	if err := repo.db.WithContext(ctx).First(&industry, industryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, utils.NewSystemError(fmt.Errorf("查询行业失败: %w", err))
+	}
+	return &industry, nil
+}
+
+// ExistsActiveIndustry 检查是否存在编码或名称相同的未删除行业
+func (repo *IndustryRepositoryImpl) ExistsActiveIndustry(ctx context.Context, industryCode, industryName string) (bool, error) {
+	var count int64
+	if err := repo.db.WithContext(ctx).
+		Model(&model.Industries{}).
+		Where("(industry_code = ? OR industry_name = ?) AND is_deleted = ?", industryCode, industryName, utils.DeletedFlagNo).
+		Count(&count).Error; err != nil {
+		return false, utils.NewSystemError(fmt.Errorf("检查行业重复失败: %w", err))
+	}
+	return count > 0, nil
+}
+
+// RestoreIndustry 恢复已删除的行业
+func (repo *IndustryRepositoryImpl) RestoreIndustry(ctx context.Context, industryID int) error {
+	result := repo.db.WithContext(ctx).Model(&model.Industries{}).
+		Where("id = ? AND is_deleted = ?", industryID, utils.DeletedFlagYes).
+		Updates(map[string]interface{}{
+			"is_deleted":  utils.DeletedFlagNo,
+			"update_time": time.Now(),
+		})
+	if result.Error != nil {
+		ok, _ := utils.IsUniqueConstraintError(result.Error)
+		if ok {
+			return utils.NewBusinessError(utils.ErrCodeResourceExists, "行业已存在")
+		}
+		return utils.NewSystemError(fmt.Errorf("恢复行业失败: %w", result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return utils.NewBusinessError(utils.ErrCodeResourceConflict, "行业已被恢复，请刷新页面后重试")
+	}
+	return nil
+}
@@ -19,7 +19,7 @@ type UserRepository interface {
 	Update(ctx context.Context, userID int, updateFields map[string]any) error
 	UpdateSessionAndLoginTime(ctx context.Context, userID int, sessionKey string) error
 	GetUserByID(ctx context.Context, userID int) (*dto.UserInfoResponse, error)
-	ListAllUsers(ctx context.Context, page, pageSize int, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, int64, error)
+	ListAllUsers(ctx context.Context, pg *utils.Pagination, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, error)
 	// GetPasswordByPhone 根据手机号获取密码
 	GetPasswordByPhone(ctx context.Context, phoneNumber string) (*model.User, error)
 }
@@ -124,9 +124,8 @@ func (repo *UserRepositoryImpl) Update(ctx context.Context, userID int, updateFi
 }
 
 // ListAllUsers 分页查询用户列表
-func (repo *UserRepositoryImpl) ListAllUsers(ctx context.Context, page, pageSize int, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, int64, error) {
+func (repo *UserRepositoryImpl) ListAllUsers(ctx context.Context, pg *utils.Pagination, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, error) {
 	var users []*dto.ListUsersResponse
-	var total int64
 
 	query := repo.db.WithContext(ctx).Table("users u").
 		Select(`u.user_id, u.nickname, u.avatar_url, u.name, u.gender AS gender_code,
@@ -138,7 +137,7 @@ func (repo *UserRepositoryImpl) ListAllUsers(ctx context.Context, page, pageSize
 					ELSE
 					'未知'
 				END AS gender,
-				u.phone_number, u.email, u.unit, u.department, u.position, u.industry, i.industry_name, ur.role_name`).
+				u.phone_number, u.email, u.unit, u.department, u.position, u.industry, i.industry_name, ur.role_name, u.create_time`).
 		Joins("LEFT JOIN industries i ON u.industry = i.industry_code").
 		Joins("LEFT JOIN user_role ur ON ur.role_code = u.role")
 
@@ -166,17 +165,27 @@ func (repo *UserRepositoryImpl) ListAllUsers(ctx context.Context, page, pageSize
 	}
 
 	// 计算总记录数
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("计算用户总数失败: %w", err))
+	if pg.WithTotal {
+		if err := query.Session(&gorm.Session{}).Count(&pg.Total).Error; err != nil {
+			return nil, utils.NewSystemError(fmt.Errorf("计算用户总数失败: %w", err))
+		}
+	}
+
+	// 按注册时间降序排列，注册时间相同按用户ID降序
+	if pg.Cursor != nil {
+		keyset, args := pg.Cursor.After("u.create_time", "u.user_id", true)
+		query = query.Where(keyset, args...)
 	}
+	query = query.Order("u.create_time DESC").Order("u.user_id DESC")
 
 	// 分页查询
-	offset := (page - 1) * pageSize
-	if err := query.Offset(offset).Limit(pageSize).Find(&users).Error; err != nil {
-		return nil, 0, utils.NewSystemError(fmt.Errorf("查询用户列表失败: %w", err))
+	if err := query.Offset(pg.Offset()).Limit(pg.Limit()).Find(&users).Error; err != nil {
+		return nil, utils.NewSystemError(fmt.Errorf("查询用户列表失败: %w", err))
 	}
 
-	return users, total, nil
+	return utils.TrimPage(pg, users, func(u *dto.ListUsersResponse) utils.Cursor {
+		return utils.Cursor{Time: u.CreateTime, ID: u.UserID}
+	}), nil
 }
 
 // GetPasswordByPhone 根据手机号获取密码
@@ -6,6 +6,7 @@ import (
 	"news-release/internal/user/model"
 	"news-release/internal/user/repository"
 	"news-release/internal/utils"
+	"time"
 )
 
 // IndustryService 服务接口，定义行业相关的业务逻辑方法
@@ -18,6 +19,8 @@ type IndustryService interface {
 	UpdateIndustry(ctx context.Context, industryID int, req dto.UpdateIndustryRequest) error
 	// DeleteIndustry 删除行业
 	DeleteIndustry(ctx context.Context, industryID int) error
+	// RestoreIndustry 恢复已删除的行业
+	RestoreIndustry(ctx context.Context, industryID int) error
 }
 
 // IndustryServiceImpl 实现 IndustryService 接口，提供行业相关的业务逻辑
@@ -65,9 +68,35 @@ func (svc *IndustryServiceImpl) UpdateIndustry(ctx context.Context, industryID i
 
 // DeleteIndustry 删除行业
 func (svc *IndustryServiceImpl) DeleteIndustry(ctx context.Context, industryID int) error {
-	// 执行软删除，直接调用更新过程
+	// 执行软删除，直接调用更新过程，更新时间作为删除时间用于到期清理
 	updateFields := make(map[string]interface{})
 	updateFields["is_deleted"] = utils.DeletedFlagYes
+	updateFields["update_time"] = time.Now()
 
 	return svc.industryRepo.UpdateIndustry(ctx, industryID, updateFields)
 }
+
+// RestoreIndustry 恢复已删除的行业，恢复前重新检查编码和名称是否与未删除的行业重复
+func (svc *IndustryServiceImpl) RestoreIndustry(ctx context.Context, industryID int) error {
+	industry, err := svc.industryRepo.GetIndustryByID(ctx, industryID)
+	if err != nil {
+		return err
+	}
+	if industry == nil {
+		return utils.NewBusinessError(utils.ErrCodeResourceNotFound, "行业不存在")
+	}
+	if industry.IsDeleted != utils.DeletedFlagYes {
+		return utils.NewBusinessError(utils.ErrCodeBusinessLogicError, "行业未被删除，无需恢复")
+	}
+
+	// 删除期间可能已创建编码或名称相同的行业
+	exists, err := svc.industryRepo.ExistsActiveIndustry(ctx, industry.IndustryCode, industry.IndustryName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return utils.NewBusinessError(utils.ErrCodeResourceExists, "已存在编码或名称相同的行业，请修改后重试")
+	}
+
+	return svc.industryRepo.RestoreIndustry(ctx, industryID)
+}
@@ -36,7 +36,7 @@ type UserService interface {
 	Login(ctx context.Context, code string) (string, error)
 	UpdateUserInfo(ctx context.Context, userID int, req dto.UserUpdateRequest) error
 	GetUserByID(ctx context.Context, userID int) (*dto.UserInfoResponse, error)
-	ListAllUsers(ctx context.Context, page, pageSize int, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, int64, error)
+	ListAllUsers(ctx context.Context, pg *utils.Pagination, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, error)
 	// CreateAdminUser 新增管理员
 	CreateAdminUser(ctx context.Context, req dto.CreateAdminRequest, operator int) error
 	// BgLogin 后台登录
@@ -256,8 +256,8 @@ func (svc *UserServiceImpl) GetUserByID(ctx context.Context, userID int) (*dto.U
 }
 
 // ListAllUsers 分页查询用户列表
-func (svc *UserServiceImpl) ListAllUsers(ctx context.Context, page, pageSize int, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, int64, error) {
-	return svc.userRepo.ListAllUsers(ctx, page, pageSize, req)
+func (svc *UserServiceImpl) ListAllUsers(ctx context.Context, pg *utils.Pagination, req dto.ListUsersRequest) ([]*dto.ListUsersResponse, error) {
+	return svc.userRepo.ListAllUsers(ctx, pg, req)
 }
 
 // CreateAdminUser 新增管理员
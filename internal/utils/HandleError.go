@@ -68,13 +68,7 @@ func IsUniqueConstraintError(err error) (bool, string) {
 		return false, ""
 	}
 
-	// 提取底层错误（可能被多次包装）
-	var underlyingErr error
-	if errors.As(err, &underlyingErr) {
-		err = underlyingErr
-	}
-
-	// 适配MySQL
+	// 适配MySQL（errors.As 会沿包装链查找底层错误）
 	var mysqlErr *mysql.MySQLError
 	if errors.As(err, &mysqlErr) {
 		if mysqlErr.Number == 1062 { // MySQL唯一索引冲突错误码
@@ -53,7 +53,7 @@ func BindJSON(ctx *gin.Context, req interface{}) bool {
 			WrapErrorHandler(ctx, err)
 		} else {
 			//err = NewBusinessError(ErrCodeParamBind, "参数绑定失败")
-			err = NewSystemError(fmt.Errorf("参数绑定失败: " + err.Error()))
+			err = NewSystemError(fmt.Errorf("参数绑定失败: %w", err))
 			WrapErrorHandler(ctx, err)
 		}
 		return false
@@ -132,6 +132,8 @@ func GetValidationErrorMsg(err validator.FieldError) string {
 		return err.Field() + "查询范围错误，只能为全部数据或已删除数据"
 	case "user_group_message_type":
 		return err.Field() + "用户群组消息类型错误，必须为系统消息或群组消息"
+	case "slug":
+		return err.Field() + "别名格式错误，只能包含小写字母、数字和连字符，且不能为纯数字"
 	default:
 		return err.Field() + "参数格式错误"
 	}
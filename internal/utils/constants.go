@@ -2,14 +2,17 @@
 package utils
 
 const (
-	DeletedFlagYes    = "Y"       // 软删除标志，表示已删除
-	DeletedFlagNo     = "N"       // 软删除标志，表示未删除
-	TypeEvent         = "EVENT"   // 活动类型常量
-	TypeArticle       = "ARTICLE" // 新闻类型常量
-	TypeGroup         = "GROUP"   // 群组类型常量
-	TypeSystem        = "SYSTEM"  // 系统消息类型常量
-	QueryScopeAll     = "ALL"     // 查询范围常量，表示查询全部
-	QueryScopeDeleted = "DELETED" // 查询范围常量，表示查询
+	DeletedFlagYes    = "Y"            // 软删除标志，表示已删除
+	DeletedFlagNo     = "N"            // 软删除标志，表示未删除
+	TypeEvent         = "EVENT"        // 活动类型常量
+	TypeArticle       = "ARTICLE"      // 新闻类型常量
+	TypeNotice        = "NOTICE"       // 公告类型常量
+	TypeGroup         = "GROUP"        // 群组类型常量
+	TypeSystem        = "SYSTEM"       // 系统消息类型常量
+	TypeRegistration  = "REGISTRATION" // 活动报名类型常量，报名表单上传的文件使用
+	TypeEventSeries   = "EVENT_SERIES" // 活动系列类型常量，系列各场次共用的图片使用
+	QueryScopeAll     = "ALL"          // 查询范围常量，表示查询全部
+	QueryScopeDeleted = "DELETED"      // 查询范围常量，表示查询
 	FlagYes           = "Y"
 	FlagNo            = "N"
 	// 角色常量
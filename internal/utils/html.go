@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	blockTagPattern = regexp.MustCompile(`(?i)</?(p|div|br|li|ul|ol|h[1-6]|tr|td|th|table|blockquote|section)\b[^>]*>`)
+	htmlTagPattern  = regexp.MustCompile(`<[^>]*>`)
+)
+
+// PlainText 去除HTML标签并合并空白字符，块级标签替换为空格以分隔段落
+func PlainText(content string) string {
+	text := blockTagPattern.ReplaceAllString(content, " ")
+	text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, ""))
+	return strings.Join(strings.Fields(text), " ")
+}
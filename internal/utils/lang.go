@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// 支持的内容语言
+const (
+	LangZh = "zh" // 中文
+	LangEn = "en" // 英文
+)
+
+// SupportedLangs 支持的内容语言列表
+var SupportedLangs = []string{LangZh, LangEn}
+
+// IsSupportedLang 判断是否为支持的内容语言
+func IsSupportedLang(lang string) bool {
+	for _, supported := range SupportedLangs {
+		if lang == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLang 获取语言中间件解析出的请求语言，未经过语言中间件时返回空字符串
+func GetLang(ctx *gin.Context) string {
+	lang, exists := ctx.Get("lang")
+	if !exists {
+		return ""
+	}
+	langStr, _ := lang.(string)
+	return langStr
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 分页参数的默认值
+const (
+	DefaultPage     = 1  // 默认页码
+	DefaultPageSize = 10 // 默认页大小
+)
+
+// Cursor 游标分页的位置，记录上一页最后一条数据的排序键，对客户端不透明
+type Cursor struct {
+	Time   time.Time `json:"t"`           // 排序时间，如发布时间、发送时间
+	ID     int       `json:"i"`           // 主键，排序时间相同时按主键排序
+	Pinned bool      `json:"p,omitempty"` // 是否为置顶数据，仅文章列表使用
+	Order  int       `json:"o,omitempty"` // 置顶排序值，仅文章列表使用
+}
+
+// EncodeCursor 将游标编码为URL安全的字符串
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解析客户端传回的游标
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewBusinessError(ErrCodeParamInvalid, "游标无效")
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return nil, NewBusinessError(ErrCodeParamInvalid, "游标无效")
+	}
+	return &c, nil
+}
+
+// After 生成查询排在游标之后数据的条件，desc 表示排序时间是否降序，主键与排序时间同向
+func (c *Cursor) After(timeColumn, idColumn string, desc bool) (string, []interface{}) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return "(" + timeColumn + " " + op + " ? OR (" + timeColumn + " = ? AND " + idColumn + " " + op + " ?))",
+		[]interface{}{c.Time, c.Time, c.ID}
+}
+
+// Pagination 列表分页参数，兼容页码分页和游标分页
+// 传入游标时按游标取下一页，否则按页码分页；两种方式都会返回下一页游标，客户端可从任一页切换到游标分页
+type Pagination struct {
+	Page       int     // 页码，游标分页时忽略
+	PageSize   int     // 页大小
+	Cursor     *Cursor // 上一页最后一条数据的位置，为nil时按页码分页
+	WithTotal  bool    // 是否统计总数
+	Total      int64   // 总数，由数据访问层在 WithTotal 为true时填充
+	NextCursor string  // 下一页游标，没有更多数据时为空
+}
+
+// NewPagination 创建分页参数，页码分页默认统计总数，游标分页默认不统计
+func NewPagination(page, pageSize int, cursor string, withTotal *bool) (*Pagination, error) {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	pg := &Pagination{Page: page, PageSize: pageSize, WithTotal: true}
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pg.Page, pg.Cursor, pg.WithTotal = 0, c, false
+	}
+	if withTotal != nil {
+		pg.WithTotal = *withTotal
+	}
+	return pg, nil
+}
+
+// Offset 返回查询偏移量，游标分页不使用偏移量
+func (pg *Pagination) Offset() int {
+	if pg.Cursor != nil {
+		return 0
+	}
+	return (pg.Page - 1) * pg.PageSize
+}
+
+// Limit 返回查询条数，多查一条用于判断是否还有下一页
+func (pg *Pagination) Limit() int {
+	return pg.PageSize + 1
+}
+
+// Response 生成分页列表的响应体，页码分页保留 total、page 字段，游标分页仅在统计总数时返回 total
+func (pg *Pagination) Response(data interface{}) gin.H {
+	res := gin.H{
+		"page_size":   pg.PageSize,
+		"data":        data,
+		"next_cursor": pg.NextCursor,
+		"has_more":    pg.NextCursor != "",
+	}
+	if pg.Cursor == nil {
+		res["page"] = pg.Page
+	}
+	if pg.WithTotal {
+		res["total"] = pg.Total
+	}
+	return res
+}
+
+// TrimPage 去掉多查询的一条数据，并根据本页最后一条数据生成下一页游标
+func TrimPage[T any](pg *Pagination, rows []T, cursorOf func(T) Cursor) []T {
+	pg.NextCursor = ""
+	if len(rows) <= pg.PageSize {
+		return rows
+	}
+	rows = rows[:pg.PageSize]
+	pg.NextCursor = EncodeCursor(cursorOf(rows[len(rows)-1]))
+	return rows
+}
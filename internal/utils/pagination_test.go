@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// row 按发布时间和主键降序排列的列表数据
+type row struct {
+	ID          int
+	ReleaseTime time.Time
+}
+
+// queryAfter 按 Cursor.After 的条件在内存中查询下一页，模拟数据访问层的降序游标查询
+func queryAfter(rows []row, pg *Pagination) []row {
+	var result []row
+	for _, r := range rows {
+		if c := pg.Cursor; c != nil &&
+			!(r.ReleaseTime.Before(c.Time) || (r.ReleaseTime.Equal(c.Time) && r.ID < c.ID)) {
+			continue
+		}
+		result = append(result, r)
+	}
+	result = result[min(pg.Offset(), len(result)):]
+	return result[:min(pg.Limit(), len(result))]
+}
+
+func TestCursorPagination(t *testing.T) {
+	base := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.Local)
+	// 发布时间相同的数据按主键排序，游标需要同时记录时间和主键才不会漏掉或重复
+	rows := []row{
+		{ID: 7, ReleaseTime: base.Add(3 * time.Hour)},
+		{ID: 6, ReleaseTime: base.Add(2 * time.Hour)},
+		{ID: 5, ReleaseTime: base.Add(2 * time.Hour)},
+		{ID: 4, ReleaseTime: base.Add(2 * time.Hour)},
+		{ID: 9, ReleaseTime: base.Add(time.Hour)},
+		{ID: 3, ReleaseTime: base.Add(time.Hour)},
+		{ID: 1, ReleaseTime: base},
+	}
+	cursorOf := func(r row) Cursor { return Cursor{Time: r.ReleaseTime, ID: r.ID} }
+
+	var got []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(rows) {
+			t.Fatal("游标分页没有结束")
+		}
+		pg, err := NewPagination(1, 2, cursor, nil)
+		if err != nil {
+			t.Fatalf("解析第 %d 页游标失败: %v", pages+1, err)
+		}
+		if pages > 0 && (pg.Cursor == nil || pg.WithTotal) {
+			t.Fatalf("第 %d 页未按游标分页: %+v", pages+1, pg)
+		}
+		page := TrimPage(pg, queryAfter(rows, pg), cursorOf)
+		for _, r := range page {
+			got = append(got, r.ID)
+		}
+		if pg.NextCursor == "" {
+			break
+		}
+		cursor = pg.NextCursor
+	}
+
+	want := []int{7, 6, 5, 4, 9, 3, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("依次翻页得到 %v，期望 %v", got, want)
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	cursorOf := func(id int) Cursor { return Cursor{ID: id} }
+
+	pg := &Pagination{Page: 1, PageSize: 2}
+	if got := TrimPage(pg, []int{1, 2}, cursorOf); len(got) != 2 || pg.NextCursor != "" {
+		t.Errorf("数据不超过页大小时返回 %v，下一页游标 %q，期望没有下一页", got, pg.NextCursor)
+	}
+
+	got := TrimPage(pg, []int{1, 2, 3}, cursorOf)
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("多查询一条时返回 %v，期望 [1 2]", got)
+	}
+	next, err := DecodeCursor(pg.NextCursor)
+	if err != nil || next.ID != 2 {
+		t.Errorf("下一页游标为 %+v，错误 %v，期望指向主键 2", next, err)
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	withTotal := true
+	cursor := EncodeCursor(Cursor{Time: time.Now(), ID: 3})
+
+	tests := []struct {
+		name      string
+		page      int
+		pageSize  int
+		cursor    string
+		withTotal *bool
+		wantPage  int
+		wantSize  int
+		wantTotal bool
+		wantErr   bool
+	}{
+		{name: "页码分页使用默认值", wantPage: DefaultPage, wantSize: DefaultPageSize, wantTotal: true},
+		{name: "页码分页", page: 3, pageSize: 20, wantPage: 3, wantSize: 20, wantTotal: true},
+		{name: "游标分页忽略页码且默认不统计总数", page: 3, pageSize: 20, cursor: cursor, wantSize: 20},
+		{name: "游标分页指定统计总数", cursor: cursor, withTotal: &withTotal, wantSize: DefaultPageSize, wantTotal: true},
+		{name: "游标不是base64", cursor: "%%%", wantErr: true},
+		{name: "游标缺少主键", cursor: EncodeCursor(Cursor{Time: time.Now()}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, err := NewPagination(tt.page, tt.pageSize, tt.cursor, tt.withTotal)
+			if tt.wantErr {
+				if _, ok := GetBusinessError(err); !ok {
+					t.Fatalf("期望返回业务错误，实际为 %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("返回错误: %v", err)
+			}
+			if pg.Page != tt.wantPage || pg.PageSize != tt.wantSize || pg.WithTotal != tt.wantTotal {
+				t.Errorf("分页参数为 %+v，期望页码 %d，页大小 %d，统计总数 %v", pg, tt.wantPage, tt.wantSize, tt.wantTotal)
+			}
+			if pg.Offset() != max(tt.wantPage-1, 0)*tt.wantSize {
+				t.Errorf("偏移量为 %d", pg.Offset())
+			}
+		})
+	}
+}
+
+func TestCursorAfter(t *testing.T) {
+	c := &Cursor{Time: time.Date(2026, time.April, 1, 9, 0, 0, 0, time.Local), ID: 5}
+	tests := []struct {
+		desc bool
+		want string
+	}{
+		{desc: true, want: "(release_time < ? OR (release_time = ? AND id < ?))"},
+		{desc: false, want: "(release_time > ? OR (release_time = ? AND id > ?))"},
+	}
+	for _, tt := range tests {
+		query, args := c.After("release_time", "id", tt.desc)
+		if query != tt.want {
+			t.Errorf("降序为 %v 时查询条件为 %s，期望 %s", tt.desc, query, tt.want)
+		}
+		if !reflect.DeepEqual(args, []interface{}{c.Time, c.Time, c.ID}) {
+			t.Errorf("查询参数为 %v", args)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SlugMaxLength 别名最大长度
+const SlugMaxLength = 200
+
+var (
+	slugPattern     = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	slugWordPattern = regexp.MustCompile(`[a-z0-9]+`)
+)
+
+// IsValidSlug 判断别名是否合法：小写字母、数字和连字符组成，且不能为纯数字，避免与数字ID混淆
+func IsValidSlug(slug string) bool {
+	if len(slug) > SlugMaxLength || !slugPattern.MatchString(slug) {
+		return false
+	}
+	_, err := strconv.Atoi(slug)
+	return err != nil
+}
+
+// GenerateSlug 从标题中提取英文字母和数字生成别名，无法生成合法别名时返回空字符串
+func GenerateSlug(title string) string {
+	words := slugWordPattern.FindAllString(strings.ToLower(title), -1)
+	slug := strings.Join(words, "-")
+	if len(slug) > SlugMaxLength {
+		slug = strings.TrimRight(slug[:SlugMaxLength], "-")
+	}
+	if !IsValidSlug(slug) {
+		return ""
+	}
+	return slug
+}
+
+// maxSlugAttempts 生成别名时追加序号的最大尝试次数
+const maxSlugAttempts = 20
+
+// UniqueSlug 根据标题生成别名，与已有别名重复时依次追加 -2、-3 等序号
+// taken 判断别名是否已被其他数据占用；标题无法生成别名或重复次数过多时返回空字符串
+func UniqueSlug(title string, taken func(slug string) (bool, error)) (string, error) {
+	base := GenerateSlug(title)
+	if base == "" {
+		return "", nil
+	}
+	for i := 1; i <= maxSlugAttempts; i++ {
+		candidate := base
+		if i > 1 {
+			suffix := "-" + strconv.Itoa(i)
+			candidate = strings.TrimRight(base[:min(len(base), SlugMaxLength-len(suffix))], "-") + suffix
+		}
+		occupied, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !occupied {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// TruncateRunes 按字符数截断文本，超出部分以省略号代替
+func TruncateRunes(text string, maxRunes int) string {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxRunes]) + "…"
+}
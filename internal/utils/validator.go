@@ -44,6 +44,11 @@ func RegisterCustomValidators(v *validator.Validate) {
 		panic("注册用户群组消息类型验证失败: " + err.Error())
 	}
 
+	// 别名验证
+	if err := v.RegisterValidation("slug", validateSlug); err != nil {
+		panic("注册别名验证失败: " + err.Error())
+	}
+
 	// 其他自定义规则...
 }
 
@@ -149,3 +154,12 @@ func validateUserGroupMessageType(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+// 别名验证实现
+func validateSlug(fl validator.FieldLevel) bool {
+	fieldValue := fl.Field().String()
+	if fieldValue == "" {
+		return true // 空值通过，表示不设置别名
+	}
+	return IsValidSlug(fieldValue)
+}
@@ -0,0 +1,9 @@
+-- 文章草稿、审核、定时发布流程
+-- 已有文章均视为已发布，避免升级后从前台列表中消失
+ALTER TABLE articles
+    ADD COLUMN status         VARCHAR(20)  NOT NULL DEFAULT 'DRAFT' COMMENT '文章状态' AFTER article_source,
+    ADD COLUMN review_comment VARCHAR(500) NULL COMMENT '审核意见' AFTER status,
+    ADD COLUMN review_user    INT          NULL COMMENT '审核人ID' AFTER review_comment,
+    ADD COLUMN review_time    DATETIME(3)  NULL COMMENT '审核时间' AFTER review_user;
+
+UPDATE articles SET status = 'PUBLISHED';
@@ -0,0 +1,20 @@
+-- 文章修订历史
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id              INT          NOT NULL AUTO_INCREMENT,
+    article_id      INT          NOT NULL,
+    revision_no     INT          NOT NULL COMMENT '文章内的修订版本号，从1开始递增',
+    action          VARCHAR(20)  NOT NULL COMMENT '操作类型',
+    restore_from    INT          NOT NULL DEFAULT 0 COMMENT '回滚操作对应的来源修订ID',
+    article_title   VARCHAR(255) NULL,
+    article_type    VARCHAR(255) NULL,
+    brief_content   TEXT         NULL,
+    article_content MEDIUMTEXT   NULL,
+    is_selection    INT          NULL,
+    field_type      VARCHAR(255) NULL,
+    cover_image_url VARCHAR(255) NULL,
+    article_source  VARCHAR(255) NULL,
+    edit_user       INT          NULL COMMENT '编辑人ID',
+    create_time     DATETIME(3)  NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_revision (article_id, revision_no)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章修订记录';
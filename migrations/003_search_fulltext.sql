@@ -0,0 +1,4 @@
+-- 文章、活动、公告的全文检索索引，使用 ngram 分词以支持中文
+ALTER TABLE articles ADD FULLTEXT INDEX ft_article_search (article_title, brief_content, article_content) WITH PARSER ngram;
+ALTER TABLE events ADD FULLTEXT INDEX ft_event_search (title, detail) WITH PARSER ngram;
+ALTER TABLE notices ADD FULLTEXT INDEX ft_notice_search (title, content) WITH PARSER ngram;
@@ -0,0 +1,21 @@
+-- 文章标签
+CREATE TABLE IF NOT EXISTS tags (
+    tag_id      INT         NOT NULL AUTO_INCREMENT,
+    tag_name    VARCHAR(50) NOT NULL,
+    is_deleted  VARCHAR(5)  NOT NULL DEFAULT 'N' COMMENT '软删除标志',
+    create_time DATETIME(3) NULL,
+    update_time DATETIME(3) NULL,
+    create_user INT         NULL COMMENT '创建人ID',
+    update_user INT         NULL COMMENT '最后更新人ID',
+    PRIMARY KEY (tag_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章标签';
+
+CREATE TABLE IF NOT EXISTS article_tag_mappings (
+    id          INT         NOT NULL AUTO_INCREMENT,
+    article_id  INT         NOT NULL,
+    tag_id      INT         NOT NULL,
+    create_time DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_tag (article_id, tag_id),
+    KEY idx_tag_id (tag_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章与标签的关联';
@@ -0,0 +1,34 @@
+-- 文章浏览量、点赞和收藏
+ALTER TABLE articles
+    ADD COLUMN view_count     BIGINT NOT NULL DEFAULT 0 COMMENT '浏览量' AFTER review_time,
+    ADD COLUMN like_count     BIGINT NOT NULL DEFAULT 0 COMMENT '点赞数' AFTER view_count,
+    ADD COLUMN favorite_count BIGINT NOT NULL DEFAULT 0 COMMENT '收藏数' AFTER like_count;
+
+CREATE TABLE IF NOT EXISTS article_view_logs (
+    id           INT         NOT NULL AUTO_INCREMENT,
+    article_id   INT         NOT NULL,
+    viewer_key   VARCHAR(64) NOT NULL COMMENT '浏览者标识，登录用户为 user:ID，匿名用户为 ip:地址',
+    window_start DATETIME(3) NOT NULL COMMENT '去重时间窗口的起始时间',
+    create_time  DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_viewer_window (article_id, viewer_key, window_start)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章浏览记录';
+
+CREATE TABLE IF NOT EXISTS article_likes (
+    id          INT         NOT NULL AUTO_INCREMENT,
+    article_id  INT         NOT NULL,
+    user_id     INT         NOT NULL,
+    create_time DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_like (article_id, user_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章点赞记录';
+
+CREATE TABLE IF NOT EXISTS article_favorites (
+    id          INT         NOT NULL AUTO_INCREMENT,
+    article_id  INT         NOT NULL,
+    user_id     INT         NOT NULL,
+    create_time DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_favorite (article_id, user_id),
+    KEY idx_favorite_user (user_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章收藏记录';
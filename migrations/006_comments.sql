@@ -0,0 +1,23 @@
+-- 文章评论
+CREATE TABLE IF NOT EXISTS comments (
+    id                INT           NOT NULL AUTO_INCREMENT,
+    article_id        INT           NOT NULL,
+    parent_id         INT           NOT NULL DEFAULT 0 COMMENT '被回复的评论ID，顶层评论为0',
+    root_id           INT           NOT NULL DEFAULT 0 COMMENT '所属顶层评论ID，顶层评论为0',
+    reply_to_user     INT           NOT NULL DEFAULT 0 COMMENT '被回复的用户ID',
+    content           VARCHAR(1000) NOT NULL COMMENT '评论内容',
+    status            VARCHAR(20)   NOT NULL COMMENT '评论状态',
+    hit_words         VARCHAR(500)  NULL COMMENT '命中的敏感词，以英文逗号分隔',
+    moderation_reason VARCHAR(500)  NULL COMMENT '驳回或隐藏原因',
+    moderate_user     INT           NULL COMMENT '审核人ID',
+    moderate_time     DATETIME(3)   NULL COMMENT '审核时间',
+    is_deleted        VARCHAR(5)    NOT NULL DEFAULT 'N' COMMENT '软删除标志',
+    create_time       DATETIME(3)   NULL,
+    update_time       DATETIME(3)   NULL,
+    create_user       INT           NULL COMMENT '评论人ID',
+    update_user       INT           NULL COMMENT '最后更新人ID',
+    PRIMARY KEY (id),
+    KEY idx_article_status (article_id, status),
+    KEY idx_root_id (root_id),
+    KEY idx_create_user (create_user)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章评论';
@@ -0,0 +1,12 @@
+-- 文章和活动的别名及SEO信息，未设置别名时为NULL，不占用唯一索引
+ALTER TABLE articles
+    ADD COLUMN slug             VARCHAR(200) NULL DEFAULT NULL COMMENT '别名' AFTER favorite_count,
+    ADD COLUMN meta_description VARCHAR(300) NULL COMMENT 'SEO描述' AFTER slug,
+    ADD COLUMN og_image_url     VARCHAR(500) NULL COMMENT 'Open Graph 分享图片' AFTER meta_description,
+    ADD UNIQUE KEY uk_article_slug (slug);
+
+ALTER TABLE events
+    ADD COLUMN slug             VARCHAR(200) NULL DEFAULT NULL COMMENT '别名' AFTER cover_image_url,
+    ADD COLUMN meta_description VARCHAR(300) NULL COMMENT 'SEO描述' AFTER slug,
+    ADD COLUMN og_image_url     VARCHAR(500) NULL COMMENT 'Open Graph 分享图片' AFTER meta_description,
+    ADD UNIQUE KEY uk_event_slug (slug);
@@ -0,0 +1,11 @@
+-- 富文本提取的纯文本列，全文检索索引改为建立在纯文本列上
+-- 新增列为NULL，由后台定时任务补全；补全完成前历史数据只能按标题和摘要检索
+ALTER TABLE articles
+    ADD COLUMN content_text MEDIUMTEXT NULL COMMENT '正文纯文本，用于全文检索' AFTER article_content,
+    DROP INDEX ft_article_search;
+ALTER TABLE articles ADD FULLTEXT INDEX ft_article_search (article_title, brief_content, content_text) WITH PARSER ngram;
+
+ALTER TABLE events
+    ADD COLUMN detail_text MEDIUMTEXT NULL COMMENT '活动详情纯文本，用于全文检索' AFTER detail,
+    DROP INDEX ft_event_search;
+ALTER TABLE events ADD FULLTEXT INDEX ft_event_search (title, detail_text) WITH PARSER ngram;
@@ -0,0 +1,17 @@
+-- 领域和文章类型的后台管理，编码唯一，支持排序和软删除
+-- 添加唯一索引前请确认已有数据中没有重复的编码
+ALTER TABLE article_types
+    MODIFY COLUMN type_code VARCHAR(50)  NULL COMMENT '类型编码',
+    MODIFY COLUMN type_name VARCHAR(255) NULL,
+    ADD COLUMN sort_order  INT        NOT NULL DEFAULT 0 COMMENT '排序值，越小越靠前' AFTER type_name,
+    ADD COLUMN is_deleted  VARCHAR(5) NOT NULL DEFAULT 'N' COMMENT '软删除标志' AFTER sort_order,
+    ADD COLUMN create_user INT        NULL COMMENT '创建人ID',
+    ADD COLUMN update_user INT        NULL COMMENT '最后更新人ID',
+    ADD UNIQUE KEY uk_article_type_code (type_code);
+
+ALTER TABLE field_types
+    MODIFY COLUMN field_code VARCHAR(50) NULL COMMENT '领域编码',
+    ADD COLUMN sort_order  INT NOT NULL DEFAULT 0 COMMENT '排序值，越小越靠前' AFTER field_name,
+    ADD COLUMN create_user INT NULL COMMENT '创建人ID',
+    ADD COLUMN update_user INT NULL COMMENT '最后更新人ID',
+    ADD UNIQUE KEY uk_field_code (field_code);
@@ -0,0 +1,16 @@
+-- 文章、活动、公告的译文
+CREATE TABLE IF NOT EXISTS translations (
+    id          INT          NOT NULL AUTO_INCREMENT,
+    biz_type    VARCHAR(20)  NOT NULL COMMENT '业务类型：ARTICLE、EVENT、NOTICE',
+    biz_id      INT          NOT NULL COMMENT '业务ID',
+    lang        VARCHAR(10)  NOT NULL COMMENT '语言',
+    title       VARCHAR(255) NOT NULL COMMENT '标题',
+    brief       TEXT         NULL COMMENT '摘要，仅文章使用',
+    content     MEDIUMTEXT   NULL COMMENT '文章正文、活动详情或公告内容',
+    create_time DATETIME(3)  NULL,
+    update_time DATETIME(3)  NULL,
+    create_user INT          NULL COMMENT '创建人ID',
+    update_user INT          NULL COMMENT '最后更新人ID',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_translation (biz_type, biz_id, lang)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '译文';
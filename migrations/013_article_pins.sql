@@ -0,0 +1,15 @@
+-- 文章置顶
+CREATE TABLE IF NOT EXISTS article_pins (
+    id          INT         NOT NULL AUTO_INCREMENT,
+    article_id  INT         NOT NULL,
+    scope       VARCHAR(20) NOT NULL COMMENT '置顶范围',
+    scope_value VARCHAR(50) NOT NULL COMMENT '范围值，首页为空字符串',
+    sort_order  INT         NOT NULL DEFAULT 0 COMMENT '排序值，越小越靠前',
+    expire_time DATETIME(3) NULL COMMENT '置顶到期时间，为空表示长期置顶',
+    create_time DATETIME(3) NULL,
+    update_time DATETIME(3) NULL,
+    create_user INT         NULL COMMENT '创建人ID',
+    update_user INT         NULL COMMENT '最后更新人ID',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_article_pin (scope, scope_value, article_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '文章置顶';
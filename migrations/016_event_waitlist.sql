@@ -0,0 +1,7 @@
+-- 活动人数上限和候补名单，已有报名均为正式报名
+ALTER TABLE events
+    ADD COLUMN max_participants INT NOT NULL DEFAULT 0 COMMENT '报名人数上限，0表示不限制' AFTER registration_fee;
+
+ALTER TABLE event_user_mappings
+    ADD COLUMN status        VARCHAR(20) NOT NULL DEFAULT 'REGISTERED' COMMENT '报名状态' AFTER event_id,
+    ADD COLUMN waitlist_time DATETIME(3) NULL COMMENT '进入候补名单的时间' AFTER status;
@@ -0,0 +1,6 @@
+-- 活动报名表单和报名答案
+ALTER TABLE events
+    ADD COLUMN registration_form JSON NULL COMMENT '报名表单' AFTER max_participants;
+
+ALTER TABLE event_user_mappings
+    ADD COLUMN answers JSON NULL COMMENT '报名表单附加问题的答案' AFTER waitlist_time;
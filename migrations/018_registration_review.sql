@@ -0,0 +1,8 @@
+-- 活动报名审核
+ALTER TABLE events
+    ADD COLUMN require_approval VARCHAR(5) NOT NULL DEFAULT 'N' COMMENT '报名是否需要审核' AFTER registration_form;
+
+ALTER TABLE event_user_mappings
+    ADD COLUMN review_reason VARCHAR(255) NULL COMMENT '审核意见' AFTER waitlist_time,
+    ADD COLUMN review_time   DATETIME(3)  NULL COMMENT '审核时间' AFTER review_reason,
+    ADD COLUMN review_user   INT          NULL COMMENT '审核人ID' AFTER review_time;
@@ -0,0 +1,4 @@
+-- 活动签到
+ALTER TABLE event_user_mappings
+    ADD COLUMN check_in_time DATETIME(3) NULL COMMENT '签到时间，未签到时为空' AFTER answers,
+    ADD COLUMN check_in_user INT         NULL COMMENT '扫码签到的工作人员ID' AFTER check_in_time;
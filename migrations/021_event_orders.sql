@@ -0,0 +1,24 @@
+-- 收费活动的报名订单
+CREATE TABLE IF NOT EXISTS event_orders (
+    id              INT          NOT NULL AUTO_INCREMENT,
+    order_no        VARCHAR(32)  NOT NULL COMMENT '订单号',
+    event_id        INT          NOT NULL COMMENT '活动ID',
+    user_id         INT          NOT NULL COMMENT '用户ID',
+    amount          INT          NOT NULL COMMENT '订单金额，单位分',
+    status          VARCHAR(20)  NOT NULL COMMENT '订单状态',
+    expire_time     DATETIME(3)  NOT NULL COMMENT '支付截止时间',
+    provider        VARCHAR(20)  NULL COMMENT '支付渠道',
+    trade_no        VARCHAR(64)  NULL COMMENT '支付渠道的交易号',
+    paid_time       DATETIME(3)  NULL COMMENT '支付时间',
+    refund_no       VARCHAR(32)  NULL COMMENT '退款单号',
+    refund_trade_no VARCHAR(64)  NULL COMMENT '支付渠道的退款交易号',
+    refund_amount   INT          NOT NULL DEFAULT 0 COMMENT '退款金额，单位分',
+    refund_reason   VARCHAR(255) NULL COMMENT '退款原因',
+    refund_time     DATETIME(3)  NULL COMMENT '退款完成时间',
+    create_time     DATETIME(3)  NULL,
+    update_time     DATETIME(3)  NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_order_no (order_no),
+    KEY idx_order_event_user (event_id, user_id),
+    KEY idx_order_status_expire (status, expire_time)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '活动报名订单';
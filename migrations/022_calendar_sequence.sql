@@ -0,0 +1,3 @@
+-- 日历修订序号，已订阅的日历根据序号判断活动是否变更
+ALTER TABLE events
+    ADD COLUMN sequence INT NOT NULL DEFAULT 0 COMMENT '日历修订序号' AFTER og_image_url;
@@ -0,0 +1,24 @@
+-- 重复活动系列，各场次为普通活动，通过 series_id 关联系列
+CREATE TABLE IF NOT EXISTS event_series (
+    id                INT            NOT NULL AUTO_INCREMENT,
+    title             VARCHAR(255)   NOT NULL COMMENT '系列标题',
+    detail            MEDIUMTEXT     NULL COMMENT '活动详情',
+    recurrence_rule   VARCHAR(255)   NOT NULL COMMENT '重复规则，RRULE格式的子集',
+    event_address     VARCHAR(255)   NULL COMMENT '活动地址',
+    registration_fee  DECIMAL(10, 2) NULL COMMENT '每场的报名费用',
+    max_participants  INT            NOT NULL DEFAULT 0 COMMENT '每场的报名人数上限，0表示不限制',
+    require_approval  VARCHAR(5)     NOT NULL DEFAULT 'N' COMMENT '报名是否需要审核',
+    registration_form JSON           NULL COMMENT '报名表单',
+    cover_image_url   VARCHAR(255)   NULL COMMENT '封面图片URL',
+    is_deleted        VARCHAR(5)     NOT NULL DEFAULT 'N' COMMENT '软删除标志',
+    create_time       DATETIME(3)    NULL,
+    update_time       DATETIME(3)    NULL,
+    create_user       INT            NULL COMMENT '创建人ID',
+    update_user       INT            NULL COMMENT '最后更新人ID',
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '活动系列';
+
+ALTER TABLE events
+    ADD COLUMN series_id         INT        NOT NULL DEFAULT 0 COMMENT '所属活动系列ID，0表示不属于任何系列' AFTER sequence,
+    ADD COLUMN series_overridden VARCHAR(5) NOT NULL DEFAULT 'N' COMMENT '是否单独修改过' AFTER series_id,
+    ADD KEY idx_event_series (series_id);
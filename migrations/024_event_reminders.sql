@@ -0,0 +1,19 @@
+-- 活动提醒发送记录和定时任务锁
+CREATE TABLE IF NOT EXISTS event_reminders (
+    id             INT         NOT NULL AUTO_INCREMENT,
+    event_id       INT         NOT NULL COMMENT '活动ID',
+    reminder_type  VARCHAR(20) NOT NULL COMMENT '提醒类型',
+    offset_minutes INT         NOT NULL COMMENT '提前提醒的分钟数',
+    target_time    DATETIME(3) NOT NULL COMMENT '提醒对应的活动开始时间或报名截止时间',
+    message_id     INT         NOT NULL DEFAULT 0 COMMENT '发送的消息ID',
+    create_time    DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_event_reminder (event_id, reminder_type, offset_minutes, target_time)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '活动提醒记录';
+
+CREATE TABLE IF NOT EXISTS scheduler_locks (
+    name        VARCHAR(64)  NOT NULL COMMENT '锁名称',
+    owner       VARCHAR(128) NOT NULL COMMENT '持有锁的实例标识',
+    expire_time DATETIME(3)  NOT NULL COMMENT '锁过期时间',
+    PRIMARY KEY (name)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '定时任务锁';
@@ -0,0 +1,4 @@
+-- 活动取消
+ALTER TABLE events
+    ADD COLUMN is_cancelled  VARCHAR(5)   NOT NULL DEFAULT 'N' COMMENT '是否已取消' AFTER series_overridden,
+    ADD COLUMN cancel_reason VARCHAR(255) NULL COMMENT '取消原因' AFTER is_cancelled;
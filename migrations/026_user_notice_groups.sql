@@ -0,0 +1,4 @@
+-- 个人通知群组：活动审核结果、取消通知等只有本人可见的通知发送到用户的个人通知群组
+ALTER TABLE user_message_groups
+    ADD COLUMN owner_user_id INT NOT NULL DEFAULT 0 COMMENT '个人通知群组所属的用户ID，为0时为普通群组' AFTER event_id,
+    ADD INDEX idx_owner_user_id (owner_user_id);
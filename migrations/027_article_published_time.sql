@@ -0,0 +1,5 @@
+-- 文章首次发布时间，已发布的文章修改后重新审核通过时保留原发布时间
+ALTER TABLE articles
+    ADD COLUMN published_time DATETIME(3) NULL COMMENT '首次发布时间' AFTER review_time;
+
+UPDATE articles SET published_time = release_time WHERE status IN ('PUBLISHED', 'ARCHIVED');